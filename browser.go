@@ -1,7 +1,6 @@
 //go:generate go run ./lib/utils/setup
 //go:generate go run ./lib/proto/generate
 //go:generate go run ./lib/js/generate
-//go:generate go run ./lib/assets/generate
 //go:generate go run ./lib/utils/lint
 
 // Package rod is a high-level driver directly based on DevTools Protocol.
@@ -9,14 +8,19 @@ package rod
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
 	"reflect"
-	"strings"
+	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/devices"
+	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -43,19 +47,55 @@ type Browser struct {
 
 	sleeper func() utils.Sleeper
 
+	// the sleeper before the Browser.DefaultTimeout wraps it, so that the timeouts won't nest
+	baseSleeper func() utils.Sleeper
+
 	logger utils.Logger
 
 	slowMotion time.Duration // see defaults.slow
 	trace      bool          // see defaults.Trace
 	monitor    string
 
+	actionTimeout time.Duration // see Browser.ActionTimeout
+	downloadTTL   time.Duration // see Browser.DownloadTTL
+
+	failOnHTTPError bool // see Browser.FailOnHTTPError
+
+	monitorAssets http.FileSystem // see Browser.MonitorAssets
+
+	monitorControl bool // see Browser.MonitorControl
+
 	defaultDevice devices.Device
 
 	controlURL  string
+	selenium    *launcher.SeleniumSession // see defaults.Selenium
 	client      CDPClient
 	event       *goob.Observable // all the browser events from cdp client
 	targetsLock *sync.Mutex
 
+	// the attached pages, the key is the session id. The events of a session are dispatched
+	// to its page directly, so pages don't have to filter the events of the entire browser.
+	sessions     *sync.Map
+	sessionsLock *sync.Mutex // guards the writes of sessions
+
+	onDisconnect func() // see Browser.OnDisconnect
+
+	onTargetCreated   func(*proto.TargetTargetInfo) // see Browser.OnTargetCreated
+	onTargetDestroyed func(proto.TargetTargetID)    // see Browser.OnTargetDestroyed
+	onTargetChanged   func(*proto.TargetTargetInfo) // see Browser.OnTargetChanged
+
+	onContextCreated  func(proto.BrowserBrowserContextID) // see Browser.OnContextCreated
+	onContextDisposed func(proto.BrowserBrowserContextID) // see Browser.OnContextDisposed
+
+	helpers []*js.Function // see Browser.Helpers
+
+	blankPages *blankPages // see Browser.ReusePages
+
+	downloads *sync.Map // see Browser.GetDownload, the key is the GUID of the download
+
+	beforeAction func(*Action) // see Browser.BeforeAction
+	afterAction  func(*Action) // see Browser.AfterAction
+
 	// stores all the previous cdp call of same type. Browser doesn't have enough API
 	// for us to retrieve all its internal states. This is an workaround to map them to local.
 	// For example you can't use cdp API to get the current position of mouse.
@@ -76,6 +116,10 @@ func New() *Browser {
 		logger:        DefaultLogger,
 		defaultDevice: devices.LaptopWithMDPIScreen.Landscape(),
 		targetsLock:   &sync.Mutex{},
+		sessions:      &sync.Map{},
+		sessionsLock:  &sync.Mutex{},
+		downloads:     &sync.Map{},
+		downloadTTL:   time.Minute,
 		states:        &sync.Map{},
 	}).WithPanic(utils.Panic)
 }
@@ -90,6 +134,10 @@ func (b *Browser) Incognito() (*Browser, error) {
 	incognito := *b
 	incognito.BrowserContextID = res.BrowserContextID
 
+	if b.onContextCreated != nil {
+		b.onContextCreated(res.BrowserContextID)
+	}
+
 	return &incognito, nil
 }
 
@@ -105,6 +153,44 @@ func (b *Browser) SlowMotion(delay time.Duration) *Browser {
 	return b
 }
 
+// ActionTimeout limits how long the input actions, such as [Element.Click] and [Element.Input],
+// wait for the element to be actionable, see [Element.WaitActionable]. The default 0 means no limit,
+// the actions only stop waiting when the context of the element is done.
+func (b *Browser) ActionTimeout(d time.Duration) *Browser {
+	b.actionTimeout = d
+	return b
+}
+
+// DownloadTTL is how long the record of a completed or canceled download is kept for [Browser.GetDownload]
+// if it's never read, so that the records won't grow forever. The default is 1 minute.
+func (b *Browser) DownloadTTL(d time.Duration) *Browser {
+	b.downloadTTL = d
+	return b
+}
+
+// FailOnHTTPError makes [Page.Navigate] and [Page.NavigateWithResponse] return [ErrHTTPStatus] when the status code
+// of the main document is 4xx or 5xx, instead of rendering the error page silently, such as "404 Not Found".
+// It's useful for the scrapers to not parse the error pages. It's disabled by default.
+func (b *Browser) FailOnHTTPError(enable bool) *Browser {
+	b.failOnHTTPError = enable
+	return b
+}
+
+// ReusePages makes [Page.Close] keep at most limit pages as blank pages instead of closing them,
+// then [Browser.Page] reuses them to save the time of creating new targets, it's useful for the scrapers
+// that open and close a lot of pages. The default 0 disables it.
+// A reused page is attached with a new session, so the event listeners, hijack routers, and scripts of
+// [Page.EvalOnNewDocument] of the previous owner don't apply to it, but the states of the target are kept,
+// such as the history and the session storage, so only enable it when the pages are used in the same way.
+func (b *Browser) ReusePages(limit int) *Browser {
+	b.blankPages = &blankPages{
+		limit:   limit,
+		pages:   map[proto.BrowserBrowserContextID][]proto.TargetTargetID{},
+		closing: map[proto.TargetTargetID]bool{},
+	}
+	return b
+}
+
 // Trace enables/disables the visual tracing of the input actions on the page
 func (b *Browser) Trace(enable bool) *Browser {
 	b.trace = enable
@@ -117,12 +203,100 @@ func (b *Browser) Monitor(url string) *Browser {
 	return b
 }
 
+// MonitorAssets overrides the html of the monitor server with the files in fs, such as to brand it or to
+// add custom panels. The "monitor.html" replaces the page list, the "monitor-page.html" replaces the page viewer,
+// the missing ones fallback to the built-in ones.
+// All the files in fs are also served under the "/assets/" path, so the html can load its own js, css, or images.
+func (b *Browser) MonitorAssets(fs http.FileSystem) *Browser {
+	b.monitorAssets = fs
+	return b
+}
+
+// MonitorControl enables the control endpoints of the monitor server, such as to navigate a page or eval js on it,
+// see [Browser.ServeMonitor]. They are disabled by default because anyone who can reach them can run js in the browser.
+func (b *Browser) MonitorControl(enable bool) *Browser {
+	b.monitorControl = enable
+	return b
+}
+
+// Helpers injects the custom js helpers, such as selector engines or domain-specific utilities, into every js context
+// of the pages and iframes alongside the built-in helpers. So the helpers can use each other via the "functions" object
+// without declaring the Dependencies, such as "functions.myHelper()". Use [EvalHelper] to call them.
+// It panics if the name of a helper is used by a built-in one.
+func (b *Browser) Helpers(list ...*js.Function) *Browser {
+	for _, fn := range list {
+		if js.Builtin(fn.Name) {
+			panic("the name of the js helper is used by a built-in one: " + fn.Name)
+		}
+	}
+
+	b.helpers = append(append([]*js.Function{}, b.helpers...), list...)
+	return b
+}
+
 // Logger overrides the default log functions for tracing
 func (b *Browser) Logger(l utils.Logger) *Browser {
 	b.logger = l
 	return b
 }
 
+// OnDisconnect sets the callback to run when the connection to the browser is lost,
+// such as the browser crashed or closed. It won't be called if the Browser.Context is canceled.
+// All the pending calls will fail, you can use it to create a new Browser to replace the current one.
+// Rod doesn't reconnect by itself, the sessions of the pages and their enabled domains end with the connection.
+func (b *Browser) OnDisconnect(fn func()) *Browser {
+	b.onDisconnect = fn
+	return b
+}
+
+// OnTargetCreated sets the hook to run when a target is created, such as a tab, a popup, or a worker.
+// It should be set before [Browser.Connect]. It's safe to call the browser in the hook, such as to close the target.
+func (b *Browser) OnTargetCreated(fn func(*proto.TargetTargetInfo)) *Browser {
+	b.onTargetCreated = fn
+	return b
+}
+
+// OnTargetDestroyed sets the hook to run when a target is destroyed.
+// It should be set before [Browser.Connect].
+func (b *Browser) OnTargetDestroyed(fn func(proto.TargetTargetID)) *Browser {
+	b.onTargetDestroyed = fn
+	return b
+}
+
+// OnTargetChanged sets the hook to run when the info of a target changes, such as the url or title.
+// It should be set before [Browser.Connect].
+func (b *Browser) OnTargetChanged(fn func(*proto.TargetTargetInfo)) *Browser {
+	b.onTargetChanged = fn
+	return b
+}
+
+// OnContextCreated sets the hook to run when a browser context is created by [Browser.Incognito].
+func (b *Browser) OnContextCreated(fn func(proto.BrowserBrowserContextID)) *Browser {
+	b.onContextCreated = fn
+	return b
+}
+
+// OnContextDisposed sets the hook to run when a browser context is disposed by [Browser.DisposeContext],
+// such as when an incognito browser is closed.
+func (b *Browser) OnContextDisposed(fn func(proto.BrowserBrowserContextID)) *Browser {
+	b.onContextDisposed = fn
+	return b
+}
+
+// BeforeAction sets the hook to run before each high-level action, such as [Page.Navigate], [Page.Eval],
+// [Element.Click], and [Element.Input]. It applies to all the pages of the browser.
+func (b *Browser) BeforeAction(fn func(*Action)) *Browser {
+	b.beforeAction = fn
+	return b
+}
+
+// AfterAction sets the hook to run after each high-level action, the [Action.Duration] and [Action.Err] are set.
+// Such as use it to take a screenshot when an action fails.
+func (b *Browser) AfterAction(fn func(*Action)) *Browser {
+	b.afterAction = fn
+	return b
+}
+
 // Client set the cdp client
 func (b *Browser) Client(c CDPClient) *Browser {
 	b.client = c
@@ -137,15 +311,62 @@ func (b *Browser) DefaultDevice(d devices.Device) *Browser {
 	return b
 }
 
+// DefaultTimeout limits the time of each retry-based operation of the browser and all the pages created after it,
+// such as [Page.Element] and [Element.WaitVisible]. The operation fails with [context.DeadlineExceeded] when the
+// timeout is reached. Use [Page.Sleeper] to override it, or [Page.Timeout] to limit the total time of chained operations.
+// Calling it again replaces the previous timeout, 0 removes it.
+func (b *Browser) DefaultTimeout(d time.Duration) *Browser {
+	if b.baseSleeper == nil {
+		b.baseSleeper = b.sleeper
+	}
+
+	sleeper := b.baseSleeper
+	if d <= 0 {
+		b.sleeper = sleeper
+		return b
+	}
+
+	b.sleeper = func() utils.Sleeper {
+		return utils.EachSleepers(utils.TimeoutSleeper(d), sleeper())
+	}
+	return b
+}
+
 // NoDefaultDevice is the same as [Browser.DefaultDevice](devices.Clear)
 func (b *Browser) NoDefaultDevice() *Browser {
 	return b.DefaultDevice(devices.Clear)
 }
 
 // Connect to the browser and start to control it.
+// If the [defaults.Manager] is set, the browser will be launched remotely via the launcher.Manager.
+// If the [defaults.Selenium] is set, a session will be created on the Selenium Grid, [Browser.Close] deletes it.
 // If fails to connect, try to launch a local browser, if local browser not found try to download one.
 func (b *Browser) Connect() error {
-	if b.client == nil {
+	if b.client == nil && b.controlURL == "" && defaults.Manager != "" {
+		l, err := launcher.NewManaged(defaults.Manager)
+		if err != nil {
+			return err
+		}
+
+		c, err := l.Context(b.ctx).Client()
+		if err != nil {
+			return err
+		}
+		b.client = c
+	} else if b.client == nil && b.controlURL == "" && defaults.Selenium != "" {
+		s, err := launcher.NewSeleniumSession(b.ctx, defaults.Selenium, nil)
+		if err != nil {
+			return err
+		}
+
+		c, err := cdp.StartWithURL(b.ctx, s.ControlURL, nil)
+		if err != nil {
+			_ = s.Close()
+			return err
+		}
+		b.client = c
+		b.selenium = s
+	} else if b.client == nil {
 		u := b.controlURL
 		if u == "" {
 			var err error
@@ -173,16 +394,73 @@ func (b *Browser) Connect() error {
 	return proto.TargetSetDiscoverTargets{Discover: true}.Call(b)
 }
 
-// Close the browser
+// Close the browser gracefully. It doesn't wait for the browser process to exit,
+// use [launcher.Launcher.WaitOrKill] to make sure the process is gone.
 func (b *Browser) Close() error {
 	if b.BrowserContextID == "" {
-		return proto.BrowserClose{}.Call(b)
+		err := proto.BrowserClose{}.Call(b)
+		if b.selenium != nil {
+			_ = b.selenium.Close()
+		}
+		return err
+	}
+	return b.DisposeContext(b.BrowserContextID)
+}
+
+// DisposeContext closes all the pages of the browser context with the id, then disposes the context.
+// Unlike calling [proto.TargetDisposeBrowserContext] directly, the cached pages of the context are cleaned up,
+// so the ongoing actions on them are aborted and no tab or state leaks.
+func (b *Browser) DisposeContext(id proto.BrowserBrowserContextID) error {
+	targets, err := proto.TargetGetTargets{}.Call(b)
+	if err != nil {
+		return err
+	}
+
+	for _, info := range targets.TargetInfos {
+		if info.BrowserContextID != id || info.Type != proto.TargetTargetInfoTypePage {
+			continue
+		}
+
+		_, err := proto.TargetCloseTarget{TargetID: info.TargetID}.Call(b)
+		if err != nil {
+			return err
+		}
+
+		if p := b.loadCachedPage(info.TargetID); p != nil {
+			p.sessionCancel()
+			p.cleanupStates()
+		}
+	}
+
+	if b.blankPages != nil {
+		b.blankPages.clear(id)
+	}
+
+	err = proto.TargetDisposeBrowserContext{BrowserContextID: id}.Call(b)
+	if err != nil {
+		return err
+	}
+
+	if b.onContextDisposed != nil {
+		b.onContextDisposed(id)
 	}
-	return proto.TargetDisposeBrowserContext{BrowserContextID: b.BrowserContextID}.Call(b)
+	return nil
 }
 
 // Page creates a new browser tab. If opts.URL is empty, the default target will be "about:blank".
+// If [Browser.ReusePages] is enabled and opts only has the URL, a blank page closed before may be reused.
 func (b *Browser) Page(opts proto.TargetCreateTarget) (p *Page, err error) {
+	if p := b.reuseBlankPage(opts); p != nil {
+		if opts.URL != "" {
+			err = p.Navigate(opts.URL)
+			if err != nil {
+				_ = p.Close()
+				return nil, err
+			}
+		}
+		return p, nil
+	}
+
 	req := opts
 	req.BrowserContextID = b.BrowserContextID
 	req.URL = "about:blank"
@@ -202,6 +480,7 @@ func (b *Browser) Page(opts proto.TargetCreateTarget) (p *Page, err error) {
 	if err != nil {
 		return
 	}
+	p.beginFrameControl = opts.EnableBeginFrameControl
 
 	if opts.URL == "" {
 		return
@@ -256,6 +535,7 @@ func (b *Browser) PageFromSession(sessionID proto.TargetSessionID) *Page {
 		sleeper:       b.sleeper,
 		browser:       b,
 		SessionID:     sessionID,
+		lifecycle:     &lifecycleEvents{},
 	}
 }
 
@@ -277,27 +557,33 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 		return nil, err
 	}
 
+	return b.newPage(targetID, session.SessionID)
+}
+
+// create a page from an attached session and cache it, the targetsLock should be held
+func (b *Browser) newPage(targetID proto.TargetTargetID, sessionID proto.TargetSessionID) (*Page, error) {
 	sessionCtx, cancel := context.WithCancel(b.ctx)
 
-	page = &Page{
+	page := &Page{
 		e:             b.e,
 		ctx:           sessionCtx,
 		sessionCancel: cancel,
 		sleeper:       b.sleeper,
 		browser:       b,
 		TargetID:      targetID,
-		SessionID:     session.SessionID,
+		SessionID:     sessionID,
 		FrameID:       proto.PageFrameID(targetID),
 		jsCtxLock:     &sync.Mutex{},
 		jsCtxID:       new(proto.RuntimeRemoteObjectID),
 		helpersLock:   &sync.Mutex{},
+		lifecycle:     &lifecycleEvents{},
 	}
 
 	page.root = page
-	page.newKeyboard().newMouse().newTouch()
+	page.newKeyboard().newMouse().newTouch().newPen()
 
 	if !b.defaultDevice.IsClear() {
-		err = page.Emulate(b.defaultDevice)
+		err := page.Emulate(b.defaultDevice)
 		if err != nil {
 			return nil, err
 		}
@@ -314,6 +600,75 @@ func (b *Browser) PageFromTarget(targetID proto.TargetTargetID) (*Page, error) {
 	return page, nil
 }
 
+// AutoAttach makes the browser attach to each new page, such as a popup, and pause it before it runs any script.
+// Then fn will be called with the page, the page resumes after fn returns. So the init scripts or hijack rules
+// that fn sets apply from the very first request of the page. Call stop to disable it.
+func (b *Browser) AutoAttach(fn func(*Page)) (stop func() error, err error) {
+	origin := b
+	b, cancel := b.WithCancel()
+
+	wait := b.EachEvent(func(e *proto.TargetAttachedToTarget) {
+		go origin.handleAutoAttached(e, fn)
+	})
+
+	err = proto.TargetSetAutoAttach{
+		AutoAttach:             true,
+		WaitForDebuggerOnStart: true,
+		Flatten:                true,
+	}.Call(b)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go wait()
+
+	return func() error {
+		cancel()
+		return proto.TargetSetAutoAttach{}.Call(origin)
+	}, nil
+}
+
+func (b *Browser) handleAutoAttached(e *proto.TargetAttachedToTarget, fn func(*Page)) {
+	session := b.PageFromSession(e.SessionID)
+
+	// the existing targets are attached too, we don't need the extra sessions
+	if !e.WaitingForDebugger {
+		_ = proto.TargetDetachFromTarget{SessionID: e.SessionID}.Call(b)
+		return
+	}
+
+	if e.TargetInfo.Type != proto.TargetTargetInfoTypePage {
+		_ = proto.RuntimeRunIfWaitingForDebugger{}.Call(session)
+		return
+	}
+
+	b.targetsLock.Lock()
+	page := b.loadCachedPage(e.TargetInfo.TargetID)
+	cached := page != nil
+	if !cached {
+		var err error
+		page, err = b.newPage(e.TargetInfo.TargetID, e.SessionID)
+		if err != nil {
+			b.targetsLock.Unlock()
+			_ = proto.RuntimeRunIfWaitingForDebugger{}.Call(session)
+			return
+		}
+	}
+	b.targetsLock.Unlock()
+
+	defer func() {
+		_ = proto.RuntimeRunIfWaitingForDebugger{}.Call(session)
+
+		// the target is already attached, such as by PageFromTarget, so the page is reused and the extra session is dropped
+		if cached {
+			_ = proto.TargetDetachFromTarget{SessionID: e.SessionID}.Call(b)
+		}
+	}()
+
+	fn(page)
+}
+
 // EachEvent is similar to [Page.EachEvent], but catches events of the entire browser.
 func (b *Browser) EachEvent(callbacks ...interface{}) (wait func()) {
 	return b.eachEvent("", callbacks...)
@@ -321,13 +676,22 @@ func (b *Browser) EachEvent(callbacks ...interface{}) (wait func()) {
 
 // WaitEvent waits for the next event for one time. It will also load the data into the event object.
 func (b *Browser) WaitEvent(e proto.Event) (wait func()) {
-	return b.waitEvent("", e)
+	return b.waitEvent("", e, nil)
+}
+
+// WaitEventUntil is similar to [Browser.WaitEvent], but it skips the events until match returns true.
+// Each event is loaded into e before match is called, so match can check the fields of e, such as:
+//
+//	e := &proto.TargetTargetCreated{}
+//	wait := browser.WaitEventUntil(e, func() bool { return e.TargetInfo.URL == "https://a.com/" })
+func (b *Browser) WaitEventUntil(e proto.Event, match func() bool) (wait func()) {
+	return b.waitEvent("", e, match)
 }
 
-// waits for the next event for one time. It will also load the data into the event object.
-func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event) (wait func()) {
+// waits for the next event that matches for one time. It will also load the data into the event object.
+// If match is nil, the first event matches.
+func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event, match func() bool) (wait func()) {
 	valE := reflect.ValueOf(e)
-	valTrue := reflect.ValueOf(true)
 
 	if valE.Kind() != reflect.Ptr {
 		valE = reflect.New(valE.Type())
@@ -337,12 +701,12 @@ func (b *Browser) waitEvent(sessionID proto.TargetSessionID, e proto.Event) (wai
 	//
 	// func(ee proto.Event) bool {
 	//   *e = *ee
-	//   return true
+	//   return match()
 	// }
-	fnType := reflect.FuncOf([]reflect.Type{valE.Type()}, []reflect.Type{valTrue.Type()}, false)
+	fnType := reflect.FuncOf([]reflect.Type{valE.Type()}, []reflect.Type{reflect.TypeOf(true)}, false)
 	fnVal := reflect.MakeFunc(fnType, func(args []reflect.Value) []reflect.Value {
 		valE.Elem().Set(args[0].Elem())
-		return []reflect.Value{valTrue}
+		return []reflect.Value{reflect.ValueOf(match == nil || match())}
 	})
 
 	return b.eachEvent(sessionID, fnVal.Interface())
@@ -439,19 +803,75 @@ func (b *Browser) initEvents() {
 	b.event = goob.New(ctx)
 	event := b.client.Event()
 
+	b.watchTargets()
+
 	go func() {
 		defer cancel()
 		for e := range event {
-			b.event.Publish(&Message{
+			msg := &Message{
 				SessionID: proto.TargetSessionID(e.SessionID),
 				Method:    e.Method,
 				lock:      &sync.Mutex{},
 				data:      e.Params,
-			})
+			}
+			b.event.Publish(msg)
+			b.dispatch(msg)
+			b.recordDownload(msg)
+		}
+
+		if b.onDisconnect != nil && ctx.Err() == nil {
+			b.onDisconnect()
 		}
 	}()
 }
 
+// run the target hooks in a separate goroutine, so that the hooks can call the browser
+func (b *Browser) watchTargets() {
+	if b.onTargetCreated == nil && b.onTargetDestroyed == nil && b.onTargetChanged == nil {
+		return
+	}
+
+	wait := b.eachEvent("", func(e *proto.TargetTargetCreated) {
+		if b.onTargetCreated != nil {
+			b.onTargetCreated(e.TargetInfo)
+		}
+	}, func(e *proto.TargetTargetDestroyed) {
+		if b.onTargetDestroyed != nil {
+			b.onTargetDestroyed(e.TargetID)
+		}
+	}, func(e *proto.TargetTargetInfoChanged) {
+		if b.onTargetChanged != nil {
+			b.onTargetChanged(e.TargetInfo)
+		}
+	})
+
+	go wait()
+}
+
+// dispatch the msg to the page it belongs to
+func (b *Browser) dispatch(msg *Message) {
+	detached := proto.TargetDetachedFromTarget{}
+	destroyed := proto.TargetTargetDestroyed{}
+
+	switch {
+	case msg.Load(&detached):
+		if p, ok := b.sessions.Load(detached.SessionID); ok {
+			p.(*Page).sessionCancel()
+		}
+	case msg.Load(&destroyed):
+		b.sessions.Range(func(_, p interface{}) bool {
+			if p.(*Page).TargetID == destroyed.TargetID {
+				p.(*Page).sessionCancel()
+			}
+			return true
+		})
+	default:
+		if p, ok := b.sessions.Load(msg.SessionID); ok {
+			p.(*Page).event.Publish(msg)
+		}
+	}
+}
+
 func (b *Browser) pageInfo(id proto.TargetTargetID) (*proto.TargetTargetInfo, error) {
 	res, err := proto.TargetGetTargetInfo{TargetID: id}.Call(b)
 	if err != nil {
@@ -460,14 +880,12 @@ func (b *Browser) pageInfo(id proto.TargetTargetID) (*proto.TargetTargetInfo, er
 	return res.TargetInfo, nil
 }
 
-func (b *Browser) isHeadless() (enabled bool) {
-	res, _ := proto.BrowserGetBrowserCommandLine{}.Call(b)
-	for _, v := range res.Arguments {
-		if strings.Contains(v, "headless") {
-			return true
-		}
+func (b *Browser) isHeadless() (bool, error) {
+	mode, err := b.HeadlessMode()
+	if err != nil {
+		return false, err
 	}
-	return false
+	return mode != HeadlessModeNone, nil
 }
 
 // IgnoreCertErrors switch. If enabled, all certificate errors will be ignored.
@@ -485,11 +903,18 @@ func (b *Browser) GetCookies() ([]*proto.NetworkCookie, error) {
 }
 
 // SetCookies to the browser. If the cookies is nil it will clear all the cookies.
+// The cookies that the browser would silently drop, such as SameSite=None without Secure,
+// are rejected with [ErrInvalidCookie].
 func (b *Browser) SetCookies(cookies []*proto.NetworkCookieParam) error {
 	if cookies == nil {
 		return proto.StorageClearCookies{BrowserContextID: b.BrowserContextID}.Call(b)
 	}
 
+	err := validateCookies(cookies)
+	if err != nil {
+		return err
+	}
+
 	return proto.StorageSetCookies{
 		Cookies:          cookies,
 		BrowserContextID: b.BrowserContextID,
@@ -500,39 +925,288 @@ func (b *Browser) SetCookies(cookies []*proto.NetworkCookieParam) error {
 // The file path will be:
 //
 //	filepath.Join(dir, info.GUID)
+//
+// It listens to the Browser domain download events if [Features.BrowserDownloadEvents] is supported,
+// otherwise the deprecated Page domain ones.
 func (b *Browser) WaitDownload(dir string) func() (info *proto.PageDownloadWillBegin) {
-	var oldDownloadBehavior proto.BrowserSetDownloadBehavior
-	has := b.LoadState("", &oldDownloadBehavior)
+	features, restore := b.setDownloadBehavior(dir)
 
-	_ = proto.BrowserSetDownloadBehavior{
+	var start *proto.PageDownloadWillBegin
+	var waitProgress func()
+
+	if features.BrowserDownloadEvents {
+		waitProgress = b.EachEvent(func(e *proto.BrowserDownloadWillBegin) {
+			start = &proto.PageDownloadWillBegin{
+				FrameID:           e.FrameID,
+				GUID:              e.GUID,
+				URL:               e.URL,
+				SuggestedFilename: e.SuggestedFilename,
+			}
+		}, func(e *proto.BrowserDownloadProgress) bool {
+			return start != nil && start.GUID == e.GUID && e.State == proto.BrowserDownloadProgressStateCompleted
+		})
+	} else {
+		waitProgress = b.EachEvent(func(e *proto.PageDownloadWillBegin) {
+			start = e
+		}, func(e *proto.PageDownloadProgress) bool {
+			return start != nil && start.GUID == e.GUID && e.State == proto.PageDownloadProgressStateCompleted
+		})
+	}
+
+	return func() *proto.PageDownloadWillBegin {
+		defer restore()
+
+		waitProgress()
+
+		return start
+	}
+}
+
+// Download is a file downloaded by the browser, see [Browser.WaitDownloads]
+type Download struct {
+	*proto.PageDownloadWillBegin
+
+	// Path of the file, it's empty if the download is canceled
+	Path string
+
+	// Canceled is true if the download is canceled by the page or the browser
+	Canceled bool
+
+	done bool
+}
+
+// WaitDownloads returns a channel that emits the next n downloads whose url matches the pattern,
+// the pattern is the same as the one of [HijackRouter.Add]. Unlike [Browser.WaitDownload],
+// the downloads can run concurrently, each one is emitted once it's completed or canceled.
+// The channel will be closed after n downloads are emitted or the context of b is canceled,
+// if n <= 0 it only stops when the context is canceled, and the channel should be kept reading or it will
+// block the other events of b. The file path will be:
+//
+//	filepath.Join(dir, download.GUID)
+func (b *Browser) WaitDownloads(dir, pattern string, n int) <-chan *Download {
+	reg := regexp.MustCompile(proto.PatternToReg(pattern))
+	features, restore := b.setDownloadBehavior(dir)
+
+	if n < 0 {
+		n = 0
+	}
+
+	ch := make(chan *Download, n)
+	started := map[string]*proto.PageDownloadWillBegin{}
+	count := 0
+
+	begin := func(e *proto.PageDownloadWillBegin) {
+		if reg.MatchString(e.URL) {
+			started[e.GUID] = e
+		}
+	}
+	progress := func(guid string, completed, canceled bool) bool {
+		start, has := started[guid]
+		if !has || !(completed || canceled) {
+			return false
+		}
+		delete(started, guid)
+
+		d := &Download{PageDownloadWillBegin: start, Canceled: canceled}
+		if completed {
+			d.Path = filepath.Join(dir, guid)
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return true
+		case ch <- d:
+		}
+
+		count++
+		return count == n
+	}
+
+	var wait func()
+	if features.BrowserDownloadEvents {
+		wait = b.EachEvent(func(e *proto.BrowserDownloadWillBegin) {
+			begin(&proto.PageDownloadWillBegin{
+				FrameID:           e.FrameID,
+				GUID:              e.GUID,
+				URL:               e.URL,
+				SuggestedFilename: e.SuggestedFilename,
+			})
+		}, func(e *proto.BrowserDownloadProgress) bool {
+			return progress(e.GUID,
+				e.State == proto.BrowserDownloadProgressStateCompleted,
+				e.State == proto.BrowserDownloadProgressStateCanceled)
+		})
+	} else {
+		wait = b.EachEvent(begin, func(e *proto.PageDownloadProgress) bool {
+			return progress(e.GUID,
+				e.State == proto.PageDownloadProgressStateCompleted,
+				e.State == proto.PageDownloadProgressStateCanceled)
+		})
+	}
+
+	go func() {
+		defer close(ch)
+		defer restore()
+		wait()
+	}()
+
+	return ch
+}
+
+// SetDownloadDir saves the downloads of the browser context into dir, each file is named by the GUID of
+// its download. Give each incognito browser its own dir, so that the concurrent pages won't overwrite
+// each other's downloads. Use [Browser.GetDownload] to map a GUID back to its suggested filename.
+func (b *Browser) SetDownloadDir(dir string) error {
+	features, err := b.Features()
+	if err != nil {
+		return err
+	}
+
+	return proto.BrowserSetDownloadBehavior{
 		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
 		BrowserContextID: b.BrowserContextID,
 		DownloadPath:     dir,
+		EventsEnabled:    features.BrowserDownloadEvents,
 	}.Call(b)
+}
 
-	var start *proto.PageDownloadWillBegin
+// GetDownload returns the download of the guid, the Path is located in the download dir that the browser context
+// used when the download began, see [Browser.SetDownloadDir]. It returns nil if the download hasn't started yet.
+// Once a completed or canceled download is returned, it's removed from the records,
+// otherwise it's removed after the [Browser.DownloadTTL].
+func (b *Browser) GetDownload(guid string) *Download {
+	d, has := b.downloads.Load(guid)
+	if !has {
+		return nil
+	}
+	download := *d.(*Download)
+
+	if download.done {
+		b.downloads.Delete(guid)
+	}
+
+	return &download
+}
 
-	waitProgress := b.EachEvent(func(e *proto.PageDownloadWillBegin) {
-		start = e
-	}, func(e *proto.PageDownloadProgress) bool {
-		return start != nil && start.GUID == e.GUID && e.State == proto.PageDownloadProgressStateCompleted
+// records the downloads of all the browser contexts for Browser.GetDownload
+func (b *Browser) recordDownload(msg *Message) {
+	begin := proto.BrowserDownloadWillBegin{}
+	pageBegin := proto.PageDownloadWillBegin{}
+	progress := proto.BrowserDownloadProgress{}
+	pageProgress := proto.PageDownloadProgress{}
+
+	switch {
+	case msg.Load(&begin):
+		b.beginDownload(&proto.PageDownloadWillBegin{
+			FrameID:           begin.FrameID,
+			GUID:              begin.GUID,
+			URL:               begin.URL,
+			SuggestedFilename: begin.SuggestedFilename,
+		})
+	case msg.Load(&pageBegin):
+		b.beginDownload(&pageBegin)
+	case msg.Load(&progress):
+		b.endDownload(progress.GUID,
+			progress.State == proto.BrowserDownloadProgressStateCompleted,
+			progress.State == proto.BrowserDownloadProgressStateCanceled)
+	case msg.Load(&pageProgress):
+		b.endDownload(pageProgress.GUID,
+			pageProgress.State == proto.PageDownloadProgressStateCompleted,
+			pageProgress.State == proto.PageDownloadProgressStateCanceled)
+	}
+}
+
+func (b *Browser) beginDownload(e *proto.PageDownloadWillBegin) {
+	d := &Download{PageDownloadWillBegin: e}
+	if dir := b.downloadDir(e.FrameID); dir != "" {
+		d.Path = filepath.Join(dir, e.GUID)
+	}
+	b.downloads.Store(e.GUID, d)
+}
+
+func (b *Browser) endDownload(guid string, completed, canceled bool) {
+	if !completed && !canceled {
+		return
+	}
+
+	d, has := b.downloads.Load(guid)
+	if !has {
+		return
+	}
+
+	download := *d.(*Download)
+	download.done = true
+	if canceled {
+		download.Path = ""
+		download.Canceled = true
+	}
+	b.downloads.Store(guid, &download)
+
+	time.AfterFunc(b.downloadTTL, func() {
+		if d, _ := b.downloads.Load(guid); d == &download {
+			b.downloads.Delete(guid)
+		}
 	})
+}
 
-	return func() *proto.PageDownloadWillBegin {
-		defer func() {
-			if has {
-				_ = oldDownloadBehavior.Call(b)
-			} else {
-				_ = proto.BrowserSetDownloadBehavior{
-					Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDefault,
-					BrowserContextID: b.BrowserContextID,
-				}.Call(b)
+// downloadDir returns the download dir of the browser context that the frame belongs to.
+// If the frame isn't a cached page, the dir is used only when a single browser context has set one.
+func (b *Browser) downloadDir(frameID proto.PageFrameID) string {
+	method := (proto.BrowserSetDownloadBehavior{}).ProtoReq()
+
+	if p := b.loadCachedPage(proto.TargetTargetID(frameID)); p != nil {
+		behavior := proto.BrowserSetDownloadBehavior{}
+		if p.browser.LoadState("", &behavior) {
+			return behavior.DownloadPath
+		}
+		return ""
+	}
+
+	dirs := map[string]bool{}
+	b.states.Range(func(key, value interface{}) bool {
+		if k, ok := key.(stateKey); ok && k.sessionID == "" && k.methodName == method {
+			behavior := reflect.Indirect(reflect.ValueOf(value)).Interface().(proto.BrowserSetDownloadBehavior)
+			if dir := behavior.DownloadPath; dir != "" {
+				dirs[dir] = true
 			}
-		}()
+		}
+		return true
+	})
 
-		waitProgress()
+	if len(dirs) == 1 {
+		for dir := range dirs {
+			return dir
+		}
+	}
+	return ""
+}
 
-		return start
+// setDownloadBehavior saves the downloads into dir, the restore func sets the behavior back
+func (b *Browser) setDownloadBehavior(dir string) (features *Features, restore func()) {
+	var oldDownloadBehavior proto.BrowserSetDownloadBehavior
+	has := b.LoadState("", &oldDownloadBehavior)
+
+	features, err := b.Features()
+	if err != nil {
+		features = &Features{}
+	}
+
+	_ = proto.BrowserSetDownloadBehavior{
+		Behavior:         proto.BrowserSetDownloadBehaviorBehaviorAllowAndName,
+		BrowserContextID: b.BrowserContextID,
+		DownloadPath:     dir,
+		EventsEnabled:    features.BrowserDownloadEvents,
+	}.Call(b)
+
+	return features, func() {
+		if has {
+			_ = oldDownloadBehavior.Call(b)
+		} else {
+			_ = proto.BrowserSetDownloadBehavior{
+				Behavior:         proto.BrowserSetDownloadBehaviorBehaviorDefault,
+				BrowserContextID: b.BrowserContextID,
+			}.Call(b)
+		}
 	}
 }
 
@@ -540,3 +1214,121 @@ func (b *Browser) WaitDownload(dir string) func() (info *proto.PageDownloadWillB
 func (b *Browser) Version() (*proto.BrowserGetVersionResult, error) {
 	return proto.BrowserGetVersion{}.Call(b)
 }
+
+// MajorVersion of the browser, such as 120 for "HeadlessChrome/120.0.6099.0".
+// It's useful to check if a feature is supported by the browser.
+func (b *Browser) MajorVersion() (int, error) {
+	v, err := b.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	m := regMajorVersion.FindStringSubmatch(v.Product)
+	if m == nil {
+		return 0, fmt.Errorf("failed to parse the browser version: %s", v.Product)
+	}
+
+	return strconv.Atoi(m[1])
+}
+
+var regMajorVersion = regexp.MustCompile(`/(\d+)\.`)
+
+// blankPages is the pool of the closed targets that are navigated to about:blank, grouped by browser context
+type blankPages struct {
+	lock    sync.Mutex
+	limit   int
+	pages   map[proto.BrowserBrowserContextID][]proto.TargetTargetID
+	closing map[proto.TargetTargetID]bool // the targets that are being recycled
+}
+
+func (bp *blankPages) size() int {
+	n := len(bp.closing)
+	for _, list := range bp.pages {
+		n += len(list)
+	}
+	return n
+}
+
+func (bp *blankPages) clear(id proto.BrowserBrowserContextID) {
+	bp.lock.Lock()
+	defer bp.lock.Unlock()
+	delete(bp.pages, id)
+}
+
+func (bp *blankPages) pop(id proto.BrowserBrowserContextID) proto.TargetTargetID {
+	bp.lock.Lock()
+	defer bp.lock.Unlock()
+
+	list := bp.pages[id]
+	if len(list) == 0 {
+		return ""
+	}
+	bp.pages[id] = list[:len(list)-1]
+
+	return list[len(list)-1]
+}
+
+// reuseBlankPage attaches a new session to a target in the pool, it returns nil if there's none
+func (b *Browser) reuseBlankPage(opts proto.TargetCreateTarget) *Page {
+	if b.blankPages == nil || opts != (proto.TargetCreateTarget{URL: opts.URL}) {
+		return nil
+	}
+
+	for {
+		id := b.blankPages.pop(b.BrowserContextID)
+		if id == "" {
+			return nil
+		}
+
+		p, err := b.PageFromTarget(id)
+		if err == nil {
+			return p
+		}
+		_, _ = proto.TargetCloseTarget{TargetID: id}.Call(b)
+	}
+}
+
+// recycleBlankPage navigates the page to about:blank, detaches its session, and puts the target into the pool.
+// It returns false if the page should be closed as usual.
+func (b *Browser) recycleBlankPage(p *Page) bool {
+	if b.blankPages == nil || p.IsIframe() || p.root == nil {
+		return false
+	}
+
+	bp := b.blankPages
+	bp.lock.Lock()
+
+	// the page is already closed, such as Close is called twice
+	if bp.closing[p.TargetID] || b.loadCachedPage(p.TargetID) != p.root {
+		bp.lock.Unlock()
+		return true
+	}
+
+	if bp.size() >= bp.limit {
+		bp.lock.Unlock()
+		return false
+	}
+	bp.closing[p.TargetID] = true
+	bp.lock.Unlock()
+
+	_ = p.StopLoading()
+	res, err := proto.PageNavigate{URL: "about:blank"}.Call(p)
+	if err != nil || res.ErrorText != "" {
+		bp.lock.Lock()
+		delete(bp.closing, p.TargetID)
+		bp.lock.Unlock()
+		return false
+	}
+
+	// the listeners and states of the previous owner end with the session
+	_ = proto.TargetDetachFromTarget{SessionID: p.SessionID}.Call(b)
+	p.sessionCancel()
+	p.cleanupStates()
+
+	bp.lock.Lock()
+	defer bp.lock.Unlock()
+	delete(bp.closing, p.TargetID)
+	bp.pages[b.BrowserContextID] = append(bp.pages[b.BrowserContextID], p.TargetID)
+
+	return true
+}
@@ -1,17 +1,23 @@
 package rod_test
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
@@ -40,6 +46,95 @@ func TestIncognito(t *testing.T) {
 	})
 }
 
+func TestBrowserReusePages(t *testing.T) {
+	g := setup(t)
+
+	b := g.browser.MustIncognito().ReusePages(1)
+	defer b.MustClose()
+
+	p := b.MustPage(g.srcFile("fixtures/click.html"))
+	id := p.TargetID
+	p.MustClose()
+	g.Eq(p.MustInfo().URL, "about:blank")
+
+	// the session of the previous owner is detached
+	g.Err(p.GetContext().Err())
+
+	// closing twice won't put it into the pool twice
+	p.MustClose()
+
+	// the pool is full
+	other := b.MustPage()
+	other2 := b.MustPage()
+	g.Eq(other.TargetID, id)
+	g.Neq(other2.TargetID, id)
+	g.Neq(other.SessionID, p.SessionID)
+	other.MustClose()
+	other2.MustClose()
+	g.Err(other2.Info())
+
+	// only the default options can reuse the page
+	p = b.MustPage(g.blank())
+	g.Eq(p.TargetID, id)
+	p.MustClose()
+	p, err := b.Page(proto.TargetCreateTarget{Background: true})
+	g.E(err)
+	g.Neq(p.TargetID, id)
+	p.MustClose()
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	g.Err(b.Page(proto.TargetCreateTarget{URL: g.blank()}))
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	b.MustPage().MustClose()
+}
+
+func TestBrowserDisposeContext(t *testing.T) {
+	g := setup(t)
+
+	created := []proto.BrowserBrowserContextID{}
+	disposed := []proto.BrowserBrowserContextID{}
+
+	b := *g.browser
+	b.OnContextCreated(func(id proto.BrowserBrowserContextID) {
+		created = append(created, id)
+	}).OnContextDisposed(func(id proto.BrowserBrowserContextID) {
+		disposed = append(disposed, id)
+	})
+
+	incognito := b.MustIncognito()
+	g.Eq(created, []proto.BrowserBrowserContextID{incognito.BrowserContextID})
+
+	p1 := incognito.MustPage(g.blank())
+	p2 := incognito.MustPage(g.blank())
+
+	b.MustDisposeContext(incognito.BrowserContextID)
+	g.Eq(disposed, created)
+
+	g.Err(p1.GetContext().Err())
+	g.Err(p2.GetContext().Err())
+	for _, info := range g.browser.MustPages() {
+		g.Neq(info.TargetID, p1.TargetID)
+		g.Neq(info.TargetID, p2.TargetID)
+	}
+
+	incognito = b.MustIncognito()
+	incognito.MustPage()
+	incognito.MustClose()
+	g.Len(disposed, 2)
+
+	g.mc.stubErr(1, proto.TargetGetTargets{})
+	g.Err(b.DisposeContext("id"))
+
+	incognito = b.MustIncognito()
+	defer incognito.MustClose()
+	incognito.MustPage()
+	g.mc.stubErr(1, proto.TargetCloseTarget{})
+	g.Err(incognito.Close())
+
+	g.Err(b.DisposeContext("not-exists"))
+}
+
 func TestBrowserResetControlURL(_ *testing.T) {
 	rod.New().ControlURL("test").ControlURL("")
 }
@@ -259,12 +354,210 @@ func TestTestTry(t *testing.T) {
 	g.Eq(errVal.Unwrap().Error(), "t")
 }
 
+func TestErrorIs(t *testing.T) {
+	g := setup(t)
+
+	list := []error{
+		&rod.ErrElementNotFound{},
+		&rod.ErrPageCloseCanceled{},
+		&rod.ErrNotInteractable{},
+		&rod.ErrPageNotFound{},
+		&rod.ErrPageClosed{},
+	}
+
+	for i, err := range list {
+		wrapped := fmt.Errorf("wrapped: %w", err)
+		for j, target := range list {
+			g.Eq(errors.Is(wrapped, target), i == j)
+		}
+	}
+}
+
 func TestBrowserOthers(t *testing.T) {
 	g := setup(t)
 
 	g.browser.Timeout(time.Second).CancelTimeout().MustGetCookies()
 }
 
+func TestBrowserDefaultTimeout(t *testing.T) {
+	g := setup(t)
+
+	b := *g.browser
+	b.DefaultTimeout(time.Hour).DefaultTimeout(100 * time.Millisecond)
+
+	p := b.MustPage(g.blank())
+	defer p.MustClose()
+
+	start := time.Now()
+	_, err := p.Element("not-exists")
+	g.Is(err, context.DeadlineExceeded)
+	g.Lt(time.Since(start), 2*time.Second)
+
+	// the later call replaces the previous timeout instead of nesting it
+	b.DefaultTimeout(100 * time.Millisecond).DefaultTimeout(300 * time.Millisecond)
+
+	p = b.MustPage(g.blank())
+	defer p.MustClose()
+
+	start = time.Now()
+	_, err = p.Element("not-exists")
+	g.Is(err, context.DeadlineExceeded)
+	g.Gte(time.Since(start), 300*time.Millisecond)
+}
+
+func TestBrowserActionHooks(t *testing.T) {
+	g := setup(t)
+
+	before := []string{}
+	after := []*rod.Action{}
+
+	b := *g.browser
+	b.BeforeAction(func(a *rod.Action) {
+		before = append(before, a.Name)
+	}).AfterAction(func(a *rod.Action) {
+		after = append(after, a)
+	})
+
+	p := b.MustPage(g.srcFile("fixtures/input.html"))
+	defer p.MustClose()
+
+	p.MustElement("[type=text]").MustInput("1")
+	el := p.MustElement("#EnabledButton").MustClick()
+	p.MustEval(`() => 1`)
+	el.MustEval(`() => 2`)
+
+	// the internal evals of rod, such as the ones of the input and click, are not actions
+	g.Eq(before, []string{"navigate", "input", "click", "eval", "eval"})
+	g.Len(after, 5)
+	g.Eq(after[1].Args, []interface{}{"1"})
+	g.NotNil(after[1].Element)
+	g.Nil(after[3].Element)
+	g.Eq(after[3].Page, p)
+	g.Eq(after[4].Element, el)
+	g.Eq(after[4].Args, []interface{}{`() => 2`})
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	g.Err(p.Navigate(g.blank()))
+	g.Err(after[5].Err)
+	g.Gt(after[5].Duration, time.Duration(0))
+}
+
+func TestBrowserMajorVersion(t *testing.T) {
+	g := setup(t)
+
+	g.Gt(g.browser.MustMajorVersion(), 0)
+
+	g.mc.stub(1, proto.BrowserGetVersion{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.BrowserGetVersionResult{Product: "Chrome/89.0.4389.0"}), nil
+	})
+	g.Eq(g.browser.MustMajorVersion(), 89)
+
+	g.mc.stub(1, proto.BrowserGetVersion{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.BrowserGetVersionResult{Product: "unknown"}), nil
+	})
+	_, err := g.browser.MajorVersion()
+	g.Eq(err.Error(), "failed to parse the browser version: unknown")
+
+	g.mc.stubErr(1, proto.BrowserGetVersion{})
+	g.Err(g.browser.MajorVersion())
+}
+
+func TestBrowserFeatures(t *testing.T) {
+	g := setup(t)
+
+	f := g.browser.MustFeatures()
+	g.Gt(f.Major, 0)
+	g.Eq(f.Protocol, "1.3")
+
+	g.mc.stub(1, proto.BrowserGetVersion{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.BrowserGetVersionResult{Product: "HeadlessChrome/90.0.4430.0"}), nil
+	})
+	f = g.browser.MustFeatures()
+	g.Eq(f.Major, 90)
+	g.False(f.BrowserDownloadEvents)
+	g.False(f.NewHeadless)
+
+	g.mc.stub(1, proto.BrowserGetVersion{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.BrowserGetVersionResult{Product: "Chrome/112.0.5615.0"}), nil
+	})
+	f = g.browser.MustFeatures()
+	g.True(f.BrowserDownloadEvents)
+	g.True(f.NewHeadless)
+
+	g.mc.stub(1, proto.BrowserGetVersion{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.BrowserGetVersionResult{Product: "custom"}), nil
+	})
+	f = g.browser.MustFeatures()
+	g.Eq(f.Major, 0)
+	g.True(f.BrowserDownloadEvents)
+
+	g.mc.stubErr(1, proto.BrowserGetVersion{})
+	g.Err(g.browser.Features())
+}
+
+func TestBrowserHeadlessMode(t *testing.T) {
+	g := setup(t)
+
+	g.Neq(g.browser.MustHeadlessMode(), rod.HeadlessModeNone)
+
+	stub := func(product string, args ...string) {
+		g.mc.setCall(func(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
+			switch method {
+			case (proto.BrowserGetBrowserCommandLine{}).ProtoReq():
+				return gson.New(proto.BrowserGetBrowserCommandLineResult{Arguments: args}).MarshalJSON()
+			case (proto.BrowserGetVersion{}).ProtoReq():
+				if product == "" {
+					return nil, errors.New("mock error")
+				}
+				return gson.New(proto.BrowserGetVersionResult{Product: product}).MarshalJSON()
+			}
+			return g.mc.principal.Call(ctx, sessionID, method, params)
+		})
+	}
+
+	check := func(product string, mode rod.HeadlessMode, args ...string) {
+		t.Helper()
+		stub(product, args...)
+		defer g.mc.resetCall()
+		g.Eq(g.browser.MustHeadlessMode(), mode)
+	}
+
+	check("", rod.HeadlessModeNone, "chrome", "--no-sandbox")
+	check("", rod.HeadlessModeNew, "chrome", "--headless=new")
+	check("", rod.HeadlessModeOld, "chrome", "--headless=old")
+	check("", rod.HeadlessModeOld, "/bin/chrome-headless-shell", "--headless")
+	check("HeadlessChrome/120.0.6099.0", rod.HeadlessModeOld, "chrome", "--headless")
+	check("HeadlessChrome/132.0.6834.0", rod.HeadlessModeNew, "chrome", "--headless")
+
+	stub("", "chrome", "--headless")
+	g.Err(g.browser.HeadlessMode())
+	g.mc.resetCall()
+
+	g.mc.stubErr(1, proto.BrowserGetBrowserCommandLine{})
+	g.Err(g.browser.HeadlessMode())
+}
+
+func TestWaitDownloadOldBrowser(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	content := "test content"
+
+	s.Route("/d", ".bin", []byte(content))
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/d" download>click</a></html>`, s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	g.mc.stub(1, proto.BrowserGetVersion{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.BrowserGetVersionResult{Product: "Chrome/90.0.4430.0"}), nil
+	})
+	wait := g.browser.MustWaitDownload()
+	page.MustElement("a").MustClick()
+	data := wait()
+
+	g.Eq(content, string(data))
+}
+
 func TestBinarySize(t *testing.T) {
 	g := setup(t)
 
@@ -365,6 +658,106 @@ func TestWaitDownloadDataURI(t *testing.T) {
 	g.Eq("test blob", string(data))
 }
 
+func TestWaitDownloads(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+
+	s.Route("/a.bin", ".bin", "a")
+	s.Route("/b.bin", ".bin", "b")
+	s.Route("/c.txt", ".bin", "c")
+	s.Route("/page", ".html", fmt.Sprintf(`<html>
+		<a id="a" href="%s/a.bin" download>a</a>
+		<a id="b" href="%s/b.bin" download>b</a>
+		<a id="c" href="%s/c.txt" download>c</a>
+	</html>`, s.URL(), s.URL(), s.URL()))
+
+	page := g.page.MustNavigate(s.URL("/page"))
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads", g.RandStr(8))
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	ch := g.browser.WaitDownloads(dir, "*.bin", 2)
+	page.MustElement("#c").MustClick()
+	page.MustElement("#a").MustClick()
+	page.MustElement("#b").MustClick()
+
+	list := map[string]string{}
+	for d := range ch {
+		g.False(d.Canceled)
+		g.Eq(d.Path, filepath.Join(dir, d.GUID))
+		data, err := utils.ReadString(d.Path)
+		g.E(err)
+		list[filepath.Base(d.URL)] = data
+	}
+	g.Eq(list, map[string]string{"a.bin": "a", "b.bin": "b"})
+}
+
+func TestWaitDownloadsCancel(t *testing.T) {
+	g := setup(t)
+
+	ch := g.browser.Context(g.Timeout(0)).WaitDownloads(os.TempDir(), "*", 0)
+	_, ok := <-ch
+	g.False(ok)
+
+	// a negative n means no limit too
+	ch = g.browser.Context(g.Timeout(0)).WaitDownloads(os.TempDir(), "*", -1)
+	_, ok = <-ch
+	g.False(ok)
+}
+
+func TestBrowserSetDownloadDir(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/d", ".bin", "test content")
+	s.Route("/page", ".html", fmt.Sprintf(`<html><a href="%s/d" download="file.bin">click</a></html>`, s.URL()))
+
+	dir := filepath.Join(os.TempDir(), "rod", "downloads", g.RandStr(8))
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	b := g.browser.MustIncognito().MustSetDownloadDir(dir)
+	defer b.MustClose()
+
+	page := b.MustPage(s.URL("/page"))
+
+	e := &proto.BrowserDownloadProgress{}
+	wait := b.WaitEventUntil(e, func() bool { return e.State == proto.BrowserDownloadProgressStateCompleted })
+	page.MustElement("a").MustClick()
+	wait()
+
+	// the dir is recorded when the download began
+	g.E(b.SetDownloadDir(os.TempDir()))
+
+	d := b.GetDownload(e.GUID)
+	g.Eq(d.SuggestedFilename, "file.bin")
+	g.Eq(d.Path, filepath.Join(dir, e.GUID))
+	g.False(d.Canceled)
+
+	// the completed download is removed once it's read
+	g.Nil(b.GetDownload(e.GUID))
+
+	data, err := utils.ReadString(d.Path)
+	g.E(err)
+	g.Eq(data, "test content")
+
+	g.Nil(b.GetDownload("not-exists"))
+
+	// the download that is never read is removed after the TTL
+	g.browser.DownloadTTL(time.Millisecond)
+	defer g.browser.DownloadTTL(time.Minute)
+
+	e = &proto.BrowserDownloadProgress{}
+	wait = b.WaitEventUntil(e, func() bool { return e.State == proto.BrowserDownloadProgressStateCompleted })
+	page.MustElement("a").MustClick()
+	wait()
+	utils.Sleep(0.1)
+	g.Nil(b.GetDownload(e.GUID))
+
+	g.mc.stubErr(1, proto.BrowserGetVersion{})
+	g.Err(b.SetDownloadDir(dir))
+}
+
 func TestWaitDownloadCancel(t *testing.T) {
 	g := setup(t)
 
@@ -426,6 +819,30 @@ func TestStreamReader(t *testing.T) {
 	})
 	_, err = r.Read(nil)
 	g.Err(err)
+
+	pdf, err := g.page.MustNavigate(g.blank()).PDF(&proto.PagePrintToPDF{})
+	g.E(err)
+	buf := bytes.NewBuffer(nil)
+	head := make([]byte, 2)
+	_, err = pdf.Read(head)
+	g.E(err)
+	_, err = io.Copy(buf, pdf)
+	g.E(err)
+	g.Has(string(head)+buf.String(), "%PDF")
+	g.E(pdf.Close())
+
+	g.mc.stubErr(1, proto.IORead{})
+	_, err = r.WriteTo(buf)
+	g.Err(err)
+
+	g.mc.stub(1, proto.IORead{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.IOReadResult{
+			Base64Encoded: true,
+			Data:          "@",
+		}), nil
+	})
+	_, err = r.WriteTo(buf)
+	g.Err(err)
 }
 
 func TestBrowserConnectFailure(t *testing.T) {
@@ -476,6 +893,102 @@ func TestBrowserLostConnection(t *testing.T) {
 	g.Err(err)
 }
 
+func TestBrowserOnDisconnect(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	disconnected := make(chan struct{})
+	rod.New().Context(g.Context()).ControlURL(l.MustLaunch()).OnDisconnect(func() {
+		close(disconnected)
+	}).MustConnect()
+
+	l.Kill()
+
+	<-disconnected
+}
+
+func TestBrowserTargetHooks(t *testing.T) {
+	g := setup(t)
+
+	created := make(chan *proto.TargetTargetInfo, 100)
+	changed := make(chan *proto.TargetTargetInfo, 100)
+	destroyed := make(chan proto.TargetTargetID, 100)
+
+	b := rod.New().Context(g.Context()).ControlURL(launcher.New().MustLaunch()).
+		OnTargetCreated(func(info *proto.TargetTargetInfo) { created <- info }).
+		OnTargetChanged(func(info *proto.TargetTargetInfo) { changed <- info }).
+		OnTargetDestroyed(func(id proto.TargetTargetID) { destroyed <- id }).
+		MustConnect()
+	defer b.MustClose()
+
+	p := b.MustPage()
+
+	for info := range created {
+		if info.TargetID == p.TargetID {
+			break
+		}
+	}
+
+	p.MustNavigate(g.blank())
+	for info := range changed {
+		if info.TargetID == p.TargetID && strings.HasSuffix(info.URL, "blank.html") {
+			break
+		}
+	}
+
+	p.MustClose()
+	for id := range destroyed {
+		if id == p.TargetID {
+			break
+		}
+	}
+}
+
+func TestBrowserAutoAttach(t *testing.T) {
+	g := setup(t)
+
+	b := rod.New().Context(g.Context()).ControlURL(launcher.New().MustLaunch()).MustConnect()
+	defer b.MustClose()
+
+	page := b.MustPage(g.blank())
+
+	attached := make(chan *rod.Page, 1)
+	stop, err := b.AutoAttach(func(p *rod.Page) {
+		p.MustEvalOnNewDocument(`window.injected = 'ok'`)
+		attached <- p
+	})
+	g.E(err)
+
+	popup := page.MustOpen(g.blank(), "")
+	g.Eq(popup.MustEval(`() => window.injected`).Str(), "ok")
+
+	// the same page is shared with PageFromTarget
+	g.True(<-attached == b.MustPageFromTargetID(popup.TargetID))
+
+	g.E(stop())
+
+	popup = page.MustOpen(g.blank(), "")
+	g.Nil(popup.MustEval(`() => window.injected`).Val())
+
+	_, err = b.Context(g.Timeout(0)).AutoAttach(nil)
+	g.Err(err)
+}
+
+func TestBrowserConnectManager(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.Handle("/", launcher.NewManager())
+
+	defaults.Manager = s.URL()
+	defer defaults.ResetWith("")
+
+	b := rod.New().Context(g.Context()).MustConnect()
+	defer b.MustClose()
+
+	g.Has(b.MustVersion().Product, "Chrome")
+}
+
 func TestBrowserConnectConflict(t *testing.T) {
 	g := setup(t)
 	g.Panic(func() {
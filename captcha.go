@@ -0,0 +1,97 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// CaptchaType of a captcha, see [Page.DetectCaptcha]
+type CaptchaType string
+
+const (
+	// CaptchaCloudflare is the interstitial page of the cloudflare browser check
+	CaptchaCloudflare CaptchaType = "cloudflare"
+
+	// CaptchaTurnstile is the cloudflare turnstile widget
+	CaptchaTurnstile CaptchaType = "turnstile"
+
+	// CaptchaReCAPTCHA is the google reCAPTCHA widget
+	CaptchaReCAPTCHA CaptchaType = "recaptcha"
+
+	// CaptchaHCaptcha is the hCaptcha widget
+	CaptchaHCaptcha CaptchaType = "hcaptcha"
+)
+
+// Captcha is a captcha or anti-bot challenge that blocks the page
+type Captcha struct {
+	Type CaptchaType `json:"type"`
+
+	// SiteKey of the widget, it's required by most solver services. It's empty if not found.
+	SiteKey string `json:"siteKey"`
+
+	// URL of the page
+	URL string `json:"url"`
+}
+
+// DetectCaptcha returns the visible captcha of the page, such as reCAPTCHA, hCaptcha, and the cloudflare
+// challenge. It returns nil if there's none. The invisible ones, such as reCAPTCHA v3, are ignored
+// because they don't block the page.
+func (p *Page) DetectCaptcha() (*Captcha, error) {
+	res, err := p.Evaluate(EvalHelper(js.DetectCaptcha))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Value.Nil() {
+		return nil, nil
+	}
+
+	c := &Captcha{}
+	err = res.Value.Unmarshal(c)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	c.URL = info.URL
+
+	return c, nil
+}
+
+// CheckCaptcha returns [ErrCaptcha] if the page is blocked by a captcha, so that a crawler can
+// pause, route it to a solver service, or back off instead of timing out on the missing elements.
+func (p *Page) CheckCaptcha() error {
+	c, err := p.DetectCaptcha()
+	if err != nil {
+		return err
+	}
+	if c != nil {
+		return &ErrCaptcha{c}
+	}
+	return nil
+}
+
+// ObserveCaptcha calls fn with the captcha detected after each load of the page until stop is called.
+func (p *Page) ObserveCaptcha(fn func(*Captcha)) (stop func()) {
+	p, cancel := p.WithCancel()
+	wait := p.EachEvent(func(e *proto.PageLoadEventFired) {
+		c, err := p.DetectCaptcha()
+		if err == nil && c != nil {
+			fn(c)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,65 @@
+package rod_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestDetectCaptcha(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+	g.Nil(p.MustDetectCaptcha())
+	g.Nil(p.CheckCaptcha())
+
+	p.MustNavigate(g.html(`<html><body>
+		<div class="g-recaptcha" data-sitekey="key" style="width: 300px; height: 80px"></div>
+	</body></html>`))
+	c := p.MustDetectCaptcha()
+	g.Eq(c.Type, rod.CaptchaReCAPTCHA)
+	g.Eq(c.SiteKey, "key")
+	g.Eq(c.URL, p.MustInfo().URL)
+
+	err := p.CheckCaptcha()
+	g.True(errors.Is(err, &rod.ErrCaptcha{}))
+	g.Has(err.Error(), "page is blocked by recaptcha captcha")
+
+	// the widget iframes are served locally, the detection only checks the paths of their urls
+	s := g.Serve()
+	s.Route("/newassets.hcaptcha.com/captcha/v1/x/static/hcaptcha.html", ".html", `<html></html>`)
+	s.Route("/recaptcha/api2/anchor", ".html", `<html></html>`)
+	p.MustNavigate(g.html(`<html><body>
+		<iframe src="` + s.URL("/newassets.hcaptcha.com/captcha/v1/x/static/hcaptcha.html#sitekey=a") + `"></iframe>
+		<iframe src="` + s.URL("/recaptcha/api2/anchor?k=b&size=invisible") + `"></iframe>
+	</body></html>`))
+	c = p.MustDetectCaptcha()
+	g.Eq(c.Type, rod.CaptchaHCaptcha)
+	g.Eq(c.SiteKey, "a")
+
+	p.MustNavigate(g.html(`<html><head><title>Just a moment...</title></head><body>
+		<div id="challenge-running"></div>
+	</body></html>`))
+	g.Eq(p.MustDetectCaptcha().Type, rod.CaptchaCloudflare)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.CheckCaptcha())
+
+	g.mc.stubErr(1, proto.TargetGetTargetInfo{})
+	g.Err(p.DetectCaptcha())
+}
+
+func TestObserveCaptcha(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	ch := make(chan *rod.Captcha, 1)
+	stop := p.ObserveCaptcha(func(c *rod.Captcha) { ch <- c })
+	defer stop()
+
+	p.MustNavigate(g.html(`<html><body><div class="cf-turnstile" style="height: 10px"></div></body></html>`))
+	g.Eq((<-ch).Type, rod.CaptchaTurnstile)
+}
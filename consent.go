@@ -0,0 +1,64 @@
+package rod
+
+import (
+	"fmt"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// CookieBannerSelectors are the selectors of the accept buttons of the common cookie banners,
+// see [Page.DismissCookieBanner]. You can append your own ones to it.
+var CookieBannerSelectors = []string{
+	"#onetrust-accept-btn-handler",                           // OneTrust
+	"#CybotCookiebotDialogBodyLevelButtonLevelOptinAllowAll", // Cookiebot
+	"#CybotCookiebotDialogBodyButtonAccept",                  // Cookiebot
+	"#didomi-notice-agree-button",                            // Didomi
+	".fc-cta-consent",                                        // Google Funding Choices
+	"#truste-consent-button",                                 // TrustArc
+	".qc-cmp2-summary-buttons button[mode=primary]",          // Quantcast
+	"[data-testid=uc-accept-all-button]",                     // Usercentrics
+	".cc-allow",                                              // Osano cookieconsent
+	".cc-dismiss",                                            // Osano cookieconsent
+}
+
+// CookieBannerTexts are the lowercase texts of the accept buttons, only the buttons inside the elements
+// whose id, class, or aria-label contains such as "cookie", "consent", "gdpr" will be clicked,
+// see [Page.DismissCookieBanner]. You can append your own ones to it.
+var CookieBannerTexts = []string{
+	"accept all", "accept all cookies", "accept cookies", "accept", "allow all", "allow all cookies",
+	"i agree", "agree", "agree and close", "got it", "ok",
+	"alle akzeptieren", "akzeptieren", "tout accepter", "accepter", "aceptar todo", "aceptar",
+	"accetta tutto", "accetta", "alles accepteren", "aceitar todos", "aceitar",
+}
+
+// DismissCookieBanner tries to accept the cookie banner of the page, it returns true if a banner is dismissed.
+// It first tries the js api of the common consent management platforms, then clicks the visible element that
+// matches [CookieBannerSelectors], then the button that matches [CookieBannerTexts].
+// The banners inside iframes are not handled.
+func (p *Page) DismissCookieBanner() (bool, error) {
+	res, err := p.Evaluate(EvalHelper(js.DismissCookieBanner, CookieBannerSelectors, CookieBannerTexts))
+	if err != nil {
+		return false, err
+	}
+	return res.Value.Bool(), nil
+}
+
+// AutoDismissCookieBanners dismisses the cookie banners after each navigation until stop is called.
+// Because many banners are rendered after the load event, it retries [Page.DismissCookieBanner]
+// every 500ms for the first 10 seconds after the load event.
+func (p *Page) AutoDismissCookieBanners() (stop func() error, err error) {
+	return p.EvalOnNewDocument(fmt.Sprintf(`(() => {
+		const dismiss = %s
+		let tries = 0
+		const run = () => {
+			if (dismiss(%s, %s) || ++tries >= 20) return
+			setTimeout(run, 500)
+		}
+		addEventListener('load', run)
+	})()`,
+		js.DismissCookieBanner.Definition,
+		utils.MustToJSON(CookieBannerSelectors),
+		utils.MustToJSON(CookieBannerTexts),
+	))
+}
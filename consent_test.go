@@ -0,0 +1,66 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestDismissCookieBanner(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><body>
+		<button onclick="this.remove()">accept</button>
+		<div class="gdpr-banner">
+			<button onclick="this.parentElement.remove()"> Accept All </button>
+		</div>
+		<div id="onetrust-banner-sdk">
+			<button id="onetrust-accept-btn-handler" onclick="this.parentElement.remove()">OK</button>
+		</div>
+	</body></html>`))
+
+	g.True(p.MustDismissCookieBanner())
+	g.False(p.MustHas("#onetrust-banner-sdk"))
+
+	g.True(p.MustDismissCookieBanner())
+	g.False(p.MustHas(".gdpr-banner"))
+
+	// the buttons outside of the banners are not clicked
+	g.False(p.MustDismissCookieBanner())
+	g.True(p.MustHas("button"))
+
+	// the js api of the consent management platforms
+	p.MustEval(`() => {
+		window.Didomi = {
+			shouldConsentBeCollected: () => !window.agreed,
+			setUserAgreeToAll: () => { window.agreed = true },
+		}
+	}`)
+	g.True(p.MustDismissCookieBanner())
+	g.True(p.MustEval(`() => window.agreed`).Bool())
+	g.False(p.MustDismissCookieBanner())
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.DismissCookieBanner())
+}
+
+func TestAutoDismissCookieBanners(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage()
+	stop := p.MustAutoDismissCookieBanners()
+
+	p.MustNavigate(g.html(`<html><body><script>
+		setTimeout(() => {
+			document.body.innerHTML =
+				'<div id="cookie-consent"><button onclick="window.dismissed = true">Got it</button></div>'
+		}, 1000)
+	</script></body></html>`))
+
+	p.MustWait(`() => window.dismissed`)
+
+	stop()
+
+	g.mc.stubErr(1, proto.PageAddScriptToEvaluateOnNewDocument{})
+	g.Err(p.AutoDismissCookieBanners())
+}
@@ -50,6 +50,7 @@ func (b *Browser) WithCancel() (*Browser, func()) {
 func (b *Browser) Sleeper(sleeper func() utils.Sleeper) *Browser {
 	newObj := *b
 	newObj.sleeper = sleeper
+	newObj.baseSleeper = nil
 	return &newObj
 }
 
@@ -0,0 +1,373 @@
+package rod
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Politeness is a hijack handler for crawlers, it obeys the robots.txt of the visited origins, and limits
+// the concurrency and the delay of the requests to each host. By default only the document requests are
+// handled, the subresources of the pages are continued as usual, see [Politeness.ResourceTypes].
+// Add it to the router of a browser so that the budgets are shared by all the pages:
+//
+//	polite := rod.NewPoliteness(http.DefaultClient)
+//	polite.Delay = time.Second
+//	router := browser.HijackRequests()
+//	router.MustAdd("*", polite.Handle)
+//	go router.Run()
+type Politeness struct {
+	// UserAgent to match the groups of the robots.txt, the "*" group is used if no group matches
+	UserAgent string
+
+	// Concurrency is the max number of the concurrent requests to a host, 0 means no limit
+	Concurrency int
+
+	// Delay between the starts of two requests to a host. If the Crawl-delay of the robots.txt is longer,
+	// the Crawl-delay will be used.
+	Delay time.Duration
+
+	// Client to load the robots.txt and the responses
+	Client *http.Client
+
+	// ResourceTypes to handle, the requests of the other types are continued without the robots.txt check
+	// and the budgets. Empty means all the types are handled. The default is only the document type.
+	ResourceTypes []proto.NetworkResourceType
+
+	lock   sync.Mutex
+	robots map[string]*robotsLoader // the key is the origin
+	hosts  map[string]*crawlHost
+}
+
+// CrawlStats of a host, see [Politeness.Stats]
+type CrawlStats struct {
+	// Requests that are sent to the host
+	Requests int
+
+	// Disallowed requests by the robots.txt
+	Disallowed int
+
+	// Failed requests
+	Failed int
+
+	// Bytes of the response bodies
+	Bytes int64
+
+	// Waited is the total time that the requests wait for the budgets of the host
+	Waited time.Duration
+}
+
+type crawlHost struct {
+	slots chan struct{}
+	next  time.Time // the earliest time to start the next request
+	stats CrawlStats
+}
+
+// NewPoliteness creates a politeness handler that loads the robots.txt and the responses via the client
+func NewPoliteness(client *http.Client) *Politeness {
+	return &Politeness{
+		UserAgent:     "*",
+		Client:        client,
+		ResourceTypes: []proto.NetworkResourceType{proto.NetworkResourceTypeDocument},
+		robots:        map[string]*robotsLoader{},
+		hosts:         map[string]*crawlHost{},
+	}
+}
+
+// Handle is a hijack handler that fails the requests disallowed by the robots.txt, and waits for the budgets
+// of the host before it loads the response via the Client.
+func (p *Politeness) Handle(h *Hijack) {
+	if !p.handles(h.Request.Type()) {
+		h.ContinueRequest(&proto.FetchContinueRequest{})
+		return
+	}
+
+	u := h.Request.URL()
+
+	allowed, delay := p.Allowed(u)
+	if !allowed {
+		p.host(u.Host, func(host *crawlHost) { host.stats.Disallowed++ })
+		h.Response.Fail(proto.NetworkErrorReasonBlockedByClient)
+		return
+	}
+
+	release, err := p.acquire(h.Request.req.Context(), u.Host, delay)
+	if err != nil {
+		h.OnError(err)
+		h.Response.Fail(proto.NetworkErrorReasonAborted)
+		return
+	}
+	defer release()
+
+	err = h.LoadResponse(p.Client, true)
+	p.host(u.Host, func(host *crawlHost) {
+		host.stats.Requests++
+		if err != nil {
+			host.stats.Failed++
+		}
+		host.stats.Bytes += int64(len(h.Response.payload.Body))
+	})
+	if err != nil {
+		h.OnError(err)
+		h.Response.Fail(proto.NetworkErrorReasonConnectionFailed)
+	}
+}
+
+func (p *Politeness) handles(t proto.NetworkResourceType) bool {
+	if len(p.ResourceTypes) == 0 {
+		return true
+	}
+	for _, rt := range p.ResourceTypes {
+		if rt == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed returns true if the robots.txt of the origin allows the url, and the Crawl-delay of the origin.
+// The robots.txt of each origin is only loaded once, the concurrent calls wait for the same load,
+// if it fails to load all the urls are allowed.
+func (p *Politeness) Allowed(u *url.URL) (allowed bool, delay time.Duration) {
+	origin := u.Scheme + "://" + u.Host
+	if u.Path == "/robots.txt" || !(u.Scheme == "http" || u.Scheme == "https") {
+		return true, 0
+	}
+
+	p.lock.Lock()
+	loader, has := p.robots[origin]
+	if !has {
+		loader = &robotsLoader{}
+		p.robots[origin] = loader
+	}
+	p.lock.Unlock()
+
+	loader.once.Do(func() { loader.robots = p.loadRobots(origin) })
+
+	r := loader.robots
+	return r.allowed(u.RequestURI()), r.delay
+}
+
+// Stats returns the clone of the crawl stats of each host
+func (p *Politeness) Stats() map[string]CrawlStats {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	stats := map[string]CrawlStats{}
+	for name, host := range p.hosts {
+		stats[name] = host.stats
+	}
+	return stats
+}
+
+func (p *Politeness) host(name string, fn func(*crawlHost)) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	host, has := p.hosts[name]
+	if !has {
+		host = &crawlHost{}
+		if p.Concurrency > 0 {
+			host.slots = make(chan struct{}, p.Concurrency)
+		}
+		p.hosts[name] = host
+	}
+	fn(host)
+}
+
+// acquire waits for a concurrency slot and the delay of the host
+func (p *Politeness) acquire(ctx context.Context, name string, delay time.Duration) (release func(), err error) {
+	if p.Delay > delay {
+		delay = p.Delay
+	}
+
+	start := time.Now()
+
+	var slots chan struct{}
+	p.host(name, func(host *crawlHost) { slots = host.slots })
+
+	if slots != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case slots <- struct{}{}:
+		}
+	}
+
+	release = func() {
+		if slots != nil {
+			<-slots
+		}
+	}
+
+	// reserve the start time of this request
+	var wait time.Duration
+	p.host(name, func(host *crawlHost) {
+		now := time.Now()
+		at := host.next
+		if at.Before(now) {
+			at = now
+		}
+		host.next = at.Add(delay)
+		wait = at.Sub(now)
+	})
+
+	t := time.NewTimer(wait)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		release()
+		return nil, ctx.Err()
+	case <-t.C:
+	}
+
+	p.host(name, func(host *crawlHost) { host.stats.Waited += time.Since(start) })
+
+	return release, nil
+}
+
+func (p *Politeness) loadRobots(origin string) *robots {
+	res, err := p.Client.Get(origin + "/robots.txt")
+	if err != nil {
+		return &robots{}
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if res.StatusCode != http.StatusOK {
+		return &robots{}
+	}
+
+	return parseRobots(res.Body, p.UserAgent)
+}
+
+// robotsLoader makes sure the robots.txt of an origin is only loaded once
+type robotsLoader struct {
+	once   sync.Once
+	robots *robots
+}
+
+// robots is the rules of the matched group of a robots.txt
+type robots struct {
+	rules []*robotsRule
+	delay time.Duration
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	reg     *regexp.Regexp
+}
+
+// the most specific rule wins, the allow rule wins if the lengths are the same
+func (r *robots) allowed(uri string) bool {
+	var matched *robotsRule
+	for _, rule := range r.rules {
+		if !rule.reg.MatchString(uri) {
+			continue
+		}
+		if matched == nil || len(rule.pattern) > len(matched.pattern) ||
+			(len(rule.pattern) == len(matched.pattern) && rule.allow) {
+			matched = rule
+		}
+	}
+	return matched == nil || matched.allow
+}
+
+// parseRobots parses the robots.txt and returns the rules of the group that matches the userAgent,
+// the longest matched agent wins, if no group matches the "*" group will be used.
+func parseRobots(r io.Reader, userAgent string) *robots {
+	groups := map[string]*robots{}
+	agents := []string{}
+	inRules := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+
+		kv := strings.SplitN(line, ":", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(kv[0]))
+		val := strings.TrimSpace(kv[1])
+
+		if key == "user-agent" {
+			if inRules {
+				agents = []string{}
+				inRules = false
+			}
+			agent := strings.ToLower(val)
+			agents = append(agents, agent)
+			if groups[agent] == nil {
+				groups[agent] = &robots{}
+			}
+			continue
+		}
+
+		inRules = true
+		for _, agent := range agents {
+			groups[agent].add(key, val)
+		}
+	}
+
+	ua := strings.ToLower(userAgent)
+	matched := ""
+	for agent := range groups {
+		if agent == "*" || !strings.Contains(ua, agent) {
+			continue
+		}
+		if len(agent) > len(matched) || (len(agent) == len(matched) && agent < matched) {
+			matched = agent
+		}
+	}
+	if matched != "" {
+		return groups[matched]
+	}
+	if g, has := groups["*"]; has {
+		return g
+	}
+	return &robots{}
+}
+
+func (r *robots) add(key, val string) {
+	switch key {
+	case "allow", "disallow":
+		if val == "" {
+			return
+		}
+		r.rules = append(r.rules, &robotsRule{
+			allow:   key == "allow",
+			pattern: val,
+			reg:     regexp.MustCompile(robotsPatternToReg(val)),
+		})
+	case "crawl-delay":
+		if s, err := strconv.ParseFloat(val, 64); err == nil {
+			r.delay = time.Duration(s * float64(time.Second))
+		}
+	}
+}
+
+// the "*" matches any sequence of characters, the "$" at the end anchors the end of the url
+func robotsPatternToReg(pattern string) string {
+	end := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	reg := `\A` + strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	if end {
+		reg += `\z`
+	}
+	return reg
+}
@@ -0,0 +1,117 @@
+package rod_test
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+)
+
+func TestPoliteness(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/robots.txt", ".txt", `
+# comment
+User-agent: other-bot
+Disallow: /
+
+User-agent: *
+Disallow: /private
+Allow: /private/public$
+Disallow: /*.pdf$
+`)
+	s.Route("/a", ".html", `<html><img src="/private/img.png"></html>`)
+	s.Route("/private/public", ".html", `<html>public</html>`)
+	s.Route("/private/img.png", ".png", "")
+
+	polite := rod.NewPoliteness(http.DefaultClient)
+	polite.Concurrency = 1
+	polite.Delay = 300 * time.Millisecond
+
+	p := g.newPage()
+	router := p.HijackRequests()
+	defer router.MustStop()
+	router.MustAdd(s.URL("/*"), polite.Handle)
+	go router.Run()
+
+	start := time.Now()
+	p.MustNavigate(s.URL("/a"))
+	p.MustNavigate(s.URL("/private/public"))
+	g.Gte(time.Since(start), polite.Delay)
+
+	g.Err(p.Navigate(s.URL("/private/a")))
+	g.Err(p.Navigate(s.URL("/doc.pdf")))
+
+	stats := polite.Stats()[parseURL(g, s.URL()).Host]
+	g.Gte(stats.Requests, 2)
+	g.Eq(stats.Disallowed, 2) // the subresources are not handled
+	g.Eq(stats.Failed, 0)
+	g.Gt(stats.Bytes, int64(0))
+	g.Gt(stats.Waited, time.Duration(0))
+
+	// the robots.txt itself is always allowed
+	allowed, _ := polite.Allowed(parseURL(g, s.URL("/robots.txt")))
+	g.True(allowed)
+
+	other := rod.NewPoliteness(http.DefaultClient)
+	other.UserAgent = "Other-Bot/1.0"
+	allowed, _ = other.Allowed(parseURL(g, s.URL("/a")))
+	g.False(allowed)
+
+	// fails to load the robots.txt
+	allowed, _ = other.Allowed(parseURL(g, "http://not-exists.invalid/a"))
+	g.True(allowed)
+}
+
+func TestPolitenessCrawlDelay(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/robots.txt", ".txt", "User-agent: *\nCrawl-delay: 1.5\n")
+
+	_, delay := rod.NewPoliteness(http.DefaultClient).Allowed(parseURL(g, s.URL("/a")))
+	g.Eq(delay, 1500*time.Millisecond)
+}
+
+func TestPolitenessRobots(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	count := int32(0)
+	s.Mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&count, 1)
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("User-agent: bot\nDisallow: /\n\nUser-agent: other-bot\nDisallow: /a\n"))
+	})
+
+	polite := rod.NewPoliteness(http.DefaultClient)
+	polite.UserAgent = "Other-Bot/1.0"
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = polite.Allowed(parseURL(g, s.URL("/b")))
+		}()
+	}
+	wg.Wait()
+	g.Eq(atomic.LoadInt32(&count), int32(1))
+
+	// the longest matched group wins
+	allowed, _ := polite.Allowed(parseURL(g, s.URL("/b")))
+	g.True(allowed)
+	allowed, _ = polite.Allowed(parseURL(g, s.URL("/a")))
+	g.False(allowed)
+}
+
+func parseURL(g G, s string) *url.URL {
+	u, err := url.Parse(s)
+	g.E(err)
+	return u
+}
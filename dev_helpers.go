@@ -5,8 +5,11 @@
 package rod
 
 import (
+	"crypto/subtle"
 	"fmt"
 	"html"
+	"io/ioutil"
+	"mime"
 	"net"
 	"net/http"
 	"strings"
@@ -47,6 +50,21 @@ const (
 
 // ServeMonitor starts the monitor server.
 // The reason why not to use "chrome://inspect/#devices" is one target cannot be driven by multiple controllers.
+// Besides the viewer, it also serves a json api:
+//
+//	GET  /api/pages               list the pages
+//	GET  /api/page/{id}           info of the page
+//	GET  /screenshot/{id}         png screenshot of the page
+//
+// If [Browser.MonitorControl] is enabled, it also serves the endpoints to control the browser remotely:
+//
+//	POST /api/navigate/{id}       navigate the page, body: {"url": "https://example.com"}
+//	POST /api/eval/{id}           eval js on the page and return the value, body: {"js": "() => document.title"}
+//	POST /api/close/{id}          close the page
+//
+// Each control request must have the "Content-Type: application/json" header and the
+// "Authorization: Bearer {token}" header, the token is [MonitorToken].
+// The errors are returned with the status code 400. Don't expose the server to an untrusted network.
 func (b *Browser) ServeMonitor(host string) string {
 	u, mux, closeSvr := serve(host)
 	go func() {
@@ -55,7 +73,7 @@ func (b *Browser) ServeMonitor(host string) string {
 	}()
 
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		httHTML(w, assets.Monitor)
+		httHTML(w, b.monitorAsset("monitor.html", assets.Monitor))
 	})
 	mux.HandleFunc("/api/pages", func(w http.ResponseWriter, r *http.Request) {
 		res, err := proto.TargetGetTargets{}.Call(b)
@@ -72,7 +90,7 @@ func (b *Browser) ServeMonitor(host string) string {
 		utils.E(w.Write(utils.MustToJSONBytes(list)))
 	})
 	mux.HandleFunc("/page/", func(w http.ResponseWriter, r *http.Request) {
-		httHTML(w, assets.MonitorPage)
+		httHTML(w, b.monitorAsset("monitor-page.html", assets.MonitorPage))
 	})
 	mux.HandleFunc("/api/page/", func(w http.ResponseWriter, r *http.Request) {
 		id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
@@ -90,9 +108,101 @@ func (b *Browser) ServeMonitor(host string) string {
 		utils.E(w.Write(p.MustScreenshot()))
 	})
 
+	if b.monitorAssets != nil {
+		mux.Handle("/assets/", http.StripPrefix("/assets/", http.FileServer(b.monitorAssets)))
+	}
+
+	if !b.monitorControl {
+		return u
+	}
+
+	// The control endpoints below only accept POST, the body is json, the last section of the path is the target id.
+
+	mux.HandleFunc("/api/navigate/", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			URL string `json:"url"`
+		}
+		p := b.controlledPage(r, &req)
+		utils.E(p.Navigate(req.URL))
+
+		info, err := p.Info()
+		utils.E(err)
+		w.WriteHeader(http.StatusOK)
+		utils.E(w.Write(utils.MustToJSONBytes(info)))
+	})
+	mux.HandleFunc("/api/eval/", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			JS string `json:"js"`
+		}
+		p := b.controlledPage(r, &req)
+
+		res, err := p.eval(req.JS)
+		utils.E(err)
+		w.WriteHeader(http.StatusOK)
+		utils.E(w.Write(utils.MustToJSONBytes(res.Value)))
+	})
+	mux.HandleFunc("/api/close/", func(w http.ResponseWriter, r *http.Request) {
+		p := b.controlledPage(r, nil)
+		utils.E(p.Close())
+		w.WriteHeader(http.StatusOK)
+	})
+
 	return u
 }
 
+// monitorAsset reads the file from the custom monitor assets, returns the fallback if it doesn't exist
+func (b *Browser) monitorAsset(name, fallback string) string {
+	if b.monitorAssets == nil {
+		return fallback
+	}
+
+	f, err := b.monitorAssets.Open(name)
+	if err != nil {
+		return fallback
+	}
+	defer func() { _ = f.Close() }()
+
+	body, err := ioutil.ReadAll(f)
+	utils.E(err)
+	return string(body)
+}
+
+// monitorToken is generated once per process, see MonitorToken
+var monitorToken = utils.RandString(16)
+
+// MonitorToken returns the token that the control requests of the monitor server must have,
+// it's random for each process. Check [Browser.ServeMonitor] for how to use it.
+func MonitorToken() string {
+	return monitorToken
+}
+
+// controlledPage checks the control request of the monitor, decodes the body to v if v is not nil,
+// and returns the page of the target id in the path.
+// The json content type can't be sent by a cross-site form, and the token can't be guessed by other sites.
+func (b *Browser) controlledPage(r *http.Request, v interface{}) *Page {
+	if r.Method != http.MethodPost {
+		panic("method not allowed: " + r.Method)
+	}
+
+	if t, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); t != "application/json" {
+		panic("content type must be application/json")
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(token), []byte(monitorToken)) != 1 {
+		panic("invalid token")
+	}
+
+	if v != nil {
+		utils.E(json.NewDecoder(r.Body).Decode(v))
+	}
+
+	id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	p, err := b.PageFromTarget(proto.TargetTargetID(id))
+	utils.E(err)
+	return p
+}
+
 // check method and sleep if needed
 func (b *Browser) trySlowMotion() {
 	if b.slowMotion == 0 {
@@ -104,7 +214,7 @@ func (b *Browser) trySlowMotion() {
 
 // ExposeHelpers helper functions to page's js context so that we can use the Devtools' console to debug them.
 func (p *Page) ExposeHelpers(list ...*js.Function) {
-	p.MustEvaluate(evalHelper(&js.Function{
+	p.MustEvaluate(EvalHelper(&js.Function{
 		Name:         "_" + utils.RandString(8), // use a random name so it won't hit the cache
 		Definition:   "() => { window.rod = functions }",
 		Dependencies: list,
@@ -115,7 +225,7 @@ func (p *Page) ExposeHelpers(list ...*js.Function) {
 func (p *Page) Overlay(left, top, width, height float64, msg string) (remove func()) {
 	id := utils.RandString(8)
 
-	_, _ = p.root.Evaluate(evalHelper(js.Overlay,
+	_, _ = p.root.Evaluate(EvalHelper(js.Overlay,
 		id,
 		left,
 		top,
@@ -125,7 +235,7 @@ func (p *Page) Overlay(left, top, width, height float64, msg string) (remove fun
 	).ByPromise())
 
 	remove = func() {
-		_, _ = p.root.Evaluate(evalHelper(js.RemoveOverlay, id))
+		_, _ = p.root.Evaluate(EvalHelper(js.RemoveOverlay, id))
 	}
 
 	return
@@ -144,6 +254,51 @@ func (p *Page) tryTrace(typ TraceType, msg ...interface{}) func() {
 	return p.Overlay(0, 0, 500, 0, fmt.Sprint(msg))
 }
 
+// Action is a high-level action passed to the [Browser.BeforeAction] and [Browser.AfterAction] hooks
+type Action struct {
+	// Name of the action, such as "navigate", "click"
+	Name string
+
+	// Args of the action, such as the url to navigate
+	Args []interface{}
+
+	// Page the action happens on
+	Page *Page
+
+	// Element the action happens on, it's nil if the action is for the page
+	Element *Element
+
+	// Start time of the action
+	Start time.Time
+
+	// Duration of the action, only available in the AfterAction hook
+	Duration time.Duration
+
+	// Err of the action, only available in the AfterAction hook
+	Err error
+}
+
+// tryHook calls the action hooks of the browser, call the returned function with the error when the action ends
+func (p *Page) tryHook(el *Element, name string, args ...interface{}) func(err error) {
+	b := p.browser
+	if b.beforeAction == nil && b.afterAction == nil {
+		return func(error) {}
+	}
+
+	a := &Action{Name: name, Args: args, Page: p, Element: el, Start: time.Now()}
+	if b.beforeAction != nil {
+		b.beforeAction(a)
+	}
+
+	return func(err error) {
+		if b.afterAction != nil {
+			a.Duration = time.Since(a.Start)
+			a.Err = err
+			b.afterAction(a)
+		}
+	}
+}
+
 func (p *Page) tryTraceQuery(opts *EvalOptions) func() {
 	if !p.browser.trace {
 		return func() {}
@@ -199,13 +354,13 @@ func (p *Page) tryTraceReq(includes, excludes []string) func(map[proto.NetworkRe
 func (el *Element) Overlay(msg string) (removeOverlay func()) {
 	id := utils.RandString(8)
 
-	_, _ = el.Evaluate(evalHelper(js.ElementOverlay,
+	_, _ = el.Evaluate(EvalHelper(js.ElementOverlay,
 		id,
 		msg,
 	).ByPromise())
 
 	removeOverlay = func() {
-		_, _ = el.Evaluate(evalHelper(js.RemoveOverlay, id))
+		_, _ = el.Evaluate(EvalHelper(js.RemoveOverlay, id))
 	}
 
 	return
@@ -225,11 +380,11 @@ func (el *Element) tryTrace(typ TraceType, msg ...interface{}) func() {
 }
 
 func (m *Mouse) initMouseTracer() {
-	_, _ = m.page.Evaluate(evalHelper(js.InitMouseTracer, m.id, assets.MousePointer).ByPromise())
+	_, _ = m.page.Evaluate(EvalHelper(js.InitMouseTracer, m.id, assets.MousePointer).ByPromise())
 }
 
 func (m *Mouse) updateMouseTracer() bool {
-	res, err := m.page.Evaluate(evalHelper(js.UpdateMouseTracer, m.id, m.pos.X, m.pos.Y))
+	res, err := m.page.Evaluate(EvalHelper(js.UpdateMouseTracer, m.id, m.pos.X, m.pos.Y))
 	if err != nil {
 		return true
 	}
@@ -1,15 +1,19 @@
 package rod_test
 
 import (
+	"net/http"
 	"testing"
+	"testing/fstest"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/got"
 	"github.com/ysmood/gson"
 )
 
@@ -38,6 +42,94 @@ func TestMonitor(t *testing.T) {
 	g.Eq(-32602, gson.New(res.Body).Get("code").Int())
 }
 
+func TestMonitorControl(t *testing.T) {
+	g := setup(t)
+
+	b := rod.New().MustConnect()
+	defer b.MustClose()
+	p := b.MustPage(g.blank()).MustWaitLoad()
+	id := string(p.TargetID)
+
+	b, cancel := b.WithCancel()
+	defer cancel()
+	host := b.Context(g.Context()).MonitorControl(true).ServeMonitor("")
+
+	auth := http.Header{"Authorization": {"Bearer " + rod.MonitorToken()}}
+	req := func(method, path string, body ...interface{}) *got.ResHelper {
+		return g.Req(method, host+path+id, append([]interface{}{auth.Clone(), got.ReqMIME(".json")}, body...)...)
+	}
+
+	u := g.srcFile("fixtures/click.html")
+	res := req(http.MethodPost, "/api/navigate/", map[string]string{"url": u})
+	g.Eq(200, res.StatusCode)
+	g.Eq(gson.New(res.Body).Get("url").Str(), u)
+
+	res = req(http.MethodPost, "/api/eval/", map[string]string{"js": "() => 1 + 2"})
+	g.Eq(200, res.StatusCode)
+	g.Eq(res.String(), "3")
+
+	res = req(http.MethodPost, "/api/eval/", map[string]string{"js": "() => { throw 'err' }"})
+	g.Eq(400, res.StatusCode)
+
+	res = req("", "/api/close/")
+	g.Eq(400, res.StatusCode)
+
+	res = req(http.MethodPost, "/api/navigate/", "not json")
+	g.Eq(400, res.StatusCode)
+
+	// a cross-site form can only send the simple content types
+	res = g.Req(http.MethodPost, host+"/api/eval/"+id, auth.Clone(), got.ReqMIME(".txt"), `{"js": "() => 1"}`)
+	g.Eq(400, res.StatusCode)
+	g.Has(res.String(), "content type must be application/json")
+
+	// the token is required
+	res = g.Req(http.MethodPost, host+"/api/eval/"+id, got.ReqMIME(".json"), map[string]string{"js": "() => 1"})
+	g.Eq(400, res.StatusCode)
+	g.Has(res.String(), "invalid token")
+
+	res = g.Req(http.MethodPost, host+"/api/eval/"+id, http.Header{"Authorization": {"Bearer wrong"}},
+		got.ReqMIME(".json"), map[string]string{"js": "() => 1"})
+	g.Eq(400, res.StatusCode)
+	g.Has(res.String(), "invalid token")
+
+	res = req(http.MethodPost, "/api/close/")
+	g.Eq(200, res.StatusCode)
+	_, err := p.Info()
+	g.Err(err)
+}
+
+func TestMonitorControlDisabled(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	b, cancel := g.browser.WithCancel()
+	defer cancel()
+	host := b.ServeMonitor("")
+
+	res := g.Req(http.MethodPost, host+"/api/eval/"+string(p.TargetID),
+		http.Header{"Authorization": {"Bearer " + rod.MonitorToken()}}, got.ReqMIME(".json"), map[string]string{"js": "() => 1"})
+	g.Eq(404, res.StatusCode)
+}
+
+func TestMonitorAssets(t *testing.T) {
+	g := setup(t)
+
+	b, cancel := g.browser.WithCancel()
+	defer cancel()
+
+	host := b.MonitorAssets(http.FS(fstest.MapFS{
+		"monitor.html": {Data: []byte(`<html><script src="/assets/panel.js"></script></html>`)},
+		"panel.js":     {Data: []byte(`document.title = 'custom'`)},
+	})).ServeMonitor("")
+
+	page := g.newPage(host)
+	page.MustWait(`() => document.title === 'custom'`)
+
+	g.Has(g.Req("", host+"/page/test").String(), "<html")
+	g.Eq(g.Req("", host+"/assets/panel.js").String(), `document.title = 'custom'`)
+}
+
 func TestMonitorErr(t *testing.T) {
 	g := setup(t)
 
@@ -98,6 +190,39 @@ func TestTraceLogs(t *testing.T) {
 	p.Overlay(0, 0, 100, 30, "")
 }
 
+func TestStructuredLogger(t *testing.T) {
+	g := setup(t)
+
+	type entry struct {
+		msg string
+		kv  []interface{}
+	}
+	list := []entry{}
+	l := rod.StructuredLogger(func(msg string, kv ...interface{}) {
+		list = append(list, entry{msg, kv})
+	})
+
+	l.Println()
+	l.Println(&cdp.Request{ID: 1, Method: "a"})
+	l.Println(&cdp.Response{ID: 1, Result: []byte("1")})
+	l.Println(&cdp.Response{ID: 2, Error: &cdp.Error{}})
+	l.Println(&cdp.Event{Method: "b"})
+	l.Println(rod.TraceTypeWait, "load", g.page)
+	l.Println(rod.TraceTypeInput, "x")
+	l.Println("other")
+
+	g.Len(list, 7)
+	g.Eq(list[0].msg, "cdp request")
+	g.Eq(list[0].kv[5], "a")
+	g.Eq(list[1].kv[2], "result")
+	g.Eq(list[2].kv[2], "error")
+	g.Eq(list[3].msg, "cdp event")
+	g.Eq(list[4].msg, "trace")
+	g.Eq(list[4].kv, []interface{}{"type", "wait", "page", g.page.String(), "args", []interface{}{"load"}})
+	g.Eq(list[5].kv, []interface{}{"type", "input", "args", []interface{}{"x"}})
+	g.Eq(list[6], entry{"log", []interface{}{"args", []interface{}{"other"}}})
+}
+
 func TestExposeHelpers(t *testing.T) {
 	g := setup(t)
 
@@ -1,10 +1,13 @@
 package rod
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"image"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -81,7 +84,7 @@ func (el *Element) ScrollIntoView() error {
 // Hover the mouse over the center of the element.
 // Before the action, it will try to scroll to the element and wait until it's interactable.
 func (el *Element) Hover() error {
-	pt, err := el.WaitInteractable()
+	pt, err := el.waitActionable(true, false)
 	if err != nil {
 		return err
 	}
@@ -100,38 +103,75 @@ func (el *Element) MoveMouseOut() error {
 }
 
 // Click will press then release the button just like a human.
-// Before the action, it will try to scroll to the element, hover the mouse over it,
-// wait until the it's interactable and enabled.
-func (el *Element) Click(button proto.InputMouseButton, clickCount int) error {
-	err := el.Hover()
+// Before the action, it will wait until the element is actionable, see [Element.WaitActionable],
+// then hover the mouse over it.
+func (el *Element) Click(button proto.InputMouseButton, clickCount int) (err error) {
+	done := el.page.tryHook(el, "click", button, clickCount)
+	defer func() { done(err) }()
+
+	pt, err := el.WaitActionable()
 	if err != nil {
 		return err
 	}
 
-	err = el.WaitEnabled()
+	mouse := el.page.Context(el.ctx).Mouse
+
+	err = mouse.MoveTo(*pt)
 	if err != nil {
 		return err
 	}
 
 	defer el.tryTrace(TraceTypeInput, string(button)+" click")()
 
-	return el.page.Context(el.ctx).Mouse.Click(button, clickCount)
+	return mouse.Click(button, clickCount)
 }
 
-// Tap will scroll to the button and tap it just like a human.
-// Before the action, it will try to scroll to the element and wait until it's interactable and enabled.
-func (el *Element) Tap() error {
-	err := el.ScrollIntoView()
+// ClickAt is similar to [Element.Click], but it clicks at the offset (x, y) from
+// the top-left corner of the element's box instead of the center, such as a spot of a slider or a canvas.
+func (el *Element) ClickAt(button proto.InputMouseButton, clickCount int, x, y float64) error {
+	return el.clickAt(button, clickCount, func(box *proto.DOMRect) proto.Point {
+		return proto.NewPoint(box.X+x, box.Y+y)
+	})
+}
+
+// ClickAtRatio is similar to [Element.ClickAt], but the offset is the ratio of the element's box size,
+// for example, (0.5, 0.5) is the center and (1, 1) is the bottom-right corner.
+func (el *Element) ClickAtRatio(button proto.InputMouseButton, clickCount int, rx, ry float64) error {
+	return el.clickAt(button, clickCount, func(box *proto.DOMRect) proto.Point {
+		return proto.NewPoint(box.X+box.Width*rx, box.Y+box.Height*ry)
+	})
+}
+
+func (el *Element) clickAt(button proto.InputMouseButton, clickCount int, point func(*proto.DOMRect) proto.Point) (err error) {
+	done := el.page.tryHook(el, "click", button, clickCount)
+	defer func() { done(err) }()
+
+	_, err = el.WaitActionable()
 	if err != nil {
 		return err
 	}
 
-	err = el.WaitEnabled()
+	shape, err := el.Shape()
 	if err != nil {
 		return err
 	}
 
-	pt, err := el.WaitInteractable()
+	mouse := el.page.Context(el.ctx).Mouse
+
+	err = mouse.MoveTo(point(shape.Box()))
+	if err != nil {
+		return err
+	}
+
+	defer el.tryTrace(TraceTypeInput, string(button)+" click")()
+
+	return mouse.Click(button, clickCount)
+}
+
+// Tap will scroll to the button and tap it just like a human.
+// Before the action, it will wait until the element is actionable, see [Element.WaitActionable].
+func (el *Element) Tap() error {
+	pt, err := el.WaitActionable()
 	if err != nil {
 		return err
 	}
@@ -145,7 +185,7 @@ func (el *Element) Tap() error {
 // The cursor can be mouse, finger, stylus, etc.
 // If not interactable err will be ErrNotInteractable, such as when covered by a modal,
 func (el *Element) Interactable() (pt *proto.Point, err error) {
-	noPointerEvents, err := el.Eval(`() => getComputedStyle(this).pointerEvents === 'none'`)
+	noPointerEvents, err := el.eval(`() => getComputedStyle(this).pointerEvents === 'none'`)
 	if err != nil {
 		return nil, err
 	}
@@ -165,15 +205,7 @@ func (el *Element) Interactable() (pt *proto.Point, err error) {
 		return
 	}
 
-	scroll, err := el.page.root.Context(el.ctx).Eval(`() => ({ x: window.scrollX, y: window.scrollY })`)
-	if err != nil {
-		return
-	}
-
-	elAtPoint, err := el.page.Context(el.ctx).ElementFromPoint(
-		int(pt.X)+scroll.Value.Get("x").Int(),
-		int(pt.Y)+scroll.Value.Get("y").Int(),
-	)
+	elAtPoint, err := el.page.Context(el.ctx).ElementFromViewportPoint(int(pt.X), int(pt.Y))
 	if err != nil {
 		if errors.Is(err, cdp.ErrNodeNotFoundAtPos) {
 			err = &ErrInvisibleShape{el}
@@ -203,6 +235,16 @@ func (el *Element) Shape() (*proto.DOMGetContentQuadsResult, error) {
 	return proto.DOMGetContentQuads{ObjectID: el.id()}.Call(el)
 }
 
+// BoxModel of the DOM element, it contains the content, padding, border, and margin boxes of the element.
+// Use [proto.DOMQuad.Center] to get the center point of a box.
+func (el *Element) BoxModel() (*proto.DOMBoxModel, error) {
+	res, err := proto.DOMGetBoxModel{ObjectID: el.id()}.Call(el)
+	if err != nil {
+		return nil, err
+	}
+	return res.Model, nil
+}
+
 // Type is similar with Keyboard.Type.
 // Before the action, it will try to scroll to the element and focus on it.
 func (el *Element) Type(keys ...input.Key) error {
@@ -213,6 +255,16 @@ func (el *Element) Type(keys ...input.Key) error {
 	return el.page.Context(el.ctx).Keyboard.Type(keys...)
 }
 
+// TypeDelay is similar with Keyboard.TypeDelay.
+// Before the action, it will try to scroll to the element and focus on it.
+func (el *Element) TypeDelay(sleeper utils.Sleeper, keys ...input.Key) error {
+	err := el.Focus()
+	if err != nil {
+		return err
+	}
+	return el.page.Context(el.ctx).Keyboard.TypeDelay(sleeper, keys...)
+}
+
 // KeyActions is similar with Page.KeyActions.
 // Before the action, it will try to scroll to the element and focus on it.
 func (el *Element) KeyActions() (*KeyActions, error) {
@@ -235,7 +287,7 @@ func (el *Element) SelectText(regex string) error {
 	defer el.tryTrace(TraceTypeInput, "select text: "+regex)()
 	el.page.browser.trySlowMotion()
 
-	_, err = el.Evaluate(evalHelper(js.SelectText, regex).ByUser())
+	_, err = el.Evaluate(EvalHelper(js.SelectText, regex).ByUser())
 	return err
 }
 
@@ -250,22 +302,26 @@ func (el *Element) SelectAllText() error {
 	defer el.tryTrace(TraceTypeInput, "select all text")()
 	el.page.browser.trySlowMotion()
 
-	_, err = el.Evaluate(evalHelper(js.SelectAllText).ByUser())
+	_, err = el.Evaluate(EvalHelper(js.SelectAllText).ByUser())
 	return err
 }
 
 // Input focuses on the element and input text to it.
-// Before the action, it will scroll to the element, wait until it's visible, enabled and writable.
+// Before the action, it fails if the element isn't visible, then it will wait until it's enabled,
+// scroll to it, and wait until it's writable.
 // To empty the input you can use something like
 //
 //	el.SelectAllText().MustInput("")
-func (el *Element) Input(text string) error {
-	err := el.Focus()
+func (el *Element) Input(text string) (err error) {
+	done := el.page.tryHook(el, "input", text)
+	defer func() { done(err) }()
+
+	_, err = el.waitActionable(false, true)
 	if err != nil {
 		return err
 	}
 
-	err = el.WaitEnabled()
+	err = el.Focus()
 	if err != nil {
 		return err
 	}
@@ -276,20 +332,45 @@ func (el *Element) Input(text string) error {
 	}
 
 	err = el.page.Context(el.ctx).InsertText(text)
-	_, _ = el.Evaluate(evalHelper(js.InputEvent).ByUser())
+	_, _ = el.Evaluate(EvalHelper(js.InputEvent).ByUser())
 	return err
 }
 
+// InputTOTP inputs the current time-based one-time password of the base32 secret to the element,
+// such as to log in a 2FA-protected test account, see [utils.TOTP]. If the code expires within 3 seconds,
+// it waits for the next code, so the code won't expire before it's submitted.
+func (el *Element) InputTOTP(secret string) error {
+	now := time.Now()
+	if left := utils.TOTPPeriod - time.Duration(now.UnixNano())%utils.TOTPPeriod; left < 3*time.Second {
+		t := time.NewTimer(left)
+		defer t.Stop()
+
+		select {
+		case <-el.ctx.Done():
+			return el.ctx.Err()
+		case <-t.C:
+		}
+		now = now.Add(left)
+	}
+
+	code, err := utils.TOTP(secret, now)
+	if err != nil {
+		return err
+	}
+
+	return el.Input(code)
+}
+
 // InputTime focuses on the element and input time to it.
-// Before the action, it will scroll to the element, wait until it's visible, enabled and writable.
-// It will wait until the element is visible, enabled and writable.
+// Before the action, it fails if the element isn't visible, then it will wait until it's enabled,
+// scroll to it, and wait until it's writable.
 func (el *Element) InputTime(t time.Time) error {
-	err := el.Focus()
+	_, err := el.waitActionable(false, true)
 	if err != nil {
 		return err
 	}
 
-	err = el.WaitEnabled()
+	err = el.Focus()
 	if err != nil {
 		return err
 	}
@@ -301,7 +382,7 @@ func (el *Element) InputTime(t time.Time) error {
 
 	defer el.tryTrace(TraceTypeInput, "input "+t.String())()
 
-	_, err = el.Evaluate(evalHelper(js.InputTime, t.UnixNano()/1e6).ByUser())
+	_, err = el.Evaluate(EvalHelper(js.InputTime, t.UnixNano()/1e6).ByUser())
 	return err
 }
 
@@ -312,10 +393,15 @@ func (el *Element) Blur() error {
 }
 
 // Select the children option elements that match the selectors.
-// Before the action, it will scroll to the element, wait until it's visible.
+// Before the action, it fails if the element isn't visible, then it will wait until it's enabled and scroll to it.
 // If no option matches the selectors, it will return [ErrElementNotFound].
 func (el *Element) Select(selectors []string, selected bool, t SelectorType) error {
-	err := el.Focus()
+	_, err := el.waitActionable(false, true)
+	if err != nil {
+		return err
+	}
+
+	err = el.Focus()
 	if err != nil {
 		return err
 	}
@@ -323,7 +409,7 @@ func (el *Element) Select(selectors []string, selected bool, t SelectorType) err
 	defer el.tryTrace(TraceTypeInput, fmt.Sprintf(`select "%s"`, strings.Join(selectors, "; ")))()
 	el.page.browser.trySlowMotion()
 
-	res, err := el.Evaluate(evalHelper(js.Select, selectors, selected, t).ByUser())
+	res, err := el.Evaluate(EvalHelper(js.Select, selectors, selected, t).ByUser())
 	if err != nil {
 		return err
 	}
@@ -335,7 +421,7 @@ func (el *Element) Select(selectors []string, selected bool, t SelectorType) err
 
 // Matches checks if the element can be selected by the css selector
 func (el *Element) Matches(selector string) (bool, error) {
-	res, err := el.Eval(`s => this.matches(s)`, selector)
+	res, err := el.eval(`s => this.matches(s)`, selector)
 	if err != nil {
 		return false, err
 	}
@@ -345,7 +431,7 @@ func (el *Element) Matches(selector string) (bool, error) {
 // Attribute of the DOM object.
 // Attribute vs Property: https://stackoverflow.com/questions/6003819/what-is-the-difference-between-properties-and-attributes-in-html
 func (el *Element) Attribute(name string) (*string, error) {
-	attr, err := el.Eval("(n) => this.getAttribute(n)", name)
+	attr, err := el.eval("(n) => this.getAttribute(n)", name)
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +447,7 @@ func (el *Element) Attribute(name string) (*string, error) {
 // Property of the DOM object.
 // Property vs Attribute: https://stackoverflow.com/questions/6003819/what-is-the-difference-between-properties-and-attributes-in-html
 func (el *Element) Property(name string) (gson.JSON, error) {
-	prop, err := el.Eval("(n) => this[n]", name)
+	prop, err := el.eval("(n) => this[n]", name)
 	if err != nil {
 		return gson.New(nil), err
 	}
@@ -446,7 +532,7 @@ func (el *Element) Frame() (*Page, error) {
 
 // ContainsElement check if the target is equal or inside the element.
 func (el *Element) ContainsElement(target *Element) (bool, error) {
-	res, err := el.Evaluate(evalHelper(js.ContainsElement, target.Object))
+	res, err := el.Evaluate(EvalHelper(js.ContainsElement, target.Object))
 	if err != nil {
 		return false, err
 	}
@@ -455,13 +541,54 @@ func (el *Element) ContainsElement(target *Element) (bool, error) {
 
 // Text that the element displays
 func (el *Element) Text() (string, error) {
-	str, err := el.Evaluate(evalHelper(js.Text))
+	str, err := el.Evaluate(EvalHelper(js.Text))
 	if err != nil {
 		return "", err
 	}
 	return str.Value.String(), nil
 }
 
+// Table is the cell texts of a table element, see [Element.Table]
+type Table struct {
+	// Header is the last row of the thead, or the first row if all its cells are th.
+	// It's empty if the table has no header.
+	Header []string `json:"header"`
+
+	// Rows of the table without the header rows
+	Rows [][]string `json:"rows"`
+}
+
+// Maps returns a map for each row, the keys are the texts of the header.
+// If the table has no header, the keys are the column indexes, such as "0", "1".
+func (t *Table) Maps() []map[string]string {
+	list := []map[string]string{}
+	for _, row := range t.Rows {
+		m := map[string]string{}
+		for i, cell := range row {
+			key := strconv.Itoa(i)
+			if i < len(t.Header) {
+				key = t.Header[i]
+			}
+			m[key] = cell
+		}
+		list = append(list, m)
+	}
+	return list
+}
+
+// Table converts the table element into the cell texts. The cell that spans multiple rows or columns
+// will be repeated in each of them, so every row has the same length.
+func (el *Element) Table() (*Table, error) {
+	res, err := el.Evaluate(EvalHelper(js.Table))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Table{}
+	err = res.Value.Unmarshal(t)
+	return t, err
+}
+
 // HTML of the element
 func (el *Element) HTML() (string, error) {
 	res, err := proto.DOMGetOuterHTML{ObjectID: el.Object.ObjectID}.Call(el)
@@ -473,7 +600,7 @@ func (el *Element) HTML() (string, error) {
 
 // Visible returns true if the element is visible on the page
 func (el *Element) Visible() (bool, error) {
-	res, err := el.Evaluate(evalHelper(js.Visible))
+	res, err := el.Evaluate(EvalHelper(js.Visible))
 	if err != nil {
 		return false, err
 	}
@@ -483,10 +610,98 @@ func (el *Element) Visible() (bool, error) {
 // WaitLoad for element like <img>
 func (el *Element) WaitLoad() error {
 	defer el.tryTrace(TraceTypeWait, "load")()
-	_, err := el.Evaluate(evalHelper(js.WaitLoad).ByPromise())
+	_, err := el.Evaluate(EvalHelper(js.WaitLoad).ByPromise())
 	return err
 }
 
+// MutationOptions is the same as the options of the js MutationObserver.observe
+type MutationOptions struct {
+	ChildList             bool     `json:"childList,omitempty"`
+	Attributes            bool     `json:"attributes,omitempty"`
+	CharacterData         bool     `json:"characterData,omitempty"`
+	Subtree               bool     `json:"subtree,omitempty"`
+	AttributeOldValue     bool     `json:"attributeOldValue,omitempty"`
+	CharacterDataOldValue bool     `json:"characterDataOldValue,omitempty"`
+	AttributeFilter       []string `json:"attributeFilter,omitempty"`
+}
+
+// Mutation is a simplified js MutationRecord
+type Mutation struct {
+	// Type is "attributes", "characterData", or "childList"
+	Type string `json:"type"`
+
+	// Target is the description of the changed node, such as "div#app"
+	Target string `json:"target"`
+
+	AttributeName string  `json:"attributeName,omitempty"`
+	OldValue      *string `json:"oldValue,omitempty"`
+
+	// AddedNodes is the count of the added nodes
+	AddedNodes int `json:"addedNodes"`
+
+	// RemovedNodes is the count of the removed nodes
+	RemovedNodes int `json:"removedNodes"`
+}
+
+// ObserveMutations calls fn with each batch of the mutations of the element until stop is called.
+// If opts is nil, all the changes of the element and its descendants will be observed.
+func (el *Element) ObserveMutations(opts *MutationOptions, fn func([]*Mutation)) (stop func() error, err error) {
+	name := "_" + utils.RandString(8)
+
+	stopExpose, err := el.page.Context(el.ctx).Expose(name, func(j gson.JSON) (interface{}, error) {
+		list := []*Mutation{}
+		err := j.Unmarshal(&list)
+		if err == nil {
+			fn(list)
+		}
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	observer, err := el.Evaluate(EvalHelper(js.ObserveMutations, name, opts).ByObject())
+	if err != nil {
+		_ = stopExpose()
+		return nil, err
+	}
+
+	return func() error {
+		_, err := el.page.Evaluate(Eval(`function() { this.disconnect() }`).This(observer))
+		if err != nil {
+			return err
+		}
+		return stopExpose()
+	}, nil
+}
+
+// WaitMutation returns wait, it waits until the element changes and returns the first batch of the mutations.
+// The changes before WaitMutation is called are ignored. The opts is the same as [Element.ObserveMutations].
+func (el *Element) WaitMutation(opts *MutationOptions) (wait func() ([]*Mutation, error)) {
+	ch := make(chan []*Mutation, 1)
+
+	stop, err := el.ObserveMutations(opts, func(list []*Mutation) {
+		select {
+		case ch <- list:
+		default:
+		}
+	})
+
+	return func() ([]*Mutation, error) {
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = stop() }()
+
+		select {
+		case <-el.ctx.Done():
+			return nil, el.ctx.Err()
+		case list := <-ch:
+			return list, nil
+		}
+	}
+}
+
 // WaitStable waits until no shape or position change for d duration.
 // Be careful, d is not the max wait timeout, it's the least stable time.
 // If you want to set a timeout you can use the [Element.Timeout] function.
@@ -578,6 +793,89 @@ func (el *Element) WaitInteractable() (pt *proto.Point, err error) {
 	return
 }
 
+// WaitActionable waits until the element is ready for the cursor actions, the checks run in order:
+// attached to the document, visible, stable, receives the pointer events, and enabled.
+// The stable check scrolls the element into view and waits until its shape is the same between two
+// animation frames, see [Element.WaitStableRAF].
+// It returns the point to act on. The wait is limited by [Browser.ActionTimeout], when the limit is reached
+// the err will be [ErrNotActionable] with the name of the check that was still waiting.
+// The [Element.Click], [Element.ClickAt] and [Element.Tap] call it before the action.
+func (el *Element) WaitActionable() (pt *proto.Point, err error) {
+	return el.waitActionable(true, true)
+}
+
+// waitActionable runs the checks of [Element.WaitActionable].
+// The actions without cursor, such as Input, skip the stable and receives events checks, because they
+// focus the element instead of moving the cursor to it, and they fail fast if the element isn't visible.
+// The Hover skips the enabled check.
+func (el *Element) waitActionable(cursor, enabled bool) (pt *proto.Point, err error) {
+	defer el.tryTrace(TraceTypeWait, "actionable")()
+
+	e := el
+	if d := el.page.browser.actionTimeout; d > 0 {
+		e = el.Timeout(d)
+		defer e.CancelTimeout()
+	}
+
+	check := func(name string, fn func() error) error {
+		err := fn()
+		if errors.Is(err, context.DeadlineExceeded) {
+			return &ErrNotActionable{el, name, err}
+		}
+		return err
+	}
+
+	err = check("attached", func() error {
+		res, err := e.eval(`() => this.isConnected`)
+		if err != nil {
+			return err
+		}
+		if !res.Value.Bool() {
+			return &ErrNotActionable{el, "attached", cdp.ErrNodeDetached}
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+
+	if cursor {
+		err = check("visible", e.WaitVisible)
+		if err != nil {
+			return
+		}
+
+		err = check("stable", e.ScrollIntoView)
+		if err != nil {
+			return
+		}
+
+		err = check("receives events", func() (err error) {
+			pt, err = e.WaitInteractable()
+			return
+		})
+	} else {
+		err = check("visible", func() error {
+			visible, err := e.Visible()
+			if err != nil {
+				return err
+			}
+			if !visible {
+				return &ErrNotActionable{el, "visible", &ErrInvisibleShape{el}}
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return
+	}
+
+	if enabled {
+		err = check("enabled", e.WaitEnabled)
+	}
+	return
+}
+
 // Wait until the js returns true
 func (el *Element) Wait(opts *EvalOptions) error {
 	return el.page.Context(el.ctx).Sleeper(el.sleeper).Wait(opts.This(el.Object))
@@ -586,7 +884,7 @@ func (el *Element) Wait(opts *EvalOptions) error {
 // WaitVisible until the element is visible
 func (el *Element) WaitVisible() error {
 	defer el.tryTrace(TraceTypeWait, "visible")()
-	return el.Wait(evalHelper(js.Visible))
+	return el.Wait(EvalHelper(js.Visible))
 }
 
 // WaitEnabled until the element is not disabled.
@@ -606,7 +904,7 @@ func (el *Element) WaitWritable() error {
 // WaitInvisible until the element invisible
 func (el *Element) WaitInvisible() error {
 	defer el.tryTrace(TraceTypeWait, "invisible")()
-	return el.Wait(evalHelper(js.Invisible))
+	return el.Wait(EvalHelper(js.Invisible))
 }
 
 // CanvasToImage get image data of a canvas.
@@ -614,7 +912,7 @@ func (el *Element) WaitInvisible() error {
 // The default quality is 0.92.
 // doc: https://developer.mozilla.org/en-US/docs/Web/API/HTMLCanvasElement/toDataURL
 func (el *Element) CanvasToImage(format string, quality float64) ([]byte, error) {
-	res, err := el.Eval(`(format, quality) => this.toDataURL(format, quality)`, format, quality)
+	res, err := el.eval(`(format, quality) => this.toDataURL(format, quality)`, format, quality)
 	if err != nil {
 		return nil, err
 	}
@@ -625,7 +923,7 @@ func (el *Element) CanvasToImage(format string, quality float64) ([]byte, error)
 
 // Resource returns the "src" content of current element. Such as the jpg of <img src="a.jpg">
 func (el *Element) Resource() ([]byte, error) {
-	src, err := el.Evaluate(evalHelper(js.Resource).ByPromise())
+	src, err := el.Evaluate(EvalHelper(js.Resource).ByPromise())
 	if err != nil {
 		return nil, err
 	}
@@ -635,7 +933,7 @@ func (el *Element) Resource() ([]byte, error) {
 
 // BackgroundImage returns the css background-image of the element
 func (el *Element) BackgroundImage() ([]byte, error) {
-	res, err := el.Eval(`() => window.getComputedStyle(this).backgroundImage.replace(/^url\("/, '').replace(/"\)$/, '')`)
+	res, err := el.eval(`() => window.getComputedStyle(this).backgroundImage.replace(/^url\("/, '').replace(/"\)$/, '')`)
 	if err != nil {
 		return nil, err
 	}
@@ -645,8 +943,35 @@ func (el *Element) BackgroundImage() ([]byte, error) {
 	return el.page.Context(el.ctx).GetResource(u)
 }
 
+// ScreenshotImage is similar to [Element.Screenshot], but it returns the decoded png image.
+func (el *Element) ScreenshotImage() (image.Image, error) {
+	bin, err := el.Screenshot(proto.PageCaptureScreenshotFormatPng, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(bin))
+	return img, err
+}
+
 // Screenshot of the area of the element
 func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
+	return el.screenshot(1, format, quality)
+}
+
+// ScreenshotScaled is similar to [Element.Screenshot], but it renders the element at the device scale factor,
+// such as 2 for the retina-quality golden images, see [Page.ScreenshotScaled].
+func (el *Element) ScreenshotScaled(scale float64, format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
+	var bin []byte
+	err := el.page.Context(el.ctx).withScaleFactor(scale, func() (err error) {
+		bin, err = el.screenshot(scale, format, quality)
+		return
+	})
+	return bin, err
+}
+
+// the scale is the ratio of the device pixels of the screenshot to the css pixels of the element's box
+func (el *Element) screenshot(scale float64, format proto.PageCaptureScreenshotFormat, quality int) ([]byte, error) {
 	err := el.ScrollIntoView()
 	if err != nil {
 		return nil, err
@@ -672,10 +997,10 @@ func (el *Element) Screenshot(format proto.PageCaptureScreenshotFormat, quality
 
 	// TODO: proto.PageCaptureScreenshot has a Clip option, but it's buggy, so now we do in Go.
 	return utils.CropImage(bin, quality,
-		int(box.X),
-		int(box.Y),
-		int(box.Width),
-		int(box.Height),
+		int(box.X*scale),
+		int(box.Y*scale),
+		int(box.Width*scale),
+		int(box.Height*scale),
 	)
 }
 
@@ -686,7 +1011,7 @@ func (el *Element) Release() error {
 
 // Remove the element from the page
 func (el *Element) Remove() error {
-	_, err := el.Eval(`() => this.remove()`)
+	_, err := el.eval(`() => this.remove()`)
 	if err != nil {
 		return err
 	}
@@ -699,7 +1024,15 @@ func (el *Element) Call(ctx context.Context, sessionID, methodName string, param
 }
 
 // Eval is a shortcut for [Element.Evaluate] with AwaitPromise, ByValue and AutoExp set to true.
-func (el *Element) Eval(js string, params ...interface{}) (*proto.RuntimeRemoteObject, error) {
+func (el *Element) Eval(js string, params ...interface{}) (res *proto.RuntimeRemoteObject, err error) {
+	done := el.page.tryHook(el, "eval", append([]interface{}{js}, params...)...)
+	defer func() { done(err) }()
+
+	return el.eval(js, params...)
+}
+
+// eval is similar to [Element.Eval] but without the action hooks, it's for the internal calls of rod
+func (el *Element) eval(js string, params ...interface{}) (*proto.RuntimeRemoteObject, error) {
 	return el.Evaluate(Eval(js, params...).ByPromise())
 }
 
@@ -710,7 +1043,7 @@ func (el *Element) Evaluate(opts *EvalOptions) (*proto.RuntimeRemoteObject, erro
 
 // Equal checks if the two elements are equal.
 func (el *Element) Equal(elm *Element) (bool, error) {
-	res, err := el.Eval(`elm => this === elm`, elm.Object)
+	res, err := el.eval(`elm => this === elm`, elm.Object)
 	return res.Value.Bool(), err
 }
 
@@ -720,7 +1053,7 @@ func (el *Element) id() proto.RuntimeRemoteObjectID {
 
 // GetXPath returns the xpath of the element
 func (el *Element) GetXPath(optimized bool) (string, error) {
-	str, err := el.Evaluate(evalHelper(js.GetXPath, optimized))
+	str, err := el.Evaluate(EvalHelper(js.GetXPath, optimized))
 	if err != nil {
 		return "", err
 	}
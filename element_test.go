@@ -2,6 +2,7 @@ package rod_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"image/color"
 	"image/png"
@@ -42,7 +43,8 @@ func TestClick(t *testing.T) {
 		el.MustClick()
 	})
 	g.Panic(func() {
-		g.mc.stubErr(8, proto.RuntimeCallFunctionOn{})
+		// the enabled check
+		g.mc.stubErr(13, proto.RuntimeCallFunctionOn{})
 		el.MustClick()
 	})
 }
@@ -60,6 +62,76 @@ func TestClickWrapped(t *testing.T) {
 	g.True(p.MustHas("[a=ok]"))
 }
 
+func TestBoxModel(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><body style="margin: 0">
+		<div style="width: 100px; height: 50px; padding: 10px; border: 5px solid; margin: 20px"></div>
+	</body></html>`))
+	el := p.MustElement("div")
+
+	m := el.MustBoxModel()
+	g.Eq(m.Width, 130)
+	g.Eq(m.Height, 80)
+	g.Eq(proto.Shape{m.Content}.Box().Width, 100.0)
+	g.Eq(proto.Shape{m.Padding}.Box().Width, 120.0)
+	g.Eq(proto.Shape{m.Border}.Box().Width, 130.0)
+	g.Eq(proto.Shape{m.Margin}.Box().Width, 170.0)
+	g.Eq(m.Content.Center(), proto.Point{X: 85, Y: 60})
+
+	g.mc.stubErr(1, proto.DOMGetBoxModel{})
+	g.Err(el.BoxModel())
+}
+
+func TestClickAt(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><body>
+		<div style="width: 200px; height: 100px" onclick="this.dataset.pt = event.offsetX + ',' + event.offsetY"></div>
+	</body></html>`))
+	el := p.MustElement("div")
+
+	el.MustClickAt(10, 20)
+	g.Eq(*el.MustAttribute("data-pt"), "10,20")
+
+	el.MustClickAtRatio(0.5, 0.25)
+	g.Eq(*el.MustAttribute("data-pt"), "100,25")
+
+	g.mc.stubErr(1, proto.DOMGetContentQuads{})
+	g.Err(el.ClickAt(proto.InputMouseButtonLeft, 1, 0, 0))
+}
+
+func TestElementMutation(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><body><ul id="list"></ul></body></html>`))
+	el := p.MustElement("ul")
+
+	wait := el.MustWaitMutation(nil)
+	el.MustEval(`() => this.appendChild(document.createElement('li'))`)
+	list := wait()
+	g.Eq(list[0].Type, "childList")
+	g.Eq(list[0].Target, "ul#list")
+	g.Eq(list[0].AddedNodes, 1)
+
+	ch := make(chan *rod.Mutation, 10)
+	stop := el.MustObserveMutations(&rod.MutationOptions{Attributes: true, AttributeOldValue: true}, func(l []*rod.Mutation) {
+		ch <- l[0]
+	})
+	el.MustEval(`() => this.setAttribute('a', '1')`)
+	m := <-ch
+	g.Eq(m.Type, "attributes")
+	g.Eq(m.AttributeName, "a")
+	g.Nil(m.OldValue)
+	stop()
+
+	g.mc.stubErr(1, proto.RuntimeAddBinding{})
+	g.Err(el.WaitMutation(nil)())
+
+	g.mc.stubErr(3, proto.RuntimeCallFunctionOn{})
+	g.Err(el.ObserveMutations(nil, nil))
+}
+
 func TestTap(t *testing.T) {
 	g := setup(t)
 
@@ -83,10 +155,12 @@ func TestTap(t *testing.T) {
 		el.MustTap()
 	})
 	g.Panic(func() {
-		g.mc.stubErr(4, proto.RuntimeCallFunctionOn{})
+		// the enabled check
+		g.mc.stubErr(13, proto.RuntimeCallFunctionOn{})
 		el.MustTap()
 	})
 	g.Panic(func() {
+		// the scroll of the receives events check
 		g.mc.stubErr(7, proto.RuntimeCallFunctionOn{})
 		el.MustTap()
 	})
@@ -194,6 +268,69 @@ func TestWaitInteractable(t *testing.T) {
 	g.Err(el.WaitInteractable())
 }
 
+func TestWaitActionable(t *testing.T) {
+	g := setup(t)
+
+	g.browser.ActionTimeout(300 * time.Millisecond)
+	defer g.browser.ActionTimeout(0)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	el := p.MustElement("button").MustWaitActionable()
+
+	p.MustWaitLoad().MustEval(`() => {
+		let div = document.createElement('div')
+		div.style = 'position: absolute; left: 0; top: 0; width: 500px; height: 500px;'
+		document.body.append(div)
+	}`)
+	err := el.Click(proto.InputMouseButtonLeft, 1)
+	var e *rod.ErrNotActionable
+	g.True(errors.As(err, &e))
+	g.Eq(e.Check, "receives events")
+	g.Is(err, context.DeadlineExceeded)
+	p.MustElement("div").MustRemove()
+
+	el.MustEval(`() => this.disabled = true`)
+	g.True(errors.As(el.Click(proto.InputMouseButtonLeft, 1), &e))
+	g.Eq(e.Check, "enabled")
+	el.MustEval(`() => this.disabled = false`)
+
+	el.MustEval(`() => this.style.visibility = 'hidden'`)
+	g.True(errors.As(el.Hover(), &e))
+	g.Eq(e.Check, "visible")
+	el.MustEval(`() => this.style.visibility = ''`)
+
+	el.MustRemove()
+	err = el.Click(proto.InputMouseButtonLeft, 1)
+	g.True(errors.As(err, &e))
+	g.Eq(e.Check, "attached")
+	g.Is(err, cdp.ErrNodeDetached)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(el.WaitActionable())
+
+	p = g.page.MustNavigate(g.html(`<html><body>
+		<style>@keyframes move { from { left: 0 } to { left: 300px } }</style>
+		<input id="moving" style="position: relative; animation: move 1s infinite alternate">
+		<input id="hidden" style="display: none">
+		<input id="ok">
+	</body></html>`))
+
+	g.True(errors.As(p.MustElement("#moving").Click(proto.InputMouseButtonLeft, 1), &e))
+	g.Eq(e.Check, "stable")
+
+	// the input actions fail fast on an invisible element, so they won't hang without the ActionTimeout
+	g.browser.ActionTimeout(0)
+	err = p.MustElement("#hidden").Input("a")
+	g.True(errors.As(err, &e))
+	g.Eq(e.Check, "visible")
+	g.Is(err, &rod.ErrInvisibleShape{})
+	g.True(errors.As(p.MustElement("#hidden").InputTime(time.Now()), &e))
+	g.True(errors.As(p.MustElement("#hidden").Select([]string{"a"}, true, rod.SelectorTypeText), &e))
+
+	p.MustElement("#ok").MustInput("ok")
+	g.Eq(p.MustElement("#ok").MustProperty("value").Str(), "ok")
+}
+
 func TestHover(t *testing.T) {
 	g := setup(t)
 
@@ -209,7 +346,8 @@ func TestHover(t *testing.T) {
 	g.mc.stubErr(1, proto.DOMGetContentQuads{})
 	g.Err(el.Hover())
 
-	g.mc.stubErr(3, proto.DOMGetContentQuads{})
+	// the shape of the receives events check
+	g.mc.stubErr(5, proto.DOMGetContentQuads{})
 	g.Err(el.Hover())
 
 	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
@@ -331,15 +469,18 @@ func TestInputTime(t *testing.T) {
 		el.MustInputTime(now)
 	})
 	g.Panic(func() {
-		g.mc.stubErr(5, proto.RuntimeCallFunctionOn{})
+		// the enabled check
+		g.mc.stubErr(3, proto.RuntimeCallFunctionOn{})
 		el.MustInputTime(now)
 	})
 	g.Panic(func() {
-		g.mc.stubErr(6, proto.RuntimeCallFunctionOn{})
+		// the writable check
+		g.mc.stubErr(8, proto.RuntimeCallFunctionOn{})
 		el.MustInputTime(now)
 	})
 	g.Panic(func() {
-		g.mc.stubErr(7, proto.RuntimeCallFunctionOn{})
+		// the input time
+		g.mc.stubErr(9, proto.RuntimeCallFunctionOn{})
 		el.MustInputTime(now)
 	})
 }
@@ -436,7 +577,8 @@ func TestSelectOptions(t *testing.T) {
 	g.Is(el.Select([]string{"not-exists"}, true, rod.SelectorTypeCSSSector), &rod.ErrElementNotFound{})
 
 	{
-		g.mc.stubErr(5, proto.RuntimeCallFunctionOn{})
+		// the select
+		g.mc.stubErr(8, proto.RuntimeCallFunctionOn{})
 		g.Err(el.Select([]string{"B"}, true, rod.SelectorTypeText))
 	}
 }
@@ -477,6 +619,74 @@ func TestAttribute(t *testing.T) {
 	})
 }
 
+func TestElementTable(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><body>
+		<table id="a">
+			<thead>
+				<tr><th rowspan="2">Name</th><th colspan="2">Score</th></tr>
+				<tr><th>Math</th><th>Art</th></tr>
+			</thead>
+			<tbody>
+				<tr><td>Jack</td><td rowspan="2">90</td><td>80</td></tr>
+				<tr><td>Lily</td><td>70</td></tr>
+				<tr><td colspan="3">Total</td></tr>
+			</tbody>
+		</table>
+		<table id="b">
+			<tr><th>x</th><th>y</th></tr>
+			<tr><td>1</td><td>2</td><td>3</td></tr>
+		</table>
+		<table id="c">
+			<tr><td>1</td></tr>
+		</table>
+	</body></html>`))
+
+	table := p.MustElement("#a").MustTable()
+	g.Eq(table.Header, []string{"Name", "Math", "Art"})
+	g.Eq(table.Rows, [][]string{
+		{"Jack", "90", "80"},
+		{"Lily", "90", "70"},
+		{"Total", "Total", "Total"},
+	})
+	g.Eq(table.Maps()[1], map[string]string{"Name": "Lily", "Math": "90", "Art": "70"})
+
+	table = p.MustElement("#b").MustTable()
+	g.Eq(table.Header, []string{"x", "y", ""})
+	g.Eq(table.Maps(), []map[string]string{{"x": "1", "y": "2", "": "3"}})
+
+	table = p.MustElement("#c").MustTable()
+	g.Len(table.Header, 0)
+	g.Eq(table.Maps(), []map[string]string{{"0": "1"}})
+
+	el := p.MustElement("#a")
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		el.MustTable()
+	})
+}
+
+func TestElementInputTOTP(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><input></html>`))
+	el := p.MustElement("input").MustInputTOTP("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+
+	now := time.Now()
+	codes := []string{}
+	for _, d := range []time.Duration{-utils.TOTPPeriod, 0, utils.TOTPPeriod} {
+		code, err := utils.TOTP("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ", now.Add(d))
+		g.E(err)
+		codes = append(codes, code)
+	}
+	g.Has(codes, el.MustText())
+
+	g.Err(el.InputTOTP("1"))
+
+	g.Err(p.MustElement("input").Context(g.Timeout(0)).InputTOTP("GEZDGNBV"))
+}
+
 func TestProperty(t *testing.T) {
 	g := setup(t)
 
@@ -700,10 +910,18 @@ func TestElementScreenshot(t *testing.T) {
 	g.Eq(30, img.Bounds().Dy())
 	g.Nil(os.Stat(f))
 
+	img = el.MustScreenshotImage()
+	g.Eq(200, img.Bounds().Dx())
+	g.Eq(30, img.Bounds().Dy())
+
 	g.Panic(func() {
 		g.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
 		el.MustScreenshot()
 	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.DOMScrollIntoViewIfNeeded{})
+		el.MustScreenshotImage()
+	})
 	g.Panic(func() {
 		g.mc.stubErr(1, proto.PageCaptureScreenshot{})
 		el.MustScreenshot()
@@ -714,6 +932,37 @@ func TestElementScreenshot(t *testing.T) {
 	})
 }
 
+func TestElementScreenshotScaled(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+	el := p.MustElement("h4")
+
+	img, err := png.Decode(bytes.NewBuffer(el.MustScreenshotScaled(2)))
+	g.E(err)
+	g.Eq(400, img.Bounds().Dx())
+	g.Eq(60, img.Bounds().Dy())
+
+	// the device metrics are restored
+	g.Eq(p.MustEval(`() => devicePixelRatio`).Num(), 1.0)
+
+	img, err = png.Decode(bytes.NewBuffer(p.MustScreenshotScaled(2)))
+	g.E(err)
+	g.Eq(p.MustEval(`() => innerWidth`).Int()*2, img.Bounds().Dx())
+
+	// without the device metrics override
+	g.E(p.SetViewport(nil))
+	img, err = png.Decode(bytes.NewBuffer(el.MustScreenshotScaled(2)))
+	g.E(err)
+	g.Eq(400, img.Bounds().Dx())
+
+	g.mc.stubErr(1, proto.EmulationSetDeviceMetricsOverride{})
+	g.Err(el.ScreenshotScaled(2, proto.PageCaptureScreenshotFormatPng, 0))
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ScreenshotScaled(2, false, nil))
+}
+
 func TestUseReleasedElement(t *testing.T) {
 	g := setup(t)
 
@@ -846,6 +1095,18 @@ func TestElementFromPointErr(t *testing.T) {
 	g.Err(g.page.ElementFromPoint(10, 10))
 }
 
+func TestElementFromViewportPoint(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/scroll.html"))
+	p.MustEval(`() => window.scrollTo(2000, 1500)`)
+
+	g.Eq(p.MustElementFromViewportPoint(10, 10).MustText(), "button")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ElementFromViewportPoint(10, 10))
+}
+
 func TestElementFromNodeErr(t *testing.T) {
 	g := setup(t)
 
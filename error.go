@@ -60,6 +60,9 @@ func (e *ErrElementNotFound) Error() string {
 	return "cannot find element"
 }
 
+// Is interface
+func (e *ErrElementNotFound) Is(err error) bool { _, ok := err.(*ErrElementNotFound); return ok }
+
 // NotFoundSleeper returns ErrElementNotFound on the first call
 func NotFoundSleeper() utils.Sleeper {
 	return func(context.Context) error {
@@ -104,6 +107,20 @@ func (e *ErrNavigation) Error() string {
 // Is interface
 func (e *ErrNavigation) Is(err error) bool { _, ok := err.(*ErrNavigation); return ok }
 
+// ErrHTTPStatus error. The status code of the main document of a navigation is 4xx or 5xx,
+// see [Browser.FailOnHTTPError].
+type ErrHTTPStatus struct {
+	Status int
+	URL    string
+}
+
+func (e *ErrHTTPStatus) Error() string {
+	return fmt.Sprintf("navigation failed: http status %d: %s", e.Status, e.URL)
+}
+
+// Is interface
+func (e *ErrHTTPStatus) Is(err error) bool { _, ok := err.(*ErrHTTPStatus); return ok }
+
 // ErrPageCloseCanceled error
 type ErrPageCloseCanceled struct{}
 
@@ -111,6 +128,9 @@ func (e *ErrPageCloseCanceled) Error() string {
 	return "page close canceled"
 }
 
+// Is interface
+func (e *ErrPageCloseCanceled) Is(err error) bool { _, ok := err.(*ErrPageCloseCanceled); return ok }
+
 // ErrNotInteractable error. Check the doc of Element.Interactable for details.
 type ErrNotInteractable struct{}
 
@@ -118,6 +138,9 @@ func (e *ErrNotInteractable) Error() string {
 	return "element is not cursor interactable"
 }
 
+// Is interface
+func (e *ErrNotInteractable) Is(err error) bool { _, ok := err.(*ErrNotInteractable); return ok }
+
 // ErrInvisibleShape error.
 type ErrInvisibleShape struct {
 	*Element
@@ -172,6 +195,55 @@ func (e *ErrNoPointerEvents) Unwrap() error {
 // Is interface
 func (e *ErrNoPointerEvents) Is(err error) bool { _, ok := err.(*ErrNoPointerEvents); return ok }
 
+// ErrNotActionable error. Check the doc of Element.WaitActionable for details.
+type ErrNotActionable struct {
+	*Element
+
+	// Check is the name of the check that failed, such as "attached", "visible", "stable",
+	// "receives events" or "enabled".
+	Check string
+
+	Err error
+}
+
+// Error ...
+func (e *ErrNotActionable) Error() string {
+	return fmt.Sprintf("element is not actionable, %s check failed: %s: %v", e.Check, e.String(), e.Err)
+}
+
+// Unwrap ...
+func (e *ErrNotActionable) Unwrap() error {
+	return e.Err
+}
+
+// Is interface
+func (e *ErrNotActionable) Is(err error) bool { _, ok := err.(*ErrNotActionable); return ok }
+
+// ErrInvalidCookie error. The browser silently drops such a cookie, so it's rejected before setting it.
+type ErrInvalidCookie struct {
+	Cookie *proto.NetworkCookieParam
+	Reason string
+}
+
+func (e *ErrInvalidCookie) Error() string {
+	return fmt.Sprintf("invalid cookie %s: %s", e.Cookie.Name, e.Reason)
+}
+
+// Is interface
+func (e *ErrInvalidCookie) Is(err error) bool { _, ok := err.(*ErrInvalidCookie); return ok }
+
+// ErrCaptcha error. The page is blocked by a captcha, see [Page.CheckCaptcha].
+type ErrCaptcha struct {
+	*Captcha
+}
+
+func (e *ErrCaptcha) Error() string {
+	return fmt.Sprintf("page is blocked by %s captcha: %s", e.Type, e.URL)
+}
+
+// Is interface
+func (e *ErrCaptcha) Is(err error) bool { _, ok := err.(*ErrCaptcha); return ok }
+
 // ErrPageNotFound error
 type ErrPageNotFound struct{}
 
@@ -179,6 +251,26 @@ func (e *ErrPageNotFound) Error() string {
 	return "cannot find page"
 }
 
+// Is interface
+func (e *ErrPageNotFound) Is(err error) bool { _, ok := err.(*ErrPageNotFound); return ok }
+
+// ErrPageClosed error. The session of the page is not found, usually because the page is closed or detached.
+type ErrPageClosed struct {
+	Err error
+}
+
+func (e *ErrPageClosed) Error() string {
+	return "page closed: " + e.Err.Error()
+}
+
+// Is interface
+func (e *ErrPageClosed) Is(err error) bool { _, ok := err.(*ErrPageClosed); return ok }
+
+// Unwrap stdlib interface
+func (e *ErrPageClosed) Unwrap() error {
+	return e.Err
+}
+
 // ErrNoShadowRoot error
 type ErrNoShadowRoot struct {
 	*Element
@@ -628,3 +628,27 @@ func Example_log_cdp_traffic() {
 
 	rod.New().Client(cdp).MustConnect().MustPage("http://mdn.dev")
 }
+
+// Shows how to report the latency of each cdp call and high-level action to a tracing system,
+// such as OpenTelemetry. The log is used here to keep the example free of dependencies,
+// replace it with the span API of your tracing lib.
+func Example_tracing() {
+	client := cdp.New().
+		Use(func(next cdp.Handler) cdp.Handler {
+			return func(ctx context.Context, req *cdp.Request) ([]byte, error) {
+				// ctx, span := tracer.Start(ctx, req.Method)
+				// defer span.End()
+				start := time.Now()
+				res, err := next(ctx, req)
+				fmt.Println("cdp", req.Method, req.SessionID, time.Since(start), err)
+				return res, err
+			}
+		}).
+		Start(cdp.MustConnectWS(launcher.New().MustLaunch()))
+
+	rod.New().Client(client).AfterAction(func(a *rod.Action) {
+		// _, span := tracer.Start(ctx, a.Name, trace.WithTimestamp(a.Start))
+		// span.End(trace.WithTimestamp(a.Start.Add(a.Duration)))
+		fmt.Println("action", a.Name, a.Page, a.Duration, a.Err)
+	}).MustConnect().MustPage("http://mdn.dev")
+}
@@ -0,0 +1,107 @@
+package rod
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// Features of the browser that differ between its versions, such as Chrome stable, beta, and the older LTS images.
+// Rod uses them to choose the new protocol APIs when they are available and fallback to the old ones.
+type Features struct {
+	// Protocol version of the DevTools Protocol, such as "1.3"
+	Protocol string
+
+	// Major version of the browser, such as 120. It's 0 if the product name can't be parsed,
+	// such as a custom build, then the browser is treated as the latest one.
+	Major int
+
+	// BrowserDownloadEvents is true if the browser emits the download events via the Browser domain,
+	// such as [proto.BrowserDownloadWillBegin], since Chrome 91.
+	// The old ones emit them via the deprecated Page domain.
+	BrowserDownloadEvents bool
+
+	// NewHeadless is true if the browser supports the "--headless=new" flag, since Chrome 112.
+	NewHeadless bool
+}
+
+// Features detects the features of the browser from [Browser.Version].
+// It calls the browser each time, keep the result if you need it frequently.
+func (b *Browser) Features() (*Features, error) {
+	v, err := b.Version()
+	if err != nil {
+		return nil, err
+	}
+
+	major := 0
+	if m := regMajorVersion.FindStringSubmatch(v.Product); m != nil {
+		major, _ = strconv.Atoi(m[1])
+	}
+
+	since := func(version int) bool {
+		return major == 0 || major >= version
+	}
+
+	return &Features{
+		Protocol:              v.ProtocolVersion,
+		Major:                 major,
+		BrowserDownloadEvents: since(91),
+		NewHeadless:           since(112),
+	}, nil
+}
+
+// HeadlessMode of the browser
+type HeadlessMode string
+
+const (
+	// HeadlessModeNone means the browser has a visible window
+	HeadlessModeNone HeadlessMode = ""
+
+	// HeadlessModeOld is the legacy headless implementation, such as "--headless=old" or the chrome-headless-shell.
+	// It's removed from Chrome since 132.
+	HeadlessModeOld HeadlessMode = "old"
+
+	// HeadlessModeNew is the headless mode that shares the code of the headful browser, such as "--headless=new".
+	// It's the default of the "--headless" flag since Chrome 132.
+	HeadlessModeNew HeadlessMode = "new"
+)
+
+// HeadlessMode detects the active headless mode from the command line of the browser.
+// The new mode behaves like the headful browser, such as the window bounds and the favicon requests.
+// It only reports the mode, the behaviors that differ between the modes, such as the downloads, the PDF,
+// and the window bounds, are not adjusted, use the mode to decide them.
+func (b *Browser) HeadlessMode() (HeadlessMode, error) {
+	res, err := proto.BrowserGetBrowserCommandLine{}.Call(b)
+	if err != nil {
+		return HeadlessModeNone, err
+	}
+
+	value, found := "", false
+	for _, arg := range res.Arguments {
+		if strings.Contains(arg, "chrome-headless-shell") {
+			return HeadlessModeOld, nil
+		}
+		if arg == "--headless" || strings.HasPrefix(arg, "--headless=") {
+			value, found = strings.TrimPrefix(strings.TrimPrefix(arg, "--headless"), "="), true
+		}
+	}
+
+	switch {
+	case !found:
+		return HeadlessModeNone, nil
+	case value == "new" || value == "chrome":
+		return HeadlessModeNew, nil
+	case value == "old":
+		return HeadlessModeOld, nil
+	}
+
+	f, err := b.Features()
+	if err != nil {
+		return HeadlessModeNone, err
+	}
+	if f.Major == 0 || f.Major >= 132 {
+		return HeadlessModeNew, nil
+	}
+	return HeadlessModeOld, nil
+}
@@ -0,0 +1,189 @@
+package rod
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+// FrameStats is the statistics of the frames rendered during a period, see [Page.MeasureFrames]
+type FrameStats struct {
+	// Duration between the first and the last frame
+	Duration time.Duration
+
+	// Frames is the number of the rendered frames
+	Frames int
+
+	// FPS is the average frames per second
+	FPS float64
+
+	// Dropped is the number of the frames missed comparing to the refresh interval of the display,
+	// such as a frame that takes 50ms on a 60Hz display drops 2 frames.
+	Dropped int
+
+	// Longest is the longest interval between two frames
+	Longest time.Duration
+}
+
+// MeasureFrames calls fn and reports the frames rendered while it runs, such as to assert an animation is smooth.
+// The frames are counted by the requestAnimationFrame callbacks, the refresh interval of the display is
+// estimated by the median interval between two frames.
+func (p *Page) MeasureFrames(fn func() error) (stats *FrameStats, err error) {
+	rec, err := p.Evaluate(Eval(`() => {
+		const rec = { times: [], stopped: false }
+		const tick = (t) => {
+			if (rec.stopped) return
+			rec.times.push(t)
+			requestAnimationFrame(tick)
+		}
+		requestAnimationFrame(tick)
+		return rec
+	}`).ByObject())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// stop the loop on every path, or the loop keeps running and the times keep growing
+		if err != nil {
+			_, _ = p.Evaluate(Eval(`() => { this.stopped = true }`).This(rec))
+		}
+		_ = proto.RuntimeReleaseObject{ObjectID: rec.ObjectID}.Call(p)
+	}()
+
+	err = fn()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.Evaluate(Eval(`() => { this.stopped = true; return this.times }`).This(rec))
+	if err != nil {
+		return nil, err
+	}
+
+	times := []float64{}
+	for _, t := range res.Value.Arr() {
+		times = append(times, t.Num())
+	}
+
+	return newFrameStats(times), nil
+}
+
+// newFrameStats from the timestamps of the frames in milliseconds
+func newFrameStats(times []float64) *FrameStats {
+	stats := &FrameStats{Frames: len(times)}
+	if len(times) < 2 {
+		return stats
+	}
+
+	intervals := []float64{}
+	longest := 0.0
+	for i := 1; i < len(times); i++ {
+		d := times[i] - times[i-1]
+		intervals = append(intervals, d)
+		longest = math.Max(longest, d)
+	}
+
+	// most frames are on time, so the median is the refresh interval even if some frames are dropped
+	sort.Float64s(intervals)
+	interval := intervals[len(intervals)/2]
+
+	for _, d := range intervals {
+		if n := int(math.Round(d/interval)) - 1; interval > 0 && n > 0 {
+			stats.Dropped += n
+		}
+	}
+
+	total := times[len(times)-1] - times[0]
+	stats.Duration = time.Duration(total * float64(time.Millisecond))
+	stats.Longest = time.Duration(longest * float64(time.Millisecond))
+	if total > 0 {
+		stats.FPS = float64(len(times)-1) / total * 1000
+	}
+
+	return stats
+}
+
+// ShowFPSCounter shows or hides the FPS counter of the browser on the page, it's handy to debug the animations
+// with a visible browser.
+func (p *Page) ShowFPSCounter(show bool) error {
+	return proto.OverlaySetShowFPSCounter{Show: show}.Call(p)
+}
+
+// LongTask is a task that blocks the main thread of the page, see [Page.ObserveLongTasks].
+// Doc: https://developer.mozilla.org/en-US/docs/Web/API/PerformanceLongTaskTiming
+type LongTask struct {
+	// Name is the source of the task, such as "self", "same-origin-descendant", or "cross-origin-ancestor"
+	Name string `json:"name"`
+
+	// StartTime in milliseconds since the document is created
+	StartTime float64 `json:"startTime"`
+
+	// Duration in milliseconds
+	Duration float64 `json:"duration"`
+
+	// URL of the document that the task blocks
+	URL string `json:"url"`
+
+	// Container is the src of the iframe that causes the task, it's empty for the page itself
+	Container string `json:"container,omitempty"`
+}
+
+// ObserveLongTasks calls fn with the tasks that block the main thread for at least the threshold until
+// stop is called, the observation survives navigations. The browser only reports the tasks longer than 50ms,
+// such as to assert no main thread task is over 200ms during a checkout:
+//
+//	stop, _ := page.ObserveLongTasks(200*time.Millisecond, func(t *rod.LongTask) { panic(t.Duration) })
+func (p *Page) ObserveLongTasks(threshold time.Duration, fn func(*LongTask)) (stop func() error, err error) {
+	name := "_" + utils.RandString(8)
+	ms := float64(threshold) / float64(time.Millisecond)
+
+	stopExpose, err := p.Expose(name, func(j gson.JSON) (interface{}, error) {
+		list := []*LongTask{}
+		err := j.Unmarshal(&list)
+		if err == nil {
+			for _, t := range list {
+				fn(t)
+			}
+		}
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	remove, err := p.EvalOnNewDocument(fmt.Sprintf(`(%s)("%s", %v)`, js.ObserveLongTasks.Definition, name, ms))
+	if err != nil {
+		_ = stopExpose()
+		return nil, err
+	}
+
+	_, err = p.Evaluate(EvalHelper(js.ObserveLongTasks, name, ms))
+	if err != nil {
+		_ = remove()
+		_ = stopExpose()
+		return nil, err
+	}
+
+	return func() error {
+		_, err := p.eval(`name => {
+			const o = window[name + '_observer']
+			if (o) o.disconnect()
+			delete window[name + '_observer']
+		}`, name)
+		if err != nil {
+			return err
+		}
+
+		err = remove()
+		if err != nil {
+			return err
+		}
+		return stopExpose()
+	}, nil
+}
@@ -0,0 +1,83 @@
+package rod_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestMeasureFrames(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+
+	stats := p.MustMeasureFrames(func() {
+		utils.Sleep(0.5)
+	})
+	g.Gt(stats.Frames, 1)
+	g.Gt(stats.FPS, 0)
+	g.Gt(stats.Duration, 100*time.Millisecond)
+	g.Gte(stats.Longest, stats.Duration/time.Duration(stats.Frames))
+
+	// block the main thread to drop frames
+	stats = p.MustMeasureFrames(func() {
+		utils.Sleep(0.1)
+		p.MustEval(`() => { const t = Date.now(); while (Date.now() - t < 200); }`)
+		utils.Sleep(0.1)
+	})
+	g.Gt(stats.Dropped, 0)
+	g.Gte(stats.Longest, 200*time.Millisecond)
+
+	_, err := p.MeasureFrames(func() error { return errors.New("err") })
+	g.Eq(err.Error(), "err")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.MeasureFrames(func() error { return nil }))
+
+	g.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+	g.Err(p.MeasureFrames(func() error { return nil }))
+}
+
+func TestShowFPSCounter(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustShowFPSCounter(true).MustShowFPSCounter(false)
+}
+
+func TestObserveLongTasks(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	ch := make(chan *rod.LongTask, 10)
+	stop := p.MustObserveLongTasks(100*time.Millisecond, func(t *rod.LongTask) { ch <- t })
+
+	p.MustEval(`() => { const t = Date.now(); while (Date.now() - t < 60); }`)
+	p.MustEval(`() => { const t = Date.now(); while (Date.now() - t < 200); }`)
+
+	task := <-ch
+	g.Gte(task.Duration, 200.0)
+	g.Eq(task.Name, "self")
+	g.Eq(task.URL, g.blank())
+
+	// survives the navigation
+	p.MustNavigate(g.blank())
+	p.MustEval(`() => { const t = Date.now(); while (Date.now() - t < 200); }`)
+	g.Gte((<-ch).Duration, 200.0)
+
+	stop()
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ObserveLongTasks(0, nil))
+
+	g.mc.stubErr(2, proto.PageAddScriptToEvaluateOnNewDocument{})
+	g.Err(p.ObserveLongTasks(0, nil))
+
+	g.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ObserveLongTasks(0, nil))
+}
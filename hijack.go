@@ -3,11 +3,21 @@ package rod
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/goccy/go-json"
 
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -74,6 +84,7 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 					continue
 				}
 
+				ctx.Skip = false
 				h.handler(ctx)
 
 				if ctx.continueRequest != nil {
@@ -100,8 +111,14 @@ func (r *HijackRouter) initEvents() *HijackRouter {
 				err := ctx.Response.payload.Call(r.client)
 				if err != nil {
 					ctx.OnError(err)
-					return
 				}
+				return
+			}
+
+			// no handler takes the request
+			err := proto.FetchContinueRequest{RequestID: e.RequestID}.Call(r.client)
+			if err != nil {
+				ctx.OnError(err)
 			}
 		}()
 
@@ -427,3 +444,504 @@ func (b *Browser) HandleAuth(username, password string) func() error {
 		return
 	}
 }
+
+// HandleProxyAuth answers all the authentication challenges of the proxy with the credentials until stop is called.
+// Unlike [Browser.HandleAuth], it keeps handling the following challenges, the challenges from other servers
+// will be handled by the browser as usual.
+func (b *Browser) HandleProxyAuth(username, password string) (stop func()) {
+	restore := b.EnableDomain("", &proto.FetchEnable{
+		HandleAuthRequests: true,
+	})
+
+	ctx, cancel := context.WithCancel(b.ctx)
+
+	wait := b.Context(ctx).EachEvent(func(e *proto.FetchRequestPaused) {
+		_ = proto.FetchContinueRequest{RequestID: e.RequestID}.Call(b)
+	}, func(e *proto.FetchAuthRequired) {
+		res := &proto.FetchAuthChallengeResponse{
+			Response: proto.FetchAuthChallengeResponseResponseDefault,
+		}
+
+		if e.AuthChallenge.Source == proto.FetchAuthChallengeSourceProxy {
+			res = &proto.FetchAuthChallengeResponse{
+				Response: proto.FetchAuthChallengeResponseResponseProvideCredentials,
+				Username: username,
+				Password: password,
+			}
+		}
+
+		_ = proto.FetchContinueWithAuth{
+			RequestID:             e.RequestID,
+			AuthChallengeResponse: res,
+		}.Call(b)
+	})
+
+	go wait()
+
+	return func() {
+		cancel()
+		restore()
+	}
+}
+
+// HijackMatcher reports whether a hijacked request matches
+type HijackMatcher func(*HijackRequest) bool
+
+// Handle returns a hijack handler that only handles the requests that match m,
+// other requests will be skipped to the next handler of the router.
+func (m HijackMatcher) Handle(handler func(*Hijack)) func(*Hijack) {
+	return func(h *Hijack) {
+		if !m(h.Request) {
+			h.Skip = true
+			return
+		}
+		handler(h)
+	}
+}
+
+// MatchAll matches if all the matchers match
+func MatchAll(list ...HijackMatcher) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		for _, m := range list {
+			if !m(r) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// MatchAny matches if any of the matchers matches
+func MatchAny(list ...HijackMatcher) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		for _, m := range list {
+			if m(r) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MatchMethod matches the http method of the request, such as "POST"
+func MatchMethod(method string) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		return strings.EqualFold(r.Method(), method)
+	}
+}
+
+// MatchURL matches the url of the request, the doc of the pattern is the same as "proto.FetchRequestPattern.URLPattern".
+func MatchURL(pattern string) HijackMatcher {
+	return MatchURLRegexp(regexp.MustCompile(proto.PatternToReg(pattern)))
+}
+
+// MatchURLRegexp matches the url of the request with the reg
+func MatchURLRegexp(reg *regexp.Regexp) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		return reg.MatchString(r.URL().String())
+	}
+}
+
+// MatchHeader matches the value of the header of the request
+func MatchHeader(key, value string) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		return r.req.Header.Get(key) == value
+	}
+}
+
+// MatchQuery matches the value of the query param of the request url
+func MatchQuery(key, value string) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		q := r.URL().Query()
+		_, has := q[key]
+		return has && q.Get(key) == value
+	}
+}
+
+// MatchJSONBody matches the value at the path of the json body of the request.
+// The path is the same as the one of [gson.JSON.Get], such as "user.tags.0".
+func MatchJSONBody(path string, value interface{}) HijackMatcher {
+	expected := gson.New(value).JSON("", "")
+	return func(r *HijackRequest) bool {
+		v, has := r.JSONBody().Gets(gson.Path(path)...)
+		return has && v.JSON("", "") == expected
+	}
+}
+
+// MatchGraphQL matches the POST GraphQL requests with the operation name
+func MatchGraphQL(operationName string) HijackMatcher {
+	return func(r *HijackRequest) bool {
+		if r.Method() != http.MethodPost {
+			return false
+		}
+		q := r.GraphQL()
+		return q != nil && q.OperationName == operationName
+	}
+}
+
+// GraphQLRequest is the decoded body of a GraphQL request
+type GraphQLRequest struct {
+	Query         string
+	OperationName string
+	Variables     gson.JSON
+}
+
+var regGraphQLOperation = regexp.MustCompile(`\b(?:query|mutation|subscription)\s+(\w+)`)
+
+// GraphQL decodes the body as a GraphQL request, returns nil if the body isn't one.
+// If the operation name isn't set in the body, it will be parsed from the query.
+func (ctx *HijackRequest) GraphQL() *GraphQLRequest {
+	body := ctx.JSONBody()
+
+	query, has := body.Gets("query")
+	if !has {
+		return nil
+	}
+
+	r := &GraphQLRequest{Query: query.Str(), Variables: body.Get("variables")}
+
+	if name, has := body.Gets("operationName"); has && name.Val() != nil {
+		r.OperationName = name.Str()
+	} else if m := regGraphQLOperation.FindStringSubmatch(r.Query); m != nil {
+		r.OperationName = m[1]
+	}
+
+	return r
+}
+
+// GraphQLError is an item of the errors field of a GraphQL response
+type GraphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path,omitempty"`
+	Extensions map[string]interface{} `json:"extensions,omitempty"`
+}
+
+// SetGraphQL sets the body as a GraphQL response with the data and errors
+func (ctx *HijackResponse) SetGraphQL(data interface{}, errs ...GraphQLError) *HijackResponse {
+	ctx.SetHeader("Content-Type", "application/json; charset=utf-8")
+	return ctx.SetBody(struct {
+		Data   interface{}    `json:"data"`
+		Errors []GraphQLError `json:"errors,omitempty"`
+	}{data, errs})
+}
+
+// HijackDelay returns a handler that waits with the sleeper before it calls the handler.
+// It's useful to test the loading states of the page, such as to delay each request for a random duration:
+//
+//	rod.HijackDelay(utils.RandomSleeper(time.Second, 2*time.Second), handler)
+//
+// If the request is canceled while waiting, the request will fail and the OnError gets the context error.
+func HijackDelay(sleeper utils.Sleeper, handler func(*Hijack)) func(*Hijack) {
+	return func(h *Hijack) {
+		err := sleeper(h.Request.req.Context())
+		if err != nil {
+			h.OnError(err)
+			h.Response.Fail(proto.NetworkErrorReasonAborted)
+			return
+		}
+
+		handler(h)
+	}
+}
+
+// HijackFailEvery returns a handler that fails every nth request it receives with the reason,
+// the other requests will be passed to the handler. It panics if n isn't greater than 0.
+// It's useful to test the retry logic of the page.
+func HijackFailEvery(n int, reason proto.NetworkErrorReason, handler func(*Hijack)) func(*Hijack) {
+	if n <= 0 {
+		panic(fmt.Sprintf("the n of HijackFailEvery must be greater than 0, got %d", n))
+	}
+
+	var count int64
+	return func(h *Hijack) {
+		if atomic.AddInt64(&count, 1)%int64(n) == 0 {
+			h.Response.Fail(reason)
+			return
+		}
+		handler(h)
+	}
+}
+
+// Cassette records the responses of the hijacked requests and replays them later, so that tests can run
+// hermetically without the real servers. Such as record the responses of a run:
+//
+//	c := rod.NewCassette()
+//	router.MustAdd("*", c.Record(http.DefaultClient))
+//	...
+//	utils.E(c.Save("cassette.json"))
+//
+// Then replay them in other runs:
+//
+//	c, _ := rod.LoadCassette("cassette.json")
+//	router.MustAdd("*", c.Replay)
+type Cassette struct {
+	Records []*CassetteRecord `json:"records"`
+
+	lock    sync.Mutex
+	replays map[string]int // the count of replayed records of each request key
+}
+
+// CassetteRecord is a request and its response
+type CassetteRecord struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Body         string      `json:"body,omitempty"`
+	Status       int         `json:"status"`
+	Headers      http.Header `json:"headers"`
+	ResponseBody []byte      `json:"responseBody"`
+}
+
+func (r *CassetteRecord) key() string {
+	return r.Method + " " + r.URL + " " + r.Body
+}
+
+// NewCassette creates an empty cassette
+func NewCassette() *Cassette {
+	return &Cassette{replays: map[string]int{}}
+}
+
+// LoadCassette from the json file at path
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c := NewCassette()
+	return c, json.Unmarshal(b, c)
+}
+
+// Save the cassette as a json file to path
+func (c *Cassette) Save(path string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return utils.OutputFile(path, c)
+}
+
+// Record returns a hijack handler that loads the response from the real server via the client,
+// and records the request and the response into the cassette.
+func (c *Cassette) Record(client *http.Client) func(*Hijack) {
+	return func(h *Hijack) {
+		err := h.LoadResponse(client, true)
+		if err != nil {
+			h.OnError(err)
+			h.Response.Fail(proto.NetworkErrorReasonConnectionFailed)
+			return
+		}
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		c.Records = append(c.Records, &CassetteRecord{
+			Method:       h.Request.Method(),
+			URL:          h.Request.URL().String(),
+			Body:         h.Request.Body(),
+			Status:       h.Response.payload.ResponseCode,
+			Headers:      h.Response.Headers(),
+			ResponseBody: h.Response.payload.Body,
+		})
+	}
+}
+
+// Replay is a hijack handler that responds with the recorded response of the same method, url, and body.
+// If the same request is recorded multiple times, the responses will be replayed in the recorded order,
+// and the last one will be repeated. The requests that are not recorded will fail.
+func (c *Cassette) Replay(h *Hijack) {
+	key := (&CassetteRecord{
+		Method: h.Request.Method(),
+		URL:    h.Request.URL().String(),
+		Body:   h.Request.Body(),
+	}).key()
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	list := []*CassetteRecord{}
+	for _, r := range c.Records {
+		if r.key() == key {
+			list = append(list, r)
+		}
+	}
+
+	if len(list) == 0 {
+		h.Response.Fail(proto.NetworkErrorReasonInternetDisconnected)
+		return
+	}
+
+	i := c.replays[key]
+	if i >= len(list) {
+		i = len(list) - 1
+	}
+	c.replays[key]++
+
+	r := list[i]
+	h.Response.payload.ResponseCode = r.Status
+	for k, vs := range r.Headers {
+		for _, v := range vs {
+			h.Response.SetHeader(k, v)
+		}
+	}
+	h.Response.SetBody(r.ResponseBody)
+}
+
+// ResponseCache stores the responses of the hijacked GET requests on disk and serves them to the later requests
+// without the real servers, such as to speed up the repeated crawls of mostly-static sites:
+//
+//	cache := rod.NewResponseCache("tmp/cache", http.DefaultClient)
+//	router.MustAdd("*", cache.Handle)
+//
+// A response is keyed by the url of the request and the values of the request headers listed in
+// the Vary header of the response, so the variants of the same url won't be mixed.
+type ResponseCache struct {
+	// Dir to store the responses
+	Dir string
+
+	// Client to load the responses that are not cached
+	Client *http.Client
+
+	// TTL of the responses, 0 means the responses never expire
+	TTL time.Duration
+
+	lock sync.Mutex
+}
+
+// ResponseCacheEntry is a variant of the cached response of a url
+type ResponseCacheEntry struct {
+	Vary    map[string]string `json:"vary,omitempty"`
+	Status  int               `json:"status"`
+	Headers http.Header       `json:"headers"`
+	Body    []byte            `json:"body"`
+	Time    time.Time         `json:"time"`
+}
+
+// NewResponseCache creates a cache that stores the responses into dir
+func NewResponseCache(dir string, client *http.Client) *ResponseCache {
+	return &ResponseCache{Dir: dir, Client: client}
+}
+
+// Handle is a hijack handler that responds with the cached response, or loads the response via the Client
+// and caches it. Only the successful responses of the GET requests without "Cache-Control: no-store" are cached,
+// the responses with "Vary: *" are never cached because they can't be matched by the request headers.
+func (c *ResponseCache) Handle(h *Hijack) {
+	if h.Request.Method() != http.MethodGet {
+		h.ContinueRequest(&proto.FetchContinueRequest{})
+		return
+	}
+
+	if e := c.Get(h.Request); e != nil {
+		h.Response.payload.ResponseCode = e.Status
+		for k, vs := range e.Headers {
+			for _, v := range vs {
+				h.Response.SetHeader(k, v)
+			}
+		}
+		h.Response.SetBody(e.Body)
+		return
+	}
+
+	err := h.LoadResponse(c.Client, true)
+	if err != nil {
+		h.OnError(err)
+		h.Response.Fail(proto.NetworkErrorReasonConnectionFailed)
+		return
+	}
+
+	headers := h.Response.Headers()
+	status := h.Response.payload.ResponseCode
+	if status < 200 || status >= 300 || strings.Contains(headers.Get("Cache-Control"), "no-store") {
+		return
+	}
+
+	vary := map[string]string{}
+	for _, v := range headers.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name == "*" {
+				return
+			}
+			if name != "" {
+				vary[name] = requestHeader(h.Request, name)
+			}
+		}
+	}
+
+	err = c.Set(h.Request.URL().String(), &ResponseCacheEntry{
+		Vary:    vary,
+		Status:  status,
+		Headers: headers,
+		Body:    h.Response.payload.Body,
+		Time:    time.Now(),
+	})
+	if err != nil {
+		h.OnError(err)
+	}
+}
+
+// Get the cached response of the request, returns nil if there's no fresh one
+func (c *ResponseCache) Get(req *HijackRequest) *ResponseCacheEntry {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, e := range c.load(req.URL().String()) {
+		if c.TTL > 0 && time.Since(e.Time) > c.TTL {
+			continue
+		}
+
+		match := true
+		for name, v := range e.Vary {
+			if requestHeader(req, name) != v {
+				match = false
+				break
+			}
+		}
+		if match {
+			return e
+		}
+	}
+	return nil
+}
+
+// Set the response of the url, it replaces the cached variant that has the same vary values
+func (c *ResponseCache) Set(u string, entry *ResponseCacheEntry) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	list := []*ResponseCacheEntry{entry}
+	for _, e := range c.load(u) {
+		if !reflect.DeepEqual(e.Vary, entry.Vary) {
+			list = append(list, e)
+		}
+	}
+
+	return utils.OutputFile(c.path(u), list)
+}
+
+// Clear all the cached responses
+func (c *ResponseCache) Clear() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return os.RemoveAll(c.Dir)
+}
+
+func (c *ResponseCache) load(u string) []*ResponseCacheEntry {
+	list := []*ResponseCacheEntry{}
+	b, err := ioutil.ReadFile(c.path(u))
+	if err == nil {
+		_ = json.Unmarshal(b, &list)
+	}
+	return list
+}
+
+func (c *ResponseCache) path(u string) string {
+	return filepath.Join(c.Dir, fmt.Sprintf("%x.json", sha1.Sum([]byte(u))))
+}
+
+// the header names of the browser may be lowercase
+func requestHeader(req *HijackRequest, name string) string {
+	for k, v := range req.Headers() {
+		if strings.EqualFold(k, name) {
+			return v.String()
+		}
+	}
+	return ""
+}
@@ -2,15 +2,20 @@ package rod_test
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"path/filepath"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -212,6 +217,192 @@ func TestHijackSkip(t *testing.T) {
 	wg.Wait()
 }
 
+func TestHandleProxyAuth(t *testing.T) {
+	g := setup(t)
+
+	// mock a proxy that requires authentication
+	proxy := g.Serve()
+	proxy.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Proxy-Authorization") != "Basic "+base64.StdEncoding.EncodeToString([]byte("a:b")) {
+			w.Header().Add("Proxy-Authenticate", `Basic realm="proxy"`)
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		g.HandleHTTP(".html", `<p>`+r.Host+`</p>`)(w, r)
+	})
+
+	u := launcher.New().Proxy(proxy.HostURL.Host).Set("proxy-bypass-list", "<-loopback>").MustLaunch()
+	browser := rod.New().Context(g.Context()).ControlURL(u).MustConnect()
+	defer browser.MustClose()
+
+	stop := browser.HandleProxyAuth("a", "b")
+	defer stop()
+
+	page := browser.MustPage("http://a.test/")
+	page.MustElementR("p", "a.test")
+
+	page.MustNavigate("http://b.test/")
+	page.MustElementR("p", "b.test")
+}
+
+func TestHijackMatcher(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".txt", "ok")
+	g.page.MustNavigate(s.URL())
+
+	router := g.page.HijackRequests()
+	defer router.MustStop()
+
+	router.MustAdd("*", rod.MatchAll(
+		rod.MatchMethod("post"),
+		rod.MatchURL("*/api*"),
+		rod.MatchHeader("X-Test", "1"),
+		rod.MatchQuery("a", "1"),
+		rod.MatchAny(rod.MatchJSONBody("user.id", 1), rod.MatchJSONBody("user.id", 2)),
+	).Handle(func(ctx *rod.Hijack) {
+		ctx.Response.SetBody("matched")
+	}))
+
+	go router.Run()
+
+	fetch := func(method, u, body string) string {
+		return g.page.MustEval(`(m, u, b) => fetch(u, {
+			method: m, headers: {'X-Test': '1'}, body: b || undefined,
+		}).then(r => r.text())`, method, u, body).Str()
+	}
+
+	g.Eq(fetch("POST", s.URL("/api?a=1"), `{"user":{"id":2}}`), "matched")
+	g.Eq(fetch("POST", s.URL("/api?a=1"), `{"user":{"id":3}}`), "ok")
+	g.Eq(fetch("POST", s.URL("/api?a=2"), `{"user":{"id":1}}`), "ok")
+	g.Eq(fetch("GET", s.URL("/api?a=1"), ""), "ok")
+}
+
+func TestHijackMatcherSkipThenRespond(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".txt", "ok")
+	g.page.MustNavigate(s.URL())
+
+	router := g.page.HijackRequests()
+	defer router.MustStop()
+
+	router.MustAdd("*", rod.MatchURL("*/a").Handle(func(ctx *rod.Hijack) {
+		ctx.Response.SetBody("a")
+	}))
+	router.MustAdd("*", rod.MatchURL("*/b").Handle(func(ctx *rod.Hijack) {
+		ctx.Response.SetBody("b")
+	}))
+
+	go router.Run()
+
+	fetch := func(u string) string {
+		return g.page.MustEval(`u => fetch(u).then(r => r.text())`, u).Str()
+	}
+
+	g.Eq(fetch(s.URL("/a")), "a")
+	g.Eq(fetch(s.URL("/b")), "b")
+	g.Eq(fetch(s.URL("/c")), "ok")
+}
+
+func TestHijackGraphQL(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".txt", `{"data":"real"}`)
+	g.page.MustNavigate(s.URL())
+
+	router := g.page.HijackRequests()
+	defer router.MustStop()
+
+	router.MustAdd("*/graphql", rod.MatchGraphQL("GetUser").Handle(func(ctx *rod.Hijack) {
+		q := ctx.Request.GraphQL()
+		ctx.Response.SetGraphQL(map[string]interface{}{
+			"user": map[string]interface{}{"id": q.Variables.Get("id").Int()},
+		})
+	}))
+	router.MustAdd("*/graphql", rod.MatchGraphQL("DelUser").Handle(func(ctx *rod.Hijack) {
+		ctx.Response.SetGraphQL(nil, rod.GraphQLError{Message: "denied", Path: []interface{}{"delUser"}})
+	}))
+
+	go router.Run()
+
+	post := func(body string) string {
+		return g.page.MustEval(`(u, b) => fetch(u, {method: 'POST', body: b}).then(r => r.text())`,
+			s.URL("/graphql"), body).Str()
+	}
+
+	g.Eq(post(`{"query":"query GetUser($id: Int) { user(id: $id) { id } }","variables":{"id":3}}`),
+		`{"data":{"user":{"id":3}}}`)
+	g.Eq(post(`{"query":"mutation { delUser }","operationName":"DelUser"}`),
+		`{"data":null,"errors":[{"message":"denied","path":["delUser"]}]}`)
+	g.Eq(post(`{"query":"query Other { a }"}`), `{"data":"real"}`)
+}
+
+func TestHijackDelayAndFail(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".txt", "ok")
+	g.page.MustNavigate(s.URL())
+
+	router := g.page.HijackRequests()
+	defer router.MustStop()
+
+	continueRequest := func(ctx *rod.Hijack) {
+		ctx.ContinueRequest(&proto.FetchContinueRequest{})
+	}
+
+	router.MustAdd("*/slow", rod.HijackDelay(utils.RandomSleeper(300*time.Millisecond, 400*time.Millisecond), continueRequest))
+	router.MustAdd("*/flaky", rod.HijackFailEvery(2, proto.NetworkErrorReasonFailed, continueRequest))
+
+	go router.Run()
+
+	get := func(path string) string {
+		return g.page.MustEval(`u => fetch(u).then(r => r.text(), () => 'failed')`, s.URL(path)).Str()
+	}
+
+	start := time.Now()
+	g.Eq(get("/slow"), "ok")
+	g.Gte(time.Since(start), 300*time.Millisecond)
+
+	g.Eq(get("/flaky"), "ok")
+	g.Eq(get("/flaky"), "failed")
+	g.Eq(get("/flaky"), "ok")
+	g.Eq(get("/flaky"), "failed")
+
+	g.Eq(g.Panic(func() {
+		rod.HijackFailEvery(0, proto.NetworkErrorReasonFailed, continueRequest)
+	}), "the n of HijackFailEvery must be greater than 0, got 0")
+}
+
+func TestHijackDelayCanceled(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".txt", "ok")
+	p := g.newPage(s.URL())
+
+	router := p.HijackRequests()
+
+	started := make(chan struct{})
+	errs := make(chan error, 2)
+	delay := rod.HijackDelay(utils.RandomSleeper(time.Minute, time.Minute), func(h *rod.Hijack) {
+		h.ContinueRequest(&proto.FetchContinueRequest{})
+	})
+	router.MustAdd("*/hang", func(h *rod.Hijack) {
+		h.OnError = func(err error) { errs <- err }
+		close(started)
+		delay(h)
+	})
+
+	go router.Run()
+
+	p.MustEval(`u => { fetch(u).catch(() => {}) }`, s.URL("/hang"))
+	<-started
+
+	// the request fails with the context error instead of waiting for the delay
+	router.MustStop()
+	g.Is(<-errs, context.Canceled)
+}
+
 func TestHijackOnErrorLog(t *testing.T) {
 	g := setup(t)
 
@@ -345,6 +536,120 @@ func TestHijackResponseErr(t *testing.T) {
 	wg.Wait()
 }
 
+func TestHijackCassette(t *testing.T) {
+	g := setup(t)
+
+	count := 0
+	s := g.Serve()
+	s.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(fmt.Sprintf("<body>%d</body>", count)))
+	})
+
+	path := filepath.Join("tmp", "cassettes", g.RandStr(16)+".json")
+
+	{ // record
+		c := rod.NewCassette()
+		router := g.page.HijackRequests()
+		router.MustAdd(s.URL("/a"), c.Record(http.DefaultClient))
+		go router.Run()
+
+		g.page.MustNavigate(s.URL("/a"))
+		g.Eq(g.page.MustElement("body").MustText(), "1")
+		g.page.MustNavigate(s.URL("/a"))
+		g.Eq(g.page.MustElement("body").MustText(), "2")
+
+		router.MustStop()
+		g.E(c.Save(path))
+	}
+
+	{ // replay
+		c, err := rod.LoadCassette(path)
+		g.E(err)
+		g.Len(c.Records, 2)
+
+		router := g.page.HijackRequests()
+		defer router.MustStop()
+		router.MustAdd(s.URL("/*"), c.Replay)
+		go router.Run()
+
+		g.page.MustNavigate(s.URL("/a"))
+		g.Eq(g.page.MustElement("body").MustText(), "1")
+		g.page.MustNavigate(s.URL("/a"))
+		g.Eq(g.page.MustElement("body").MustText(), "2")
+		g.page.MustNavigate(s.URL("/a"))
+		g.Eq(g.page.MustElement("body").MustText(), "2")
+
+		g.Err(g.page.Navigate(s.URL("/not-recorded")))
+	}
+
+	g.Eq(count, 2)
+
+	_, err := rod.LoadCassette("not-exists")
+	g.Err(err)
+}
+
+func TestHijackResponseCache(t *testing.T) {
+	g := setup(t)
+
+	var count int32
+	s := g.Serve()
+	s.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&count, 1)
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Vary", "X-Lang")
+		switch r.URL.Path {
+		case "/no-store":
+			w.Header().Set("Cache-Control", "no-store")
+		case "/vary-all":
+			w.Header().Set("Vary", "*")
+		}
+		_, _ = w.Write([]byte(fmt.Sprintf("<body>%d:%s</body>", n, r.Header.Get("X-Lang"))))
+	})
+
+	dir := filepath.Join("tmp", "response-cache", g.RandStr(16))
+	p := g.newPage()
+
+	run := func(cache *rod.ResponseCache, path string) string {
+		router := p.HijackRequests()
+		defer router.MustStop()
+		router.MustAdd(s.URL("/*"), cache.Handle)
+		go router.Run()
+
+		p.MustNavigate(s.URL(path))
+		return p.MustElement("body").MustText()
+	}
+
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/a"), "1:")
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/a"), "1:")
+	g.Eq(atomic.LoadInt32(&count), int32(1))
+
+	// the variants of the vary headers are cached separately
+	p.MustSetExtraHeaders("X-Lang", "en")
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/a"), "2:en")
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/a"), "2:en")
+	g.Eq(atomic.LoadInt32(&count), int32(2))
+
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/no-store"), "3:en")
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/no-store"), "4:en")
+
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/vary-all"), "5:en")
+	g.Eq(run(rod.NewResponseCache(dir, http.DefaultClient), "/vary-all"), "6:en")
+
+	expired := rod.NewResponseCache(dir, http.DefaultClient)
+	expired.TTL = time.Nanosecond
+	g.Eq(run(expired, "/a"), "7:en")
+
+	// the zero value is ready to use
+	literal := &rod.ResponseCache{Dir: dir, Client: http.DefaultClient}
+	g.Eq(run(literal, "/a"), "7:en")
+
+	cache := rod.NewResponseCache(dir, http.DefaultClient)
+	g.E(cache.Clear())
+	g.Eq(run(cache, "/a"), "8:en")
+}
+
 func TestHandleAuth(t *testing.T) {
 	g := setup(t)
 
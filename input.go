@@ -85,6 +85,57 @@ func (k *Keyboard) Type(keys ...input.Key) (err error) {
 	return
 }
 
+// TypeDelay is similar to [Keyboard.Type], but it calls the sleeper between keystrokes.
+// Useful when the page behaves differently if the text doesn't arrive instantaneously,
+// such as search-as-you-type inputs. Use [utils.RandomSleeper] to simulate the rhythm of a human.
+func (k *Keyboard) TypeDelay(sleeper utils.Sleeper, keys ...input.Key) error {
+	for i, key := range keys {
+		if i > 0 {
+			err := sleeper(k.page.ctx)
+			if err != nil {
+				return err
+			}
+		}
+
+		err := k.Type(key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WithModifiers holds the keys down while fn runs, then releases them in reverse order.
+// The keys are usually modifiers, such as [input.ControlLeft] or [input.ShiftLeft], every key
+// and mouse event dispatched during fn will have the modifiers set.
+// Useful to simulate interactions like ctrl+click or shift+click, for example:
+//
+//	page.Keyboard.WithModifiers(func() error {
+//		return el.Click(proto.InputMouseButtonLeft, 1)
+//	}, input.ControlLeft)
+func (k *Keyboard) WithModifiers(fn func() error, keys ...input.Key) (err error) {
+	pressed := []input.Key{}
+
+	defer func() {
+		for i := len(pressed) - 1; i >= 0; i-- {
+			e := k.Release(pressed[i])
+			if err == nil {
+				err = e
+			}
+		}
+	}()
+
+	for _, key := range keys {
+		err = k.Press(key)
+		if err != nil {
+			return
+		}
+		pressed = append(pressed, key)
+	}
+
+	return fn()
+}
+
 // KeyActionType enum
 type KeyActionType int
 
@@ -285,6 +336,20 @@ func (m *Mouse) MoveLinear(to proto.Point, steps int) error {
 	})
 }
 
+// MoveHuman to the absolute position with the given steps along a random bezier curve with slight jitter.
+// Unlike [Mouse.MoveLinear] it won't move in a straight line with constant speed, so it looks more like a human.
+// Useful for pages that track the mouse movement before enabling some actions.
+func (m *Mouse) MoveHuman(to proto.Point, steps int) error {
+	path := input.HumanPath(m.Position(), to, steps, 1)
+	i := 0
+
+	return m.MoveAlong(func() (proto.Point, bool) {
+		p := path[i]
+		i++
+		return p, i == len(path)
+	})
+}
+
 // Scroll the relative offset with specified steps
 func (m *Mouse) Scroll(offsetX, offsetY float64, steps int) error {
 	m.Lock()
@@ -389,6 +454,150 @@ func (m *Mouse) Click(button proto.InputMouseButton, clickCount int) error {
 	return m.Up(button, clickCount)
 }
 
+// PenPoint is a position of the [Pen] with the stylus state on it
+type PenPoint struct {
+	X float64
+	Y float64
+
+	// Pressure is the normalized pressure in the range [0, 1]
+	Pressure float64
+
+	// TiltX is the angle in degrees between the Y-Z plane and the plane containing the stylus axis and the Y axis,
+	// in the range [-90, 90]. A positive TiltX is to the right.
+	TiltX int
+
+	// TiltY is the angle in degrees between the X-Z plane and the plane containing the stylus axis and the X axis,
+	// in the range [-90, 90]. A positive TiltY is towards the user.
+	TiltY int
+
+	// Twist is the clockwise rotation of the stylus around its own axis in degrees, in the range [0, 359]
+	Twist int
+}
+
+// Pen presents a stylus, such as the one of a drawing tablet. It dispatches the mouse events with the
+// pointerType "pen", so the page will receive pointer events with the pressure and tilt.
+// Useful to test drawing canvases and signature pads.
+type Pen struct {
+	sync.Mutex
+
+	page *Page
+
+	pos PenPoint
+
+	down bool
+}
+
+func (p *Page) newPen() *Page {
+	p.Pen = &Pen{page: p}
+	return p
+}
+
+// Position of the pen
+func (pen *Pen) Position() PenPoint {
+	pen.Lock()
+	defer pen.Unlock()
+	return pen.pos
+}
+
+// MoveTo the point. The pen hovers if it's not down, otherwise it draws.
+func (pen *Pen) MoveTo(p PenPoint) error {
+	pen.Lock()
+	defer pen.Unlock()
+
+	pen.page.browser.trySlowMotion()
+
+	err := pen.dispatch(proto.InputDispatchMouseEventTypeMouseMoved, p)
+	if err != nil {
+		return err
+	}
+
+	pen.pos = p
+	return nil
+}
+
+// Down puts the pen down on the point
+func (pen *Pen) Down(p PenPoint) error {
+	pen.Lock()
+	defer pen.Unlock()
+
+	err := pen.dispatch(proto.InputDispatchMouseEventTypeMousePressed, p)
+	if err != nil {
+		return err
+	}
+
+	pen.pos = p
+	pen.down = true
+	return nil
+}
+
+// Up lifts the pen from the current position
+func (pen *Pen) Up() error {
+	pen.Lock()
+	defer pen.Unlock()
+
+	p := pen.pos
+	p.Pressure = 0
+
+	err := pen.dispatch(proto.InputDispatchMouseEventTypeMouseReleased, p)
+	if err != nil {
+		return err
+	}
+
+	pen.pos = p
+	pen.down = false
+	return nil
+}
+
+// Stroke puts the pen down on the first point, draws along the rest points, then lifts the pen.
+func (pen *Pen) Stroke(points ...PenPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	defer pen.page.tryTrace(TraceTypeInput, "pen stroke")()
+	pen.page.browser.trySlowMotion()
+
+	err := pen.Down(points[0])
+	if err != nil {
+		return err
+	}
+
+	for _, p := range points[1:] {
+		err = pen.MoveTo(p)
+		if err != nil {
+			return err
+		}
+	}
+
+	return pen.Up()
+}
+
+func (pen *Pen) dispatch(typ proto.InputDispatchMouseEventType, p PenPoint) error {
+	e := proto.InputDispatchMouseEvent{
+		Type:        typ,
+		X:           p.X,
+		Y:           p.Y,
+		Buttons:     gson.Int(0),
+		Modifiers:   pen.page.Keyboard.getModifiers(),
+		Force:       p.Pressure,
+		TiltX:       p.TiltX,
+		TiltY:       p.TiltY,
+		Twist:       p.Twist,
+		PointerType: proto.InputDispatchMouseEventPointerTypePen,
+	}
+
+	if typ != proto.InputDispatchMouseEventTypeMouseMoved {
+		e.Button = proto.InputMouseButtonLeft
+		e.ClickCount = 1
+	}
+	if typ == proto.InputDispatchMouseEventTypeMousePressed ||
+		typ == proto.InputDispatchMouseEventTypeMouseMoved && pen.down {
+		e.Buttons = gson.Int(1)
+	}
+
+	return e.Call(pen.page)
+}
+
 // Touch presents a touch device, such as a hand with fingers, each finger is a [proto.InputTouchPoint].
 // Touch events is stateless, we use the struct here only as a namespace to make the API style unified.
 type Touch struct {
@@ -455,3 +664,33 @@ func (t *Touch) Tap(x, y float64) error {
 
 	return t.End()
 }
+
+// Scroll synthesizes a swipe gesture from the point (x, y) that moves the finger by the distance.
+// Positive distance scrolls the page left and up, just like a real finger.
+// Unlike [Mouse.Scroll] the gesture has momentum scrolling.
+func (t *Touch) Scroll(x, y, distanceX, distanceY float64) error {
+	defer t.page.tryTrace(TraceTypeInput, "touch scroll")()
+	t.page.browser.trySlowMotion()
+
+	return proto.InputSynthesizeScrollGesture{
+		X:                 x,
+		Y:                 y,
+		XDistance:         &distanceX,
+		YDistance:         &distanceY,
+		GestureSourceType: proto.InputGestureSourceTypeTouch,
+	}.Call(t.page)
+}
+
+// Pinch synthesizes a pinch gesture centered at the point (x, y).
+// The scale larger than 1 zooms in, smaller than 1 zooms out.
+func (t *Touch) Pinch(x, y, scale float64) error {
+	defer t.page.tryTrace(TraceTypeInput, "touch pinch")()
+	t.page.browser.trySlowMotion()
+
+	return proto.InputSynthesizePinchGesture{
+		X:                 x,
+		Y:                 y,
+		ScaleFactor:       scale,
+		GestureSourceType: proto.InputGestureSourceTypeTouch,
+	}.Call(t.page)
+}
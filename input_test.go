@@ -1,8 +1,11 @@
 package rod_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/devices"
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
@@ -39,6 +42,29 @@ func TestKeyActions(t *testing.T) {
 	g.Nil(p.Keyboard.Release('a'))
 }
 
+func TestKeyboardWithModifiers(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/keys.html"))
+	body := p.MustElement("body")
+
+	p.Keyboard.MustWithModifiers(func() {
+		p.Mouse.MustClick(proto.InputMouseButtonLeft)
+	}, input.ControlLeft, input.ShiftLeft)
+	g.Eq(body.MustText(), `↓ "Control" ControlLeft 17 modifiers(ctrl)
+↓ "Shift" ShiftLeft 16 modifiers(ctrl,shift)
+mousedown modifiers(ctrl,shift)
+↑ "Shift" ShiftLeft 16 modifiers(ctrl)
+↑ "Control" ControlLeft 17 modifiers()
+`)
+
+	g.mc.stubErr(2, proto.InputDispatchKeyEvent{})
+	g.Err(p.Keyboard.WithModifiers(func() error { return nil }, input.ControlLeft, input.ShiftLeft))
+
+	g.mc.stubErr(2, proto.InputDispatchKeyEvent{})
+	g.Err(p.Keyboard.WithModifiers(func() error { return nil }, input.ControlLeft))
+}
+
 func TestKeyType(t *testing.T) {
 	g := setup(t)
 
@@ -53,6 +79,25 @@ func TestKeyType(t *testing.T) {
 	g.Eq("1 A b test", el.MustText())
 }
 
+func TestKeyTypeDelay(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/input.html"))
+	el := p.MustElement("[type=text]")
+
+	start := time.Now()
+	el.MustTypeDelay(utils.RandomSleeper(10*time.Millisecond, 20*time.Millisecond), 'a', 'b', 'c')
+	g.Gte(time.Since(start), 20*time.Millisecond)
+	p.Keyboard.MustTypeDelay(utils.RandomSleeper(0, 0), 'd')
+
+	g.Eq("abcd", el.MustText())
+
+	g.mc.stubErr(1, proto.InputDispatchKeyEvent{})
+	g.Err(p.Keyboard.TypeDelay(utils.RandomSleeper(0, 0), 'a'))
+
+	g.Is(p.Keyboard.TypeDelay(utils.CountSleeper(0), 'a', 'b'), &utils.ErrMaxSleepCount{})
+}
+
 func TestKeyTypeErr(t *testing.T) {
 	g := setup(t)
 
@@ -95,15 +140,18 @@ func TestInput(t *testing.T) {
 		el.MustText()
 	})
 	g.Panic(func() {
-		g.mc.stubErr(4, proto.RuntimeCallFunctionOn{})
+		// the focus
+		g.mc.stubErr(7, proto.RuntimeCallFunctionOn{})
 		el.MustInput("")
 	})
 	g.Panic(func() {
-		g.mc.stubErr(5, proto.RuntimeCallFunctionOn{})
+		// the enabled check
+		g.mc.stubErr(3, proto.RuntimeCallFunctionOn{})
 		el.MustInput("")
 	})
 	g.Panic(func() {
-		g.mc.stubErr(6, proto.RuntimeCallFunctionOn{})
+		// the writable check
+		g.mc.stubErr(8, proto.RuntimeCallFunctionOn{})
 		el.MustInput("")
 	})
 	g.Panic(func() {
@@ -181,6 +229,19 @@ func TestMouseDoubleClick(t *testing.T) {
 	g.Eq(el.MustText(), "ok")
 }
 
+func TestMouseRightClick(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.srcFile("fixtures/right-click.html"))
+	el := page.MustElement("button")
+	el.MustRightClick()
+	g.Eq(el.MustText(), "ok")
+
+	el.MustEval(`() => this.textContent = ""`)
+	page.Mouse.MustClick(proto.InputMouseButtonRight)
+	g.Eq(el.MustText(), "ok")
+}
+
 func TestMouseDrag(t *testing.T) {
 	g := setup(t)
 
@@ -224,6 +285,23 @@ func TestMouseMoveLinear(t *testing.T) {
 	g.Eq(page.MustEval(`() => moveTrack`).Str(), " move 1 2 move 1 2 move 2 3 move 3 4")
 }
 
+func TestMouseMoveHuman(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage().MustNavigate(g.srcFile("fixtures/mouse-move.html")).MustWaitLoad()
+	mouse := page.Mouse
+
+	mouse.MustMoveTo(1, 2)
+	g.E(mouse.MoveHuman(proto.NewPoint(50, 60), 5))
+	g.Eq(mouse.Position(), proto.NewPoint(50, 60))
+
+	utils.Sleep(0.3)
+	g.Len(strings.Split(strings.TrimSpace(page.MustEval(`() => moveTrack`).Str()), "move "), 7)
+
+	g.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+	g.Err(mouse.MoveHuman(proto.NewPoint(10, 10), 3))
+}
+
 func TestMouseMoveErr(t *testing.T) {
 	g := setup(t)
 
@@ -281,3 +359,58 @@ func TestTouch(t *testing.T) {
 		touch.MustTap(1, 2)
 	})
 }
+
+func TestTouchGestures(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage().MustEmulate(devices.IPad).MustNavigate(g.srcFile("fixtures/scroll.html")).MustWaitLoad()
+
+	page.Touch.MustScroll(100, 300, 0, -200)
+	page.MustWait(`() => window.scrollY > 0`)
+
+	page.Touch.MustPinch(100, 100, 2)
+	page.MustWait(`() => window.visualViewport.scale > 1`)
+
+	g.mc.stubErr(1, proto.InputSynthesizeScrollGesture{})
+	g.Err(page.Touch.Scroll(0, 0, 0, 0))
+
+	g.mc.stubErr(1, proto.InputSynthesizePinchGesture{})
+	g.Err(page.Touch.Pinch(0, 0, 1))
+}
+
+func TestPen(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html><body style="margin: 0; height: 100vh">
+<script>
+	window.events = []
+	for (const t of ['pointerdown', 'pointermove', 'pointerup']) {
+		document.addEventListener(t, e => events.push([t, e.pointerType, e.pressure, e.tiltX, e.tiltY]))
+	}
+</script></body></html>`))
+
+	p.Pen.MustMoveTo(rod.PenPoint{X: 5, Y: 5})
+	p.Pen.MustStroke(
+		rod.PenPoint{X: 10, Y: 10, Pressure: 0.5, TiltX: 30},
+		rod.PenPoint{X: 20, Y: 20, Pressure: 0.75, TiltY: -15},
+	)
+	g.Eq(p.Pen.Position().X, 20.0)
+
+	events := p.MustEval(`() => events`).Arr()
+	g.Len(events, 4)
+	g.Eq(events[0].Join(","), "pointermove,pen,0,0,0")
+	g.Eq(events[1].Join(","), "pointerdown,pen,0.5,30,0")
+	g.Eq(events[2].Join(","), "pointermove,pen,0.75,0,-15")
+	g.Eq(events[3].Join(","), "pointerup,pen,0,0,-15")
+
+	g.Nil(p.Pen.Stroke())
+
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(p.Pen.MoveTo(rod.PenPoint{}))
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(p.Pen.Stroke(rod.PenPoint{}))
+	g.mc.stubErr(2, proto.InputDispatchMouseEvent{})
+	g.Err(p.Pen.Stroke(rod.PenPoint{}, rod.PenPoint{}))
+	g.mc.stubErr(1, proto.InputDispatchMouseEvent{})
+	g.Err(p.Pen.Up())
+}
@@ -0,0 +1,218 @@
+// Package bidi is an experimental client of the WebDriver BiDi protocol, it only handles the transport,
+// such as the commands, results, and events of the protocol. It's internal until rod can drive a browser with it.
+//
+// Doc of the protocol: https://w3c.github.io/webdriver-bidi
+package bidi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+
+	"github.com/goccy/go-json"
+
+	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/defaults"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// Command to send to the browser
+type Command struct {
+	ID     int         `json:"id"`
+	Method string      `json:"method"`
+	Params interface{} `json:"params"`
+}
+
+// Event from the browser
+type Event struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// Error of a command
+type Error struct {
+	Code       string `json:"error"`
+	Message    string `json:"message"`
+	Stacktrace string `json:"stacktrace,omitempty"`
+}
+
+// Error stdlib interface
+func (e *Error) Error() string {
+	return e.Code + ": " + e.Message
+}
+
+// Is stdlib interface
+func (e *Error) Is(target error) bool {
+	err, ok := target.(*Error)
+	return ok && e.Code == err.Code
+}
+
+// message is the union of the messages from the browser, the Type is "success", "error", or "event"
+type message struct {
+	Type   string          `json:"type"`
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result,omitempty"`
+
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+
+	Code       string `json:"error"`
+	Message    string `json:"message"`
+	Stacktrace string `json:"stacktrace"`
+}
+
+func (msg *message) err() *Error {
+	return &Error{Code: msg.Code, Message: msg.Message, Stacktrace: msg.Stacktrace}
+}
+
+// Client is a WebDriver BiDi connection instance.
+type Client struct {
+	count uint64
+
+	ws cdp.WebSocketable
+
+	pending sync.Map    // pending commands
+	event   chan *Event // events from browser
+
+	closed chan struct{} // closed when the connection to the browser is broken
+	err    error         // the reason why the connection is broken
+
+	logger utils.Logger
+}
+
+// New creates a bidi connection, all messages from Client.Event must be received or they will block the client.
+func New() *Client {
+	return &Client{
+		event:  make(chan *Event),
+		closed: make(chan struct{}),
+		logger: defaults.CDP,
+	}
+}
+
+// Logger sets the logger to log all the commands, results, and events transferred between Rod and the browser.
+func (c *Client) Logger(l utils.Logger) *Client {
+	c.logger = l
+	return c
+}
+
+// Start to browser
+func (c *Client) Start(ws cdp.WebSocketable) *Client {
+	c.ws = ws
+
+	go c.consumeMessages()
+
+	return c
+}
+
+type result struct {
+	msg json.RawMessage
+	err error
+}
+
+// Call a command and wait for its result, such as:
+//
+//	res, err := client.Call(ctx, "browsingContext.navigate", map[string]interface{}{"context": id, "url": u})
+//
+// It returns immediately when the ctx is done or the connection to the browser is broken.
+func (c *Client) Call(ctx context.Context, method string, params interface{}) ([]byte, error) {
+	if params == nil {
+		params = struct{}{}
+	}
+
+	cmd := &Command{
+		ID:     int(atomic.AddUint64(&c.count, 1)),
+		Method: method,
+		Params: params,
+	}
+
+	c.logger.Println(cmd)
+
+	data, err := json.Marshal(cmd)
+	utils.E(err)
+
+	done := make(chan result)
+	once := sync.Once{}
+	c.pending.Store(cmd.ID, func(res result) {
+		once.Do(func() {
+			select {
+			case <-ctx.Done():
+			case done <- res:
+			}
+		})
+	})
+	defer c.pending.Delete(cmd.ID)
+
+	err = c.ws.Send(data)
+	if err != nil {
+		return nil, err
+	}
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		return res.msg, res.err
+	case <-c.closed:
+		return nil, c.err
+	}
+}
+
+// Event returns a channel that will emit the events the session subscribed via "session.subscribe".
+// Must be consumed or will block producer.
+func (c *Client) Event() <-chan *Event {
+	return c.event
+}
+
+// Consume messages coming from the browser via the websocket.
+func (c *Client) consumeMessages() {
+	defer close(c.event)
+
+	for {
+		data, err := c.ws.Read()
+		if err != nil {
+			c.err = err
+			close(c.closed)
+
+			c.pending.Range(func(_, val interface{}) bool {
+				val.(func(result))(result{err: err})
+				return true
+			})
+			return
+		}
+
+		var msg message
+		err = json.Unmarshal(data, &msg)
+		utils.E(err)
+
+		if msg.Type == "event" {
+			e := &Event{Method: msg.Method, Params: msg.Params}
+			c.logger.Println(e)
+			c.event <- e
+			continue
+		}
+
+		c.logger.Println(&msg)
+
+		val, ok := c.pending.Load(msg.ID)
+		if !ok {
+			continue
+		}
+		if msg.Type == "error" {
+			val.(func(result))(result{nil, msg.err()})
+		} else {
+			val.(func(result))(result{msg.Result, nil})
+		}
+	}
+}
+
+// StartWithURL helper to connect to the u with the default websocket lib, such as the
+// "webSocketUrl" capability returned by a WebDriver session, or "ws://127.0.0.1:9222/session" of Firefox.
+func StartWithURL(ctx context.Context, u string, h http.Header) (*Client, error) {
+	ws := &cdp.WebSocket{}
+	err := ws.Connect(ctx, u, h)
+	if err != nil {
+		return nil, err
+	}
+	return New().Start(ws), nil
+}
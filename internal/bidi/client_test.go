@@ -0,0 +1,109 @@
+package bidi_test
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/goccy/go-json"
+
+	"github.com/go-rod/rod/internal/bidi"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/got"
+	"github.com/ysmood/gson"
+)
+
+var setup = got.Setup(nil)
+
+type MockWebSocket struct {
+	send func(data []byte) error
+	read func() ([]byte, error)
+}
+
+func (c *MockWebSocket) Send(data []byte) error {
+	return c.send(data)
+}
+
+func (c *MockWebSocket) Read() ([]byte, error) {
+	return c.read()
+}
+
+func TestClient(t *testing.T) {
+	g := setup(t)
+
+	req := make(chan []byte, 10)
+	t.Cleanup(func() { close(req) })
+
+	ws := &MockWebSocket{
+		send: func(data []byte) error {
+			req <- data
+			return nil
+		},
+		read: func() ([]byte, error) {
+			data, ok := <-req
+			if !ok {
+				return nil, io.EOF
+			}
+
+			cmd := gson.New(data)
+			switch cmd.Get("method").Str() {
+			case "session.status":
+				return []byte(`{"type":"event","method":"log.entryAdded","params":{"text":"ok"}}`), nil
+			case "fail":
+				return json.Marshal(map[string]interface{}{
+					"type": "error", "id": cmd.Get("id").Int(), "error": "unknown command", "message": "fail",
+				})
+			}
+			return json.Marshal(map[string]interface{}{
+				"type": "success", "id": cmd.Get("id").Int(), "result": cmd.Get("params"),
+			})
+		},
+	}
+
+	c := bidi.New().Logger(utils.LoggerQuiet).Start(ws)
+
+	res, err := c.Call(g.Context(), "browsingContext.navigate", map[string]string{"url": "about:blank"})
+	g.E(err)
+	g.Eq(string(res), `{"url":"about:blank"}`)
+
+	res, err = c.Call(g.Context(), "session.new", nil)
+	g.E(err)
+	g.Eq(string(res), `{}`)
+
+	_, err = c.Call(g.Context(), "fail", nil)
+	g.Eq(err.Error(), "unknown command: fail")
+	g.Is(err, &bidi.Error{Code: "unknown command"})
+
+	go func() { _, _ = c.Call(context.Background(), "session.status", nil) }()
+	e := <-c.Event()
+	g.Eq(e.Method, "log.entryAdded")
+	g.Eq(gson.New([]byte(e.Params)).Get("text").Str(), "ok")
+}
+
+func TestClientClosed(t *testing.T) {
+	g := setup(t)
+
+	ws := &MockWebSocket{
+		send: func([]byte) error { return nil },
+		read: func() ([]byte, error) { return nil, io.EOF },
+	}
+
+	c := bidi.New().Start(ws)
+
+	for range c.Event() {
+		utils.Noop()
+	}
+
+	_, err := c.Call(g.Context(), "session.status", nil)
+	g.Eq(err, io.EOF)
+
+	_, err = bidi.StartWithURL(g.Context(), "://", nil)
+	g.Err(err)
+}
+
+func TestFormat(t *testing.T) {
+	g := setup(t)
+
+	g.Eq(bidi.Command{ID: 1, Method: "session.new", Params: 1}.String(), "=> #1 session.new 1")
+	g.Eq(bidi.Event{Method: "log.entryAdded", Params: []byte("1")}.String(), "<- log.entryAdded 1")
+}
@@ -0,0 +1,22 @@
+package bidi
+
+import (
+	"fmt"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func (cmd Command) String() string {
+	return fmt.Sprintf("=> #%d %s %s", cmd.ID, cmd.Method, utils.MustToJSON(cmd.Params))
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("<- %s %s", e.Method, utils.MustToJSON(e.Params))
+}
+
+func (msg message) String() string {
+	if msg.Type == "error" {
+		return fmt.Sprintf("<= #%d error: %s", msg.ID, msg.err().Error())
+	}
+	return fmt.Sprintf("<= #%d %s", msg.ID, utils.MustToJSON(msg.Result))
+}
@@ -34,6 +34,16 @@ type Event struct {
 	Params    json.RawMessage `json:"params,omitempty"`
 }
 
+// message is either a Response or an Event from the browser, so it only needs to be decoded once
+type message struct {
+	ID        int             `json:"id"`
+	SessionID string          `json:"sessionId,omitempty"`
+	Method    string          `json:"method"`
+	Params    json.RawMessage `json:"params,omitempty"`
+	Result    json.RawMessage `json:"result,omitempty"`
+	Error     *Error          `json:"error,omitempty"`
+}
+
 // WebSocketable enables you to choose the websocket lib you want to use.
 // Such as you can easily wrap gorilla/websocket and use it as the transport layer.
 type WebSocketable interface {
@@ -52,15 +62,73 @@ type Client struct {
 	pending sync.Map    // pending requests
 	event   chan *Event // events from browser
 
+	closed chan struct{} // closed when the connection to the browser is broken
+	err    error         // the reason why the connection is broken
+
+	handler      Handler      // see Client.Use
+	eventHandler EventHandler // see Client.UseEvent
+
 	logger utils.Logger
+
+	codec Codec // see Client.Codec
+}
+
+// Codec encodes the requests and decodes the messages from the browser
+type Codec interface {
+	Marshal(v interface{}) ([]byte, error)
+	Unmarshal(data []byte, v interface{}) error
 }
 
+// DefaultCodec is based on github.com/goccy/go-json
+var DefaultCodec Codec = defaultCodec{}
+
+type defaultCodec struct{}
+
+func (defaultCodec) Marshal(v interface{}) ([]byte, error)      { return json.Marshal(v) }
+func (defaultCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// Handler sends the req to the browser and returns the result of the response
+type Handler func(ctx context.Context, req *Request) ([]byte, error)
+
+// Middleware wraps the next Handler, it can observe or modify the request and the response
+type Middleware func(next Handler) Handler
+
+// EventHandler handles an event from the browser
+type EventHandler func(e *Event)
+
+// EventMiddleware wraps the next EventHandler, it can observe, modify, or drop the event.
+// To drop the event, don't call the next handler.
+type EventMiddleware func(next EventHandler) EventHandler
+
 // New creates a cdp connection, all messages from Client.Event must be received or they will block the client.
 func New() *Client {
-	return &Client{
+	cdp := &Client{
 		event:  make(chan *Event),
+		closed: make(chan struct{}),
 		logger: defaults.CDP,
+		codec:  DefaultCodec,
 	}
+	cdp.handler = cdp.send
+	cdp.eventHandler = func(e *Event) { cdp.event <- e }
+	return cdp
+}
+
+// Use middlewares for all the calls, the first one is the outermost.
+// It should be used before the Client.Start.
+func (cdp *Client) Use(list ...Middleware) *Client {
+	for i := len(list) - 1; i >= 0; i-- {
+		cdp.handler = list[i](cdp.handler)
+	}
+	return cdp
+}
+
+// UseEvent middlewares for all the events, the first one is the outermost.
+// It should be used before the Client.Start.
+func (cdp *Client) UseEvent(list ...EventMiddleware) *Client {
+	for i := len(list) - 1; i >= 0; i-- {
+		cdp.eventHandler = list[i](cdp.eventHandler)
+	}
+	return cdp
 }
 
 // Logger sets the logger to log all the requests, responses, and events transferred between Rod and the browser.
@@ -70,6 +138,14 @@ func (cdp *Client) Logger(l utils.Logger) *Client {
 	return cdp
 }
 
+// Codec sets the json codec for the messages transferred between Rod and the browser, such as to plug a faster
+// json lib when streaming thousands of events per second. The default is [DefaultCodec].
+// It should be used before the Client.Start.
+func (cdp *Client) Codec(c Codec) *Client {
+	cdp.codec = c
+	return cdp
+}
+
 // Start to browser
 func (cdp *Client) Start(ws WebSocketable) *Client {
 	cdp.ws = ws
@@ -84,7 +160,8 @@ type result struct {
 	err error
 }
 
-// Call a method and wait for its response
+// Call a method and wait for its response.
+// It returns immediately when the ctx is done or the connection to the browser is broken.
 func (cdp *Client) Call(ctx context.Context, sessionID, method string, params interface{}) ([]byte, error) {
 	req := &Request{
 		ID:        int(atomic.AddUint64(&cdp.count, 1)),
@@ -93,9 +170,13 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 		Params:    params,
 	}
 
+	return cdp.handler(ctx, req)
+}
+
+func (cdp *Client) send(ctx context.Context, req *Request) ([]byte, error) {
 	cdp.logger.Println(req)
 
-	data, err := json.Marshal(req)
+	data, err := cdp.codec.Marshal(req)
 	utils.E(err)
 
 	done := make(chan result)
@@ -120,6 +201,8 @@ func (cdp *Client) Call(ctx context.Context, sessionID, method string, params in
 		return nil, ctx.Err()
 	case res := <-done:
 		return res.msg, res.err
+	case <-cdp.closed:
+		return nil, cdp.err
 	}
 }
 
@@ -135,6 +218,9 @@ func (cdp *Client) consumeMessages() {
 	for {
 		data, err := cdp.ws.Read()
 		if err != nil {
+			cdp.err = err
+			close(cdp.closed)
+
 			cdp.pending.Range(func(_, val interface{}) bool {
 				val.(func(result))(result{err: err})
 				return true
@@ -142,28 +228,21 @@ func (cdp *Client) consumeMessages() {
 			return
 		}
 
-		var id struct {
-			ID int `json:"id"`
-		}
-		err = json.Unmarshal(data, &id)
+		var msg message
+		err = cdp.codec.Unmarshal(data, &msg)
 		utils.E(err)
 
-		if id.ID == 0 {
-			var evt Event
-			err := json.Unmarshal(data, &evt)
-			utils.E(err)
-			cdp.logger.Println(&evt)
-			cdp.event <- &evt
+		if msg.ID == 0 {
+			evt := &Event{msg.SessionID, msg.Method, msg.Params}
+			cdp.logger.Println(evt)
+			cdp.eventHandler(evt)
 			continue
 		}
 
-		var res Response
-		err = json.Unmarshal(data, &res)
-		utils.E(err)
-
-		cdp.logger.Println(&res)
+		res := &Response{msg.ID, msg.Result, msg.Error}
+		cdp.logger.Println(res)
 
-		val, ok := cdp.pending.Load(id.ID)
+		val, ok := cdp.pending.Load(res.ID)
 		if !ok {
 			continue
 		}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/got"
 	"github.com/ysmood/gotrace"
@@ -280,6 +281,150 @@ func TestCancelCallLeak(t *testing.T) {
 	}
 }
 
+func TestCallAfterClosed(t *testing.T) {
+	g := setup(t)
+
+	gotrace.CheckLeak(g, 0)
+
+	ws := &MockWebSocket{
+		send: func([]byte) error { return nil },
+		read: func() ([]byte, error) { return nil, io.EOF },
+	}
+
+	c := cdp.New().Start(ws)
+
+	// wait until the connection is broken
+	for range c.Event() {
+		utils.Noop()
+	}
+
+	_, err := c.Call(g.Context(), "", "method", nil)
+	g.Eq(err, io.EOF)
+}
+
+func TestMiddleware(t *testing.T) {
+	g := setup(t)
+
+	gotrace.CheckLeak(g, 0)
+
+	wait := make(chan int)
+	sent := make(chan []byte, 1)
+	id := 0
+
+	ws := &MockWebSocket{
+		send: func(data []byte) error {
+			sent <- data
+			close(wait)
+			return nil
+		},
+		read: func() ([]byte, error) {
+			id++
+			switch id {
+			case 1:
+				return json.Marshal(cdp.Event{Method: "drop"})
+			case 2:
+				return json.Marshal(cdp.Event{Method: "event"})
+			case 3:
+				<-wait
+				return json.Marshal(cdp.Response{ID: 1, Result: json.RawMessage("1")})
+			}
+			return nil, io.EOF
+		},
+	}
+
+	list := []string{}
+	trace := func(name string) cdp.Middleware {
+		return func(next cdp.Handler) cdp.Handler {
+			return func(ctx context.Context, req *cdp.Request) ([]byte, error) {
+				list = append(list, name)
+				req.Method = name + "." + req.Method
+				return next(ctx, req)
+			}
+		}
+	}
+
+	c := cdp.New().Use(trace("a"), trace("b")).UseEvent(func(next cdp.EventHandler) cdp.EventHandler {
+		return func(e *cdp.Event) {
+			if e.Method != "drop" {
+				e.Method = "modified"
+				next(e)
+			}
+		}
+	}).Start(ws)
+
+	e := <-c.Event()
+	g.Eq(e.Method, "modified")
+
+	res, err := c.Call(g.Context(), "", "method", nil)
+	g.E(err)
+	g.Eq(string(res), "1")
+	g.Eq(list, []string{"a", "b"})
+	g.Has(string(<-sent), `"method":"b.a.method"`)
+
+	for range c.Event() {
+		utils.Noop()
+	}
+}
+
+type countCodec struct {
+	marshal, unmarshal int
+}
+
+func (c *countCodec) Marshal(v interface{}) ([]byte, error) {
+	c.marshal++
+	return cdp.DefaultCodec.Marshal(v)
+}
+
+func (c *countCodec) Unmarshal(data []byte, v interface{}) error {
+	c.unmarshal++
+	return cdp.DefaultCodec.Unmarshal(data, v)
+}
+
+func TestCodec(t *testing.T) {
+	g := setup(t)
+
+	wait := make(chan int)
+	id := 0
+
+	ws := &MockWebSocket{
+		send: func([]byte) error {
+			close(wait)
+			return nil
+		},
+		read: func() ([]byte, error) {
+			id++
+			switch id {
+			case 1:
+				return json.Marshal(cdp.Event{SessionID: "s", Method: "event", Params: json.RawMessage(`{"a":1}`)})
+			case 2:
+				<-wait
+				return json.Marshal(cdp.Response{ID: 1, Result: json.RawMessage("1")})
+			}
+			return nil, io.EOF
+		},
+	}
+
+	codec := &countCodec{}
+	c := cdp.New().Codec(codec).Start(ws)
+
+	e := <-c.Event()
+	g.Eq(e.SessionID, "s")
+	g.Eq(e.Method, "event")
+	g.Eq(string(e.Params), `{"a":1}`)
+
+	res, err := c.Call(g.Context(), "", "method", nil)
+	g.E(err)
+	g.Eq(string(res), "1")
+
+	for range c.Event() {
+		utils.Noop()
+	}
+
+	// each message is only decoded once
+	g.Eq(codec.marshal, 1)
+	g.Eq(codec.unmarshal, 2)
+}
+
 func TestConcurrentCall(t *testing.T) {
 	g := setup(t)
 
@@ -356,3 +501,73 @@ func (c *MockWebSocket) Send(data []byte) error {
 func (c *MockWebSocket) Read() ([]byte, error) {
 	return c.read()
 }
+
+func TestEventLoad(t *testing.T) {
+	g := setup(t)
+
+	e := &cdp.Event{
+		Method: "Page.frameNavigated",
+		Params: json.RawMessage(`{"frame":{"id":"1","url":"http://a.com"}}`),
+	}
+
+	var load proto.PageLoadEventFired
+	ok, err := e.Load(&load)
+	g.E(err)
+	g.False(ok)
+
+	var nav proto.PageFrameNavigated
+	ok, err = e.Load(&nav)
+	g.E(err)
+	g.True(ok)
+	g.Eq(nav.Frame.ID, proto.PageFrameID("1"))
+
+	e.Params = json.RawMessage(`{"frame":1}`)
+	_, err = e.Load(&nav)
+	g.Err(err)
+}
+
+func TestRetry(t *testing.T) {
+	g := setup(t)
+
+	count := 0
+	errs := []error{cdp.ErrCtxNotFound, cdp.ErrNodeDetached, nil}
+	h := cdp.Retry(cdp.RetryPolicy{})(func(_ context.Context, _ *cdp.Request) ([]byte, error) {
+		err := errs[count]
+		count++
+		if err != nil {
+			return nil, err
+		}
+		return []byte("1"), nil
+	})
+
+	res, err := h(g.Context(), &cdp.Request{})
+	g.E(err)
+	g.Eq(string(res), "1")
+	g.Eq(count, 3)
+
+	count = 0
+	h = cdp.Retry(cdp.RetryPolicy{
+		Sleeper: func() utils.Sleeper { return utils.CountSleeper(2) },
+	})(func(_ context.Context, _ *cdp.Request) ([]byte, error) {
+		count++
+		return nil, cdp.ErrTargetNavigatedOrClosed
+	})
+
+	_, err = h(g.Context(), &cdp.Request{})
+	g.Eq(err, cdp.ErrTargetNavigatedOrClosed)
+	g.Eq(count, 3)
+
+	count = 0
+	h = cdp.Retry(cdp.RetryPolicy{
+		Transient: func(err error) bool { return err == io.EOF },
+	})(func(_ context.Context, _ *cdp.Request) ([]byte, error) {
+		count++
+		return nil, cdp.ErrCtxNotFound
+	})
+
+	_, err = h(g.Context(), &cdp.Request{})
+	g.Eq(err, cdp.ErrCtxNotFound)
+	g.Eq(count, 1)
+
+	g.False(cdp.IsTransient(io.EOF))
+}
@@ -63,3 +63,21 @@ var ErrNotAttachedToActivePage = &Error{
 	Code:    -32000,
 	Message: "Not attached to an active page",
 }
+
+// ErrTargetNavigatedOrClosed type
+var ErrTargetNavigatedOrClosed = &Error{
+	Code:    -32000,
+	Message: "Inspected target navigated or closed",
+}
+
+// ErrNodeDetached type
+var ErrNodeDetached = &Error{
+	Code:    -32000,
+	Message: "Node is detached from document",
+}
+
+// ErrNoResource type
+var ErrNoResource = &Error{
+	Code:    -32000,
+	Message: "No resource with given URL found",
+}
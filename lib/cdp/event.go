@@ -0,0 +1,20 @@
+package cdp
+
+import "github.com/goccy/go-json"
+
+// Load the Params into v if v matches the Method, v is usually a struct of the proto lib, such as:
+//
+//	var load proto.PageLoadEventFired
+//	ok, err := e.Load(&load)
+//
+// It returns the json error if the shape of the Params doesn't match v.
+// To decode the Params into the typed struct of the Method, use proto.DecodeEvent.
+func (e *Event) Load(v interface{ ProtoEvent() string }) (bool, error) {
+	if e.Method != v.ProtoEvent() {
+		return false, nil
+	}
+	if len(e.Params) == 0 {
+		return true, nil
+	}
+	return true, json.Unmarshal(e.Params, v)
+}
@@ -0,0 +1,44 @@
+package cdp
+
+import (
+	"bufio"
+	"io"
+	"sync"
+)
+
+var _ WebSocketable = &Pipe{}
+
+// Pipe is the transport for the browser launched with the "--remote-debugging-pipe" flag.
+// The browser reads messages from fd 3 and writes messages to fd 4, each message ends with a null byte.
+// Unlike WebSocket, it doesn't expose any debugging port. Both the Read and Write are thread-safe.
+type Pipe struct {
+	lock sync.Mutex
+	w    io.Writer
+	r    *bufio.Reader
+}
+
+// NewPipe creates a Pipe, w is the writer to the fd 3 of the browser, r is the reader of the fd 4 of the browser.
+func NewPipe(w io.Writer, r io.Reader) *Pipe {
+	return &Pipe{w: w, r: bufio.NewReader(r)}
+}
+
+// Send a message to browser
+func (p *Pipe) Send(msg []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	data := make([]byte, len(msg)+1)
+	copy(data, msg)
+
+	_, err := p.w.Write(data)
+	return err
+}
+
+// Read a message from browser
+func (p *Pipe) Read() ([]byte, error) {
+	b, err := p.r.ReadBytes(0)
+	if err != nil {
+		return nil, err
+	}
+	return b[:len(b)-1], nil
+}
@@ -0,0 +1,31 @@
+package cdp_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/cdp"
+)
+
+func TestPipe(t *testing.T) {
+	g := setup(t)
+
+	w := bytes.NewBuffer(nil)
+	p := cdp.NewPipe(w, strings.NewReader("a\x00bc\x00d"))
+
+	g.E(p.Send([]byte("ok")))
+	g.Eq(w.String(), "ok\x00")
+
+	b, err := p.Read()
+	g.E(err)
+	g.Eq(string(b), "a")
+
+	b, err = p.Read()
+	g.E(err)
+	g.Eq(string(b), "bc")
+
+	_, err = p.Read()
+	g.Eq(err, io.EOF)
+}
@@ -0,0 +1,73 @@
+package cdp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// TransientErrors are the known errors that usually disappear when the call is retried,
+// such as the errors caused by a page that is navigating.
+var TransientErrors = []error{
+	ErrCtxNotFound,
+	ErrTargetNavigatedOrClosed,
+	ErrNodeDetached,
+}
+
+// IsTransient returns true if err is one of the [TransientErrors].
+func IsTransient(err error) bool {
+	for _, e := range TransientErrors {
+		if errors.Is(err, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryPolicy for the [Retry] middleware
+type RetryPolicy struct {
+	// Transient returns true if the call should be retried for the err.
+	// Default is [IsTransient].
+	Transient func(err error) bool
+
+	// Sleeper creates the sleeper for each call, the call stops retrying when the sleeper returns error.
+	// Default is 3 retries with a backoff from 100ms to 1s.
+	Sleeper func() utils.Sleeper
+}
+
+// Retry middleware retries the calls that fail with transient errors, such as:
+//
+//	client := cdp.New().Use(cdp.Retry(cdp.RetryPolicy{}))
+//
+// The last error is returned when the sleeper stops. Only use it when the calls you make are safe to repeat.
+func Retry(p RetryPolicy) Middleware {
+	transient := p.Transient
+	if transient == nil {
+		transient = IsTransient
+	}
+
+	sleeper := p.Sleeper
+	if sleeper == nil {
+		sleeper = func() utils.Sleeper {
+			return utils.EachSleepers(utils.CountSleeper(3), utils.BackoffSleeper(100*time.Millisecond, time.Second, nil))
+		}
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) ([]byte, error) {
+			s := sleeper()
+			for {
+				res, err := next(ctx, req)
+				if err == nil || !transient(err) {
+					return res, err
+				}
+
+				if s(ctx) != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
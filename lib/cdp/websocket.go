@@ -3,6 +3,7 @@ package cdp
 import (
 	"bufio"
 	"context"
+	"crypto/tls"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -19,7 +20,8 @@ var _ WebSocketable = &WebSocket{}
 // Limitation: https://bugs.chromium.org/p/chromium/issues/detail?id=1069431
 // Ref: https://tools.ietf.org/html/rfc6455
 type WebSocket struct {
-	// Dialer is usually used for proxy
+	// Dialer is usually used for proxy, such as [ProxyDialer].
+	// For wss, the connection it returns will be wrapped with TLS, unless it's already a [tls.Conn].
 	Dialer Dialer
 
 	lock sync.Mutex
@@ -45,6 +47,10 @@ func (ws *WebSocket) Connect(ctx context.Context, wsURL string, header http.Head
 		return err
 	}
 
+	if _, ok := conn.(*tls.Conn); !ok && u.Scheme == "wss" {
+		conn = tls.Client(conn, &tls.Config{ServerName: u.Hostname()})
+	}
+
 	ws.conn = conn
 	ws.r = bufio.NewReader(conn)
 	return ws.handshake(ctx, u, header)
@@ -56,15 +62,20 @@ func (ws *WebSocket) Close() error {
 }
 
 func (ws *WebSocket) initDialer(u *url.URL) {
+	if u.Port() == "" {
+		if u.Scheme == "wss" {
+			u.Host += ":443"
+		} else {
+			u.Host += ":80"
+		}
+	}
+
 	if ws.Dialer != nil {
 		return
 	}
 
 	if u.Scheme == "wss" {
 		ws.Dialer = &tlsDialer{}
-		if u.Port() == "" {
-			u.Host += ":443"
-		}
 	} else {
 		ws.Dialer = &net.Dialer{}
 	}
@@ -22,12 +22,16 @@ func TestWebSocketErr(t *testing.T) {
 	g.Err(ws.Connect(g.Context(), "://", nil))
 
 	ws.Dialer = &net.Dialer{}
-	ws.initDialer(nil)
+	u, err := url.Parse("ws://no-exist")
+	g.E(err)
+	ws.initDialer(u)
+	g.Eq(u.Host, "no-exist:80")
 
-	u, err := url.Parse("wss://no-exist")
+	u, err = url.Parse("wss://no-exist")
 	g.E(err)
 	ws.Dialer = nil
 	ws.initDialer(u)
+	g.Eq(u.Host, "no-exist:443")
 
 	mc := &MockConn{}
 	ws.conn = mc
@@ -1,9 +1,15 @@
 package cdp_test
 
 import (
+	"bufio"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
+	"net/url"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -107,6 +113,107 @@ func newPage(ctx context.Context, g got.G) (*cdp.Client, string) {
 	return client, sessionID
 }
 
+func TestProxyDialer(t *testing.T) {
+	g := setup(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.E(err)
+	defer func() { _ = l.Close() }()
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		g.E(err)
+		g.Eq(req.Method, http.MethodConnect)
+		g.Eq(req.Host, "browser:9222")
+
+		user, pass, _ := (&http.Request{Header: http.Header{
+			"Authorization": req.Header["Proxy-Authorization"],
+		}}).BasicAuth()
+		g.Eq(user+":"+pass, "user:pass")
+
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\nok"))
+	}()
+
+	d := &cdp.ProxyDialer{Proxy: &url.URL{Scheme: "http", User: url.UserPassword("user", "pass"), Host: l.Addr().String()}}
+
+	conn, err := d.DialContext(g.Context(), "tcp", "browser:9222")
+	g.E(err)
+	b, err := ioutil.ReadAll(conn)
+	g.E(err)
+	g.Eq(string(b), "ok")
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		_, _ = conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+		_ = conn.Close()
+	}()
+
+	_, err = d.DialContext(g.Context(), "tcp", "browser:9222")
+	g.Eq(err.Error(), "proxy CONNECT failed: 407 Proxy Authentication Required")
+}
+
+func TestProxyDialerWSS(t *testing.T) {
+	g := setup(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	g.E(err)
+	defer func() { _ = l.Close() }()
+
+	serverName := make(chan string, 1)
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		g.E(err)
+		g.Eq(req.Host, "browser:443")
+
+		_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+
+		// the client should start the TLS handshake with the browser through the tunnel
+		_ = tls.Server(conn, &tls.Config{
+			GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+				serverName <- hello.ServerName
+				return nil, errors.New("stop")
+			},
+		}).Handshake()
+	}()
+
+	d := &redirectDialer{to: l.Addr().String()}
+	ws := &cdp.WebSocket{Dialer: &cdp.ProxyDialer{
+		Proxy:  &url.URL{Scheme: "http", Host: "proxy"},
+		Dialer: d,
+	}}
+
+	g.Err(ws.Connect(g.Context(), "wss://browser/devtools/browser", nil))
+	g.Eq(d.address, "proxy:80")
+	g.Eq(<-serverName, "browser")
+}
+
+// redirectDialer dials the to address instead, and records the address to dial
+type redirectDialer struct {
+	to      string
+	address string
+}
+
+func (d *redirectDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.address = address
+	return (&net.Dialer{}).DialContext(ctx, network, d.to)
+}
+
 func TestDuplicatedConnectErr(t *testing.T) {
 	g := setup(t)
 
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// headers flag can be set multiple times
+type headers []string
+
+func (h *headers) String() string { return strings.Join(*h, ", ") }
+
+func (h *headers) Set(v string) error {
+	kv := strings.SplitN(v, ":", 2)
+	if len(kv) != 2 {
+		return fmt.Errorf(`header should be "Key: value", got: %s`, v)
+	}
+	*h = append(*h, strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	return nil
+}
+
+// commands that run once and exit, the key is the name of the command
+var commands = map[string]struct {
+	usage string
+	args  int
+	run   func(p *rod.Page, opts *options, args []string) error
+}{
+	"shot": {"shot [flags] <url> <out.png|out.jpg>", 2, shot},
+	"pdf":  {"pdf [flags] <url> <out.pdf>", 2, pdf},
+	"html": {"html [flags] <url>", 1, html},
+}
+
+type options struct {
+	viewport string
+	wait     string
+	headers  headers
+	full     bool
+	timeout  time.Duration
+
+	out io.Writer // where the html command prints to
+}
+
+func runCommand(name string, args []string) {
+	c := commands[name]
+
+	opts := &options{out: os.Stdout}
+	set := flag.NewFlagSet(name, flag.ExitOnError)
+	set.StringVar(&opts.viewport, "viewport", "1280x800", "the size of the viewport, such as 375x667")
+	set.StringVar(&opts.wait, "wait", "load", `wait for "load", "idle" for no network request for 500ms, `+
+		`"stable" for no dom change for 1s, or a css selector to be visible`)
+	set.Var(&opts.headers, "header", `extra http header for the requests, such as "Authorization: Bearer xxx", can be repeated`)
+	set.BoolVar(&opts.full, "full", false, "capture the full page instead of the viewport, only for the shot command")
+	set.DurationVar(&opts.timeout, "timeout", time.Minute, "the time limit of the command, no limit if it's 0")
+	set.Usage = func() {
+		fmt.Fprintln(set.Output(), "Usage: rod "+c.usage)
+		set.PrintDefaults()
+	}
+	utils.E(set.Parse(args))
+
+	if set.NArg() != c.args {
+		set.Usage()
+		os.Exit(2)
+	}
+
+	b, cleanup := connect()
+	err := capture(b, c.run, opts, set.Args())
+	cleanup()
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// capture opens the url of args[0] in a new page of b, then runs the command on the page.
+// The page is closed after it, so the browser of -control is left as it was.
+func capture(b *rod.Browser, run func(p *rod.Page, opts *options, args []string) error, opts *options, args []string) error {
+	page, err := b.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = page.Close() }()
+
+	p := page
+	if opts.timeout > 0 {
+		p = page.Timeout(opts.timeout)
+		defer p.CancelTimeout()
+	}
+
+	err = open(p, opts, args[0])
+	if err != nil {
+		return err
+	}
+	return run(p, opts, args)
+}
+
+// open the url in the page with the options
+func open(p *rod.Page, opts *options, u string) error {
+	var w, h int
+	if _, err := fmt.Sscanf(opts.viewport, "%dx%d", &w, &h); err != nil {
+		return fmt.Errorf("invalid viewport %q: %w", opts.viewport, err)
+	}
+	err := p.SetViewport(&proto.EmulationSetDeviceMetricsOverride{Width: w, Height: h, DeviceScaleFactor: 1})
+	if err != nil {
+		return err
+	}
+
+	if len(opts.headers) > 0 {
+		_, err = p.SetExtraHeaders(opts.headers)
+		if err != nil {
+			return err
+		}
+	}
+
+	var waitIdle func()
+	if opts.wait == "idle" {
+		waitIdle = p.WaitRequestIdle(500*time.Millisecond, nil, nil, nil)
+	}
+
+	err = p.Navigate(u)
+	if err != nil {
+		return err
+	}
+
+	switch opts.wait {
+	case "load":
+		err = p.WaitLoad()
+	case "idle":
+		waitIdle()
+	case "stable":
+		err = p.WaitStable(time.Second)
+	default:
+		var el *rod.Element
+		el, err = p.Element(opts.wait)
+		if err == nil {
+			err = el.WaitVisible()
+		}
+	}
+
+	return err
+}
+
+func shot(p *rod.Page, opts *options, args []string) error {
+	format := proto.PageCaptureScreenshotFormatPng
+	switch strings.ToLower(filepath.Ext(args[1])) {
+	case ".jpg", ".jpeg":
+		format = proto.PageCaptureScreenshotFormatJpeg
+	case ".webp":
+		format = proto.PageCaptureScreenshotFormatWebp
+	}
+
+	bin, err := p.Screenshot(opts.full, &proto.PageCaptureScreenshot{Format: format})
+	if err != nil {
+		return err
+	}
+	return utils.OutputFile(args[1], bin)
+}
+
+func pdf(p *rod.Page, _ *options, args []string) error {
+	r, err := p.PDF(&proto.PagePrintToPDF{PrintBackground: true})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(args[1])
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func html(p *rod.Page, opts *options, _ []string) error {
+	s, err := p.HTML()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(opts.out, s)
+	return err
+}
+
+// the names of the commands for the usage
+func commandNames() string {
+	list := []string{}
+	for _, name := range []string{"shot", "pdf", "html"} {
+		list = append(list, "  rod "+commands[name].usage)
+	}
+	return strings.Join(list, "\n")
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/ysmood/got"
+)
+
+func TestCommands(t *testing.T) {
+	g := got.T(t)
+
+	browser := rod.New().MustConnect()
+	defer browser.MustClose()
+
+	dir := t.TempDir()
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><p id="a">` + r.Header.Get("X-Test") + `</p></body></html>`))
+	})
+
+	newOpts := func() *options {
+		return &options{viewport: "320x200", wait: "load", timeout: time.Minute, out: bytes.NewBuffer(nil)}
+	}
+
+	{ // shot
+		f := filepath.Join(dir, g.RandStr(8)+".png")
+		g.E(capture(browser, shot, newOpts(), []string{s.URL(), f}))
+		img, err := png.Decode(g.Read(f))
+		g.E(err)
+		g.Eq(img.Bounds().Dx(), 320)
+
+		opts := newOpts()
+		opts.full = true
+		f = filepath.Join(dir, g.RandStr(8)+".jpg")
+		g.E(capture(browser, shot, opts, []string{s.URL(), f}))
+		_, err = jpeg.Decode(g.Read(f))
+		g.E(err)
+
+		f = filepath.Join(dir, g.RandStr(8)+".webp")
+		g.E(capture(browser, shot, newOpts(), []string{s.URL(), f}))
+		g.Has(g.Read(f).String(), "WEBP")
+	}
+
+	{ // pdf
+		f := filepath.Join(dir, g.RandStr(8)+".pdf")
+		g.E(capture(browser, pdf, newOpts(), []string{s.URL(), f}))
+		g.Has(g.Read(f).String(), "%PDF")
+
+		g.Err(capture(browser, pdf, newOpts(), []string{s.URL(), filepath.Join(dir, "not-exists", "a.pdf")}))
+	}
+
+	{ // html with the headers and the wait conditions
+		for _, wait := range []string{"idle", "stable", "#a"} {
+			opts := newOpts()
+			opts.wait = wait
+			g.E(opts.headers.Set("X-Test: ok"))
+			g.E(capture(browser, html, opts, []string{s.URL()}))
+			g.Has(opts.out.(*bytes.Buffer).String(), `<p id="a">ok</p>`)
+		}
+	}
+
+	{ // invalid options
+		opts := newOpts()
+		opts.viewport = "wrong"
+		g.Eq(capture(browser, html, opts, []string{s.URL()}).Error(), `invalid viewport "wrong": expected integer`)
+
+		opts = newOpts()
+		opts.wait = "#not-exists"
+		opts.timeout = 100 * time.Millisecond
+		g.Err(capture(browser, html, opts, []string{s.URL()}))
+
+		g.Eq(opts.headers.Set("wrong").Error(), `header should be "Key: value", got: wrong`)
+	}
+
+	// the created pages are closed
+	pages, err := proto.TargetGetTargets{}.Call(browser)
+	g.E(err)
+	for _, info := range pages.TargetInfos {
+		g.Neq(info.URL, s.URL())
+	}
+
+	g.Has(commandNames(), "rod shot [flags] <url> <out.png|out.jpg>")
+}
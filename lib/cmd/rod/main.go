@@ -0,0 +1,76 @@
+// The rod command line tool. Run it without arguments to start an interactive REPL to iterate on
+// selectors and js expressions against a live page:
+//
+//	go run github.com/go-rod/rod/lib/cmd/rod -show
+//
+// Or use the commands to capture a page without writing Go, such as:
+//
+//	rod shot -viewport 375x667 -wait idle https://example.com out.png
+//	rod pdf -header "Authorization: Bearer xxx" https://example.com out.pdf
+//	rod html -wait "#app" https://example.com
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+var (
+	control = flag.String("control", "", "the control url of a running browser, launch a new one if empty")
+	show    = flag.Bool("show", false, "launch the browser with the window visible")
+	history = flag.String("history", "", "the file to append the Go code of the successful commands, such as rod-repl.txt")
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: rod [flags] [command]\n\nCommands:\n"+commandNames()+
+			"\n\nStart the REPL if no command is given. Flags:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if name := flag.Arg(0); name != "" {
+		if _, has := commands[name]; !has {
+			flag.Usage()
+			os.Exit(2)
+		}
+		runCommand(name, flag.Args()[1:])
+		return
+	}
+
+	b, cleanup := connect()
+	defer cleanup()
+
+	page := b.MustPage()
+
+	r := newREPL(page, os.Stdout)
+
+	if *history != "" {
+		f, err := os.OpenFile(*history, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o664)
+		utils.E(err)
+		defer func() { _ = f.Close() }()
+		r.history = f
+	}
+
+	r.run(os.Stdin)
+}
+
+// connect to the browser of -control, or launch a new one. The returned func closes the launched browser
+// and removes its user data dir, the browser of -control is left running.
+func connect() (*rod.Browser, func()) {
+	if *control != "" {
+		return rod.New().ControlURL(*control).MustConnect(), func() {}
+	}
+
+	l := launcher.New().Headless(!*show)
+	b := rod.New().ControlURL(l.MustLaunch()).MustConnect()
+	return b, func() {
+		_ = b.Close()
+		l.Cleanup()
+	}
+}
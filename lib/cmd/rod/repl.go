@@ -0,0 +1,167 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+const replHelp = `Commands:
+  open <url>          navigate the page to the url
+  $ <selector>        list the elements that match the css selector
+  $x <xpath>          list the elements that match the xpath
+  click <selector>    click the first element that matches the css selector
+  complete <prefix>   list the tags, ids, and classes on the page that start with the last word of prefix
+  help                show this help
+  exit                quit
+Other input is evaluated as a js expression on the page, such as: document.title`
+
+// repl reads a command per line and runs it against the page. The Go code of each successful command is
+// appended to the history, so that it can be pasted into a Go program.
+type repl struct {
+	page    *rod.Page
+	out     io.Writer
+	history io.Writer
+}
+
+func newREPL(page *rod.Page, out io.Writer) *repl {
+	return &repl{page: page, out: out}
+}
+
+func (r *repl) run(in io.Reader) {
+	s := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(r.out, "rod> ")
+		if !s.Scan() {
+			fmt.Fprintln(r.out)
+			return
+		}
+
+		line := strings.TrimSpace(s.Text())
+		switch line {
+		case "":
+			continue
+		case "exit":
+			return
+		case "help":
+			fmt.Fprintln(r.out, replHelp)
+			continue
+		}
+
+		code, err := r.exec(line)
+		if err != nil {
+			fmt.Fprintln(r.out, "error:", err)
+			continue
+		}
+
+		if code != "" && r.history != nil {
+			fmt.Fprintln(r.history, code)
+		}
+	}
+}
+
+// exec the line and returns the equivalent Go code
+func (r *repl) exec(line string) (string, error) {
+	cmd, arg := line, ""
+	if i := strings.IndexByte(line, ' '); i > 0 {
+		cmd, arg = line[:i], strings.TrimSpace(line[i+1:])
+	}
+
+	switch cmd {
+	case "open":
+		err := r.page.Navigate(arg)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("page.MustNavigate(%q).MustWaitLoad()", arg), r.page.WaitLoad()
+
+	case "$":
+		list, err := r.page.Elements(arg)
+		if err != nil {
+			return "", err
+		}
+		r.print(list)
+		return fmt.Sprintf("page.MustElements(%q)", arg), nil
+
+	case "$x":
+		list, err := r.page.ElementsX(arg)
+		if err != nil {
+			return "", err
+		}
+		r.print(list)
+		return fmt.Sprintf("page.MustElementsX(%q)", arg), nil
+
+	case "click":
+		// fail fast on a wrong selector instead of waiting for the element forever
+		el, err := r.page.Sleeper(rod.NotFoundSleeper).Element(arg)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("page.MustElement(%q).MustClick()", arg), el.Click(proto.InputMouseButtonLeft, 1)
+
+	case "complete":
+		list, err := r.complete(arg)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintln(r.out, strings.Join(list, "  "))
+		return "", nil
+	}
+
+	res, err := r.page.Eval(`() => (` + line + `)`)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintln(r.out, res.Value.JSON("", "  "))
+	return fmt.Sprintf("page.MustEval(%q)", "() => ("+line+")"), nil
+}
+
+func (r *repl) print(list rod.Elements) {
+	for i, el := range list {
+		html, err := el.HTML()
+		if err != nil {
+			html = err.Error()
+		}
+		if len(html) > 100 {
+			html = html[:100] + "..."
+		}
+		fmt.Fprintf(r.out, "%d: %s\n", i, html)
+	}
+	fmt.Fprintf(r.out, "(%d elements)\n", len(list))
+}
+
+// complete returns the completions of the last word of prefix from the tags, ids, and classes on the page
+func (r *repl) complete(prefix string) ([]string, error) {
+	res, err := r.page.Eval(`() => {
+		const s = new Set()
+		for (const el of document.querySelectorAll('*')) {
+			s.add(el.localName)
+			if (el.id) s.add('#' + el.id)
+			for (const c of el.classList) s.add('.' + c)
+		}
+		return [...s]
+	}`)
+	if err != nil {
+		return nil, err
+	}
+
+	word := prefix
+	if i := strings.LastIndexAny(prefix, " >+~"); i >= 0 {
+		word = prefix[i+1:]
+	}
+	head := prefix[:len(prefix)-len(word)]
+
+	list := []string{}
+	for _, v := range res.Value.Arr() {
+		if strings.HasPrefix(v.Str(), word) {
+			list = append(list, head+v.Str())
+		}
+	}
+	sort.Strings(list)
+	return list, nil
+}
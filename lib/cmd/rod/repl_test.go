@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/ysmood/got"
+)
+
+func TestREPL(t *testing.T) {
+	g := got.T(t)
+
+	browser := rod.New().MustConnect()
+	defer browser.MustClose()
+
+	page := browser.MustPage()
+	page.MustSetDocumentContent(`<title>t</title><ul><li id="a" class="item" onclick="this.innerText='ok'">a</li><li class="item">b</li></ul>`)
+
+	out := bytes.NewBuffer(nil)
+	history := bytes.NewBuffer(nil)
+
+	r := newREPL(page, out)
+	r.history = history
+	r.run(strings.NewReader(strings.Join([]string{
+		"$ li",
+		"click #typo",
+		"click #a",
+		"document.querySelector('#a').innerText",
+		"complete ul .it",
+		"help",
+		"exit",
+	}, "\n")))
+
+	g.Has(out.String(), "(2 elements)")
+	g.Has(out.String(), "error: cannot find element")
+	g.Has(out.String(), `"ok"`)
+	g.Has(out.String(), "ul .item")
+	g.Has(out.String(), "Commands:")
+
+	g.Eq(history.String(), `page.MustElements("li")
+page.MustElement("#a").MustClick()
+page.MustEval("() => (document.querySelector('#a').innerText)")
+`)
+}
@@ -59,6 +59,14 @@ var LockPort int
 // Option name is "url".
 var URL string
 
+// Manager is the default url of the launcher.Manager to launch the browser remotely,
+// it's used when the URL is empty. Option name is "manager".
+var Manager string
+
+// Selenium is the default url of the Selenium 4 Grid to create the browser session on,
+// it's used when the URL and Manager are empty. Option name is "selenium".
+var Selenium string
+
 // CDP is the default of cdp.Client.Logger
 // Option name is "cdp".
 var CDP utils.Logger
@@ -76,6 +84,8 @@ func Reset() {
 	Proxy = ""
 	LockPort = 2978
 	URL = ""
+	Manager = ""
+	Selenium = ""
 	CDP = utils.LoggerQuiet
 }
 
@@ -125,6 +135,12 @@ var envParsers = map[string]func(string){
 	"url": func(v string) {
 		URL = v
 	},
+	"manager": func(v string) {
+		Manager = v
+	},
+	"selenium": func(v string) {
+		Selenium = v
+	},
 	"cdp": func(v string) {
 		CDP = log.New(log.Writer(), "[cdp] ", log.LstdFlags)
 	},
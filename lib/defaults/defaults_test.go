@@ -25,7 +25,7 @@ func TestBasic(t *testing.T) {
 
 	parse("show,devtools,trace,slow=2s,port=8080,dir=tmp," +
 		"url=http://test.com,cdp,monitor,bin=/path/to/chrome," +
-		"proxy=localhost:8080,lock=9981,",
+		"proxy=localhost:8080,lock=9981,manager=ws://a.com,selenium=http://grid.com,",
 	)
 
 	g.True(Show)
@@ -40,6 +40,8 @@ func TestBasic(t *testing.T) {
 	g.Eq(":0", Monitor)
 	g.Eq("localhost:8080", Proxy)
 	g.Eq(9981, LockPort)
+	g.Eq("ws://a.com", Manager)
+	g.Eq("http://grid.com", Selenium)
 
 	parse("monitor=:1234")
 	g.Eq(":1234", Monitor)
@@ -2,6 +2,9 @@
 package devices
 
 import (
+	"regexp"
+	"strings"
+
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/ysmood/gson"
 )
@@ -38,6 +41,15 @@ func (device Device) Landscape() Device {
 	return d
 }
 
+// WithChromeVersion clones the device and replaces the Chrome version of its UserAgent with version,
+// such as "120.0.6099.71", so that the user agent and client hints match the browser that emulates the device.
+// It does nothing if the UserAgent isn't a Chromium based one.
+func (device Device) WithChromeVersion(version string) Device {
+	d := device
+	d.UserAgent = regChromeVersion.ReplaceAllLiteralString(device.UserAgent, "Chrome/"+version)
+	return d
+}
+
 // MetricsEmulation config
 func (device Device) MetricsEmulation() *proto.EmulationSetDeviceMetricsOverride {
 	if device.IsClear() {
@@ -90,11 +102,65 @@ func (device Device) UserAgentEmulation() *proto.NetworkSetUserAgentOverride {
 	}
 
 	return &proto.NetworkSetUserAgentOverride{
-		UserAgent:      device.UserAgent,
-		AcceptLanguage: device.AcceptLanguage,
+		UserAgent:         device.UserAgent,
+		AcceptLanguage:    device.AcceptLanguage,
+		UserAgentMetadata: device.UserAgentMetadata(),
 	}
 }
 
+var (
+	regChromeVersion  = regexp.MustCompile(`Chrome/((\d+)[\d.]*)`)
+	regAndroidVersion = regexp.MustCompile(`Android ([\d.]+)(?:; (?:[a-z]{2}-[a-z]{2}; )?([^;]+?)(?: Build/[^;)]+)?\) )?`)
+	regMacVersion     = regexp.MustCompile(`Mac OS X ([\d_]+)`)
+)
+
+// UserAgentMetadata config for the client hints, such as the Sec-CH-UA headers and navigator.userAgentData.
+// It's derived from the UserAgent, nil if the UserAgent isn't a Chromium based one, because
+// the other browsers don't support client hints. Use [Device.WithChromeVersion] to change the version of the brands.
+func (device Device) UserAgentMetadata() *proto.EmulationUserAgentMetadata {
+	m := regChromeVersion.FindStringSubmatch(device.UserAgent)
+	if device.IsClear() || m == nil {
+		return nil
+	}
+
+	full, major := m[1], m[2]
+
+	meta := &proto.EmulationUserAgentMetadata{
+		Brands: []*proto.EmulationUserAgentBrandVersion{
+			{Brand: "Chromium", Version: major},
+			{Brand: "Google Chrome", Version: major},
+		},
+		FullVersionList: []*proto.EmulationUserAgentBrandVersion{
+			{Brand: "Chromium", Version: full},
+			{Brand: "Google Chrome", Version: full},
+		},
+		Mobile: has(device.Capabilities, "mobile"),
+	}
+
+	switch {
+	case strings.Contains(device.UserAgent, "Android"):
+		meta.Platform = "Android"
+		if m := regAndroidVersion.FindStringSubmatch(device.UserAgent); m != nil {
+			meta.PlatformVersion = m[1]
+			meta.Model = m[2]
+		}
+	case strings.Contains(device.UserAgent, "Macintosh"):
+		meta.Platform = "macOS"
+		meta.Architecture = "x86"
+		if m := regMacVersion.FindStringSubmatch(device.UserAgent); m != nil {
+			meta.PlatformVersion = strings.ReplaceAll(m[1], "_", ".")
+		}
+	case strings.Contains(device.UserAgent, "Windows"):
+		meta.Platform = "Windows"
+		meta.Architecture = "x86"
+	default:
+		meta.Platform = "Linux"
+		meta.Architecture = "x86"
+	}
+
+	return meta
+}
+
 // IsClear type
 func (device Device) IsClear() bool {
 	return device.clear
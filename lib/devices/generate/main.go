@@ -1,8 +1,11 @@
-// Package main ...
+// Package main generates the device list from the vendored "devices.json", so it works offline
+// and the output is reproducible. Use the "-update" flag to refresh the json from the devtools-frontend.
 package main
 
 import (
+	"flag"
 	"fmt"
+	"go/format"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -11,12 +14,25 @@ import (
 	"github.com/ysmood/gson"
 )
 
+const jsonPath = "./lib/devices/generate/devices.json"
+
+var (
+	update   = flag.Bool("update", false, "download the device list of the revision to "+jsonPath)
+	revision = flag.String("revision", "", "git revision of the devtools-frontend to update from, "+
+		"defaults to the current one in "+jsonPath)
+)
+
 func main() {
-	devices := getDeviceList()
+	flag.Parse()
+
+	if *update {
+		updateDeviceList()
+	}
+
+	list := getDeviceList()
 
 	code := ``
-	for _, d := range devices.Arr() {
-		d = d.Get("device")
+	for _, d := range list.Get("devices").Arr() {
 		name := d.Get("title").String()
 
 		code += utils.S(`
@@ -55,39 +71,58 @@ func main() {
 
 		package devices
 
-		import (
-			"github.com/go-rod/rod/lib/devices"
-		)
+		// Version is the git revision of the devtools-frontend that the list is generated from
+		const Version = "{{.version}}"
 
 		var (
 			{{.code}}
 		)
-	`, "code", code)
+	`, "version", list.Get("revision").Str(), "code", code)
 
-	path := "./lib/devices/list.go"
-	utils.E(utils.OutputFile(path, code))
+	src, err := format.Source([]byte(code))
+	utils.E(err)
 
-	utils.Exec("gofumpt -w", path)
-	utils.Exec(
-		"go run github.com/ysmood/golangci-lint@latest -- "+
-			"run --no-config --fix",
-		path,
-	)
+	utils.E(utils.OutputFile("./lib/devices/list.go", src))
 }
 
 func getDeviceList() gson.JSON {
-	// we use the list from the web UI of devtools
-	// TODO: We should keep update with their latest list, using hash id is a temp solution
+	data, err := ioutil.ReadFile(jsonPath)
+	utils.E(err)
+	return gson.New(data)
+}
+
+// we use the list from the web UI of devtools
+func updateDeviceList() {
+	rev := *revision
+	if rev == "" {
+		rev = getDeviceList().Get("revision").Str()
+	}
+
 	res, err := http.Get(
-		"https://raw.githubusercontent.com/ChromeDevTools/devtools-frontend/c4e2fefe3327aa9fe5f4398a1baddb8726c230d5/front_end/emulated_devices/module.json",
+		"https://raw.githubusercontent.com/ChromeDevTools/devtools-frontend/" + rev +
+			"/front_end/emulated_devices/module.json",
 	)
 	utils.E(err)
 	defer func() { _ = res.Body.Close() }()
 
+	if res.StatusCode != http.StatusOK {
+		panic(fmt.Sprintf("failed to download the device list of %s: %s", rev, res.Status))
+	}
+
 	data, err := ioutil.ReadAll(res.Body)
 	utils.E(err)
 
-	return gson.New(data).Get("extensions")
+	devices := []interface{}{}
+	for _, ext := range gson.New(data).Get("extensions").Arr() {
+		devices = append(devices, ext.Get("device").Val())
+	}
+
+	list := gson.New(map[string]interface{}{
+		"revision": rev,
+		"devices":  devices,
+	})
+
+	utils.E(utils.OutputFile(jsonPath, list.JSON("", "  ")+"\n"))
 }
 
 func normalizeName(name string) string {
@@ -2,6 +2,9 @@
 
 package devices
 
+// Version is the git revision of the devtools-frontend that the list is generated from
+const Version = "c4e2fefe3327aa9fe5f4398a1baddb8726c230d5"
+
 var (
 
 	// IPhone4 device
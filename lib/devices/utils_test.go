@@ -33,3 +33,35 @@ func TestErr(t *testing.T) {
 	as.False(devices.Clear.TouchEmulation().Enabled)
 	as.Nil(devices.Clear.UserAgentEmulation())
 }
+
+func TestUserAgentMetadata(t *testing.T) {
+	as := got.New(t)
+
+	m := devices.Pixel2.UserAgentMetadata()
+	as.Eq(m.Platform, "Android")
+	as.Eq(m.PlatformVersion, "8.0")
+	as.Eq(m.Model, "Pixel 2")
+	as.Eq(m.Brands[0].Version, "87")
+	as.Eq(m.FullVersionList[0].Version, "87.0.4280.88")
+	as.True(m.Mobile)
+
+	m = devices.LaptopWithMDPIScreen.UserAgentMetadata()
+	as.Eq(m.Platform, "macOS")
+	as.Eq(m.PlatformVersion, "11.0.0")
+	as.False(m.Mobile)
+
+	as.Eq(devices.GalaxyS5.UserAgentMetadata().Model, "SM-G900P")
+	as.Eq(devices.Nexus10.UserAgentMetadata().Model, "Nexus 10")
+	as.Eq(devices.MotoG4.UserAgentMetadata().Model, "Moto G (4)")
+
+	as.Nil(devices.IPad.UserAgentMetadata())
+	as.Nil(devices.Clear.UserAgentMetadata())
+	as.Eq(devices.Pixel2.UserAgentEmulation().UserAgentMetadata, devices.Pixel2.UserAgentMetadata())
+
+	d := devices.Pixel2.WithChromeVersion("120.0.6099.71")
+	as.Has(d.UserAgent, "Chrome/120.0.6099.71 Mobile")
+	as.Eq(d.UserAgentMetadata().Brands[0].Version, "120")
+	as.Eq(d.UserAgentMetadata().FullVersionList[0].Version, "120.0.6099.71")
+	as.Eq(devices.Pixel2.UserAgentMetadata().Brands[0].Version, "87")
+	as.Eq(devices.IPad.WithChromeVersion("120.0.6099.71").UserAgent, devices.IPad.UserAgent)
+}
@@ -0,0 +1,128 @@
+// Package imgdiff compares images pixel by pixel, it's useful for the screenshot based regression tests.
+package imgdiff
+
+import (
+	"errors"
+	"image"
+	"image/color"
+	"math"
+)
+
+// ErrSizeMismatch is returned when the two images have different sizes
+var ErrSizeMismatch = errors.New("images have different sizes")
+
+// Options for Compare
+type Options struct {
+	// Threshold is the max color distance in the range [0, 1] for two pixels to be treated as the same.
+	// The default value is 0.1 .
+	Threshold float64
+
+	// AntiAliasing ignores the pixels that only shift by one pixel between the two images,
+	// such as the edges of the text and shapes that are rendered with anti-aliasing.
+	AntiAliasing bool
+}
+
+// DefaultOptions for Compare
+var DefaultOptions = &Options{
+	Threshold:    0.1,
+	AntiAliasing: true,
+}
+
+// Result of Compare
+type Result struct {
+	// DiffPixels is the count of the different pixels
+	DiffPixels int
+
+	// TotalPixels is the count of all the pixels
+	TotalPixels int
+
+	// Diff image, the different pixels are red, the same pixels are faded gray
+	Diff *image.NRGBA
+}
+
+// Ratio of the different pixels
+func (r *Result) Ratio() float64 {
+	if r.TotalPixels == 0 {
+		return 0
+	}
+	return float64(r.DiffPixels) / float64(r.TotalPixels)
+}
+
+// Compare a and b pixel by pixel. If opts is nil, the DefaultOptions will be used.
+func Compare(a, b image.Image, opts *Options) (*Result, error) {
+	if opts == nil {
+		opts = DefaultOptions
+	}
+
+	ba, bb := a.Bounds(), b.Bounds()
+	if ba.Dx() != bb.Dx() || ba.Dy() != bb.Dy() {
+		return nil, ErrSizeMismatch
+	}
+
+	w, h := ba.Dx(), ba.Dy()
+	res := &Result{
+		TotalPixels: w * h,
+		Diff:        image.NewNRGBA(image.Rect(0, 0, w, h)),
+	}
+
+	at := func(img image.Image, x, y int) color.Color {
+		return img.At(img.Bounds().Min.X+x, img.Bounds().Min.Y+y)
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			ca, cb := at(a, x, y), at(b, x, y)
+
+			if Distance(ca, cb) <= opts.Threshold ||
+				opts.AntiAliasing && shifted(a, b, x, y, opts.Threshold) && shifted(b, a, x, y, opts.Threshold) {
+				gray := color.GrayModel.Convert(ca).(color.Gray)
+				v := 255 - (255-gray.Y)/10
+				res.Diff.SetNRGBA(x, y, color.NRGBA{v, v, v, 255})
+				continue
+			}
+
+			res.DiffPixels++
+			res.Diff.SetNRGBA(x, y, color.NRGBA{255, 0, 0, 255})
+		}
+	}
+
+	return res, nil
+}
+
+// Distance between two colors in the range [0, 1]
+func Distance(a, b color.Color) float64 {
+	r1, g1, b1, a1 := a.RGBA()
+	r2, g2, b2, a2 := b.RGBA()
+
+	d := func(x, y uint32) float64 {
+		v := (float64(x) - float64(y)) / 0xffff
+		return v * v
+	}
+
+	return math.Sqrt((d(r1, r2) + d(g1, g2) + d(b1, b2) + d(a1, a2)) / 4)
+}
+
+// shifted checks if the pixel of a at (x, y) matches one of the neighbor pixels of b
+func shifted(a, b image.Image, x, y int, threshold float64) bool {
+	ba, bb := a.Bounds(), b.Bounds()
+	c := a.At(ba.Min.X+x, ba.Min.Y+y)
+
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if dx == 0 && dy == 0 {
+				continue
+			}
+
+			p := image.Pt(bb.Min.X+x+dx, bb.Min.Y+y+dy)
+			if !p.In(bb) {
+				continue
+			}
+
+			if Distance(c, b.At(p.X, p.Y)) <= threshold {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,81 @@
+package imgdiff_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/go-rod/rod/lib/imgdiff"
+	"github.com/ysmood/got"
+)
+
+var setup = got.Setup(nil)
+
+func newImg(w, h int, fill color.Color) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	return img
+}
+
+func TestCompare(t *testing.T) {
+	g := setup(t)
+
+	white := color.NRGBA{255, 255, 255, 255}
+	black := color.NRGBA{0, 0, 0, 255}
+
+	a := newImg(10, 10, white)
+	b := newImg(10, 10, white)
+
+	res, err := imgdiff.Compare(a, b, nil)
+	g.E(err)
+	g.Eq(res.DiffPixels, 0)
+	g.Eq(res.TotalPixels, 100)
+	g.Eq(res.Ratio(), 0.0)
+
+	b.Set(5, 5, black)
+	res, _ = imgdiff.Compare(a, b, nil)
+	g.Eq(res.DiffPixels, 1)
+	g.Eq(res.Ratio(), 0.01)
+	g.Eq(res.Diff.At(5, 5), color.NRGBA{255, 0, 0, 255})
+
+	// a slightly different color is under the threshold
+	b.Set(5, 5, color.NRGBA{250, 250, 250, 255})
+	res, _ = imgdiff.Compare(a, b, nil)
+	g.Eq(res.DiffPixels, 0)
+
+	_, err = imgdiff.Compare(a, newImg(1, 1, white), nil)
+	g.Eq(err, imgdiff.ErrSizeMismatch)
+
+	g.Eq((&imgdiff.Result{}).Ratio(), 0.0)
+}
+
+func TestCompareAntiAliasing(t *testing.T) {
+	g := setup(t)
+
+	white := color.NRGBA{255, 255, 255, 255}
+	black := color.NRGBA{0, 0, 0, 255}
+
+	// a vertical line that shifts by one pixel
+	a := newImg(10, 10, white)
+	b := newImg(10, 10, white)
+	for y := 0; y < 10; y++ {
+		a.Set(4, y, black)
+		b.Set(5, y, black)
+	}
+
+	res, _ := imgdiff.Compare(a, b, nil)
+	g.Eq(res.DiffPixels, 0)
+
+	res, _ = imgdiff.Compare(a, b, &imgdiff.Options{Threshold: 0.1})
+	g.Eq(res.DiffPixels, 20)
+
+	// an isolated pixel is not anti-aliasing
+	c := newImg(10, 10, white)
+	c.Set(5, 5, color.NRGBA{255, 0, 0, 255})
+	res, _ = imgdiff.Compare(newImg(10, 10, white), c, nil)
+	g.Eq(res.DiffPixels, 1)
+}
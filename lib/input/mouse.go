@@ -1,6 +1,11 @@
 package input
 
-import "github.com/go-rod/rod/lib/proto"
+import (
+	"math"
+	"math/rand"
+
+	"github.com/go-rod/rod/lib/proto"
+)
 
 // MouseKeys is the map for mouse keys
 var MouseKeys = map[proto.InputMouseButton]int{
@@ -23,3 +28,52 @@ func EncodeMouseButton(buttons []proto.InputMouseButton) (proto.InputMouseButton
 	}
 	return btn, flag
 }
+
+// HumanPath returns the points of a cubic bezier curve from the "from" to the "to" with the given steps.
+// The control points are randomly placed beside the straight line, and each point except the last one
+// is shifted by a random offset within the jitter, so that the path looks like a human moving a mouse.
+// The last point is always the "to".
+func HumanPath(from, to proto.Point, steps int, jitter float64) []proto.Point {
+	if steps < 1 {
+		steps = 1
+	}
+
+	d := to.Minus(from)
+	dist := math.Hypot(d.X, d.Y)
+
+	// unit normal of the straight line
+	n := proto.NewPoint(0, 0)
+	if dist > 0 {
+		n = proto.NewPoint(-d.Y, d.X).Scale(1 / dist)
+	}
+
+	spread := dist * 0.2
+	c1 := from.Add(d.Scale(0.2 + rand.Float64()*0.2)).Add(n.Scale(randRange(spread)))
+	c2 := from.Add(d.Scale(0.6 + rand.Float64()*0.2)).Add(n.Scale(randRange(spread)))
+
+	list := make([]proto.Point, 0, steps)
+	for i := 1; i <= steps; i++ {
+		if i == steps {
+			list = append(list, to)
+			break
+		}
+
+		t := float64(i) / float64(steps)
+		mt := 1 - t
+
+		pt := from.Scale(mt * mt * mt).
+			Add(c1.Scale(3 * mt * mt * t)).
+			Add(c2.Scale(3 * mt * t * t)).
+			Add(to.Scale(t * t * t)).
+			Add(proto.NewPoint(randRange(jitter), randRange(jitter)))
+
+		list = append(list, pt)
+	}
+
+	return list
+}
+
+// returns a random value in [-r, r)
+func randRange(r float64) float64 {
+	return (rand.Float64()*2 - 1) * r
+}
@@ -16,3 +16,23 @@ func TestMouseEncode(t *testing.T) {
 	g.Eq(b, proto.InputMouseButtonLeft)
 	g.Eq(flag, 1)
 }
+
+func TestHumanPath(t *testing.T) {
+	g := got.T(t)
+
+	from := proto.NewPoint(10, 10)
+	to := proto.NewPoint(110, 60)
+
+	list := input.HumanPath(from, to, 10, 1)
+	g.Len(list, 10)
+	g.Eq(list[9], to)
+
+	for _, pt := range list {
+		g.Gte(pt.X, 0)
+		g.Lte(pt.X, 120)
+		g.Gte(pt.Y, -20)
+		g.Lte(pt.Y, 90)
+	}
+
+	g.Eq(input.HumanPath(from, from, 0, 0), []proto.Point{from})
+}
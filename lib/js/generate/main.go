@@ -2,39 +2,41 @@
 package main
 
 import (
-	"fmt"
+	"go/format"
 	"regexp"
 	"strings"
 
+	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/utils"
-	"github.com/ysmood/gson"
 )
 
+// The definitions are embedded from "helper.js" at build time, here we only generate the variables of the
+// functions and their dependencies, so it doesn't need any js toolchain.
 func main() {
-	list := getList()
+	code, err := utils.ReadString("lib/js/helper.js")
+	utils.E(err)
+
 	out := "// Package js generated by \"lib/js/generate\"\npackage js\n\n"
 
-	for _, fn := range list.Arr() {
-		name := fn.Get("name").Str()
-		def := fn.Get("definition").Str()
+	for _, fn := range js.Parse(code) {
 		out += utils.S(`
 			// {{.Name}} ...
 			var {{.Name}} = &Function{
 				Name: "{{.name}}",
-				Definition:   {{.definition}},
+				Definition:   definition("{{.name}}"),
 				Dependencies: {{.dependencies}},
 			}
 		`,
-			"Name", fnName(name),
-			"name", name,
-			"definition", utils.EscapeGoString(def),
-			"dependencies", getDeps(def),
+			"Name", fnName(fn.Name),
+			"name", fn.Name,
+			"dependencies", getDeps(fn.Definition),
 		)
 	}
 
-	utils.E(utils.OutputFile("lib/js/helper.go", out))
+	src, err := format.Source([]byte(out))
+	utils.E(err)
 
-	utils.Exec("gofumpt -w lib/js/helper.go")
+	utils.E(utils.OutputFile("lib/js/helper.go", src))
 }
 
 var regDeps = regexp.MustCompile(`\Wfunctions.(\w+)`)
@@ -43,9 +45,15 @@ func getDeps(fn string) string {
 	ms := regDeps.FindAllStringSubmatch(fn, -1)
 
 	list := []string{}
+	has := map[string]bool{}
 
 	for _, m := range ms {
-		list = append(list, fnName(m[1]))
+		name := fnName(m[1])
+		if has[name] {
+			continue
+		}
+		has[name] = true
+		list = append(list, name)
 	}
 
 	return "[]*Function{" + strings.Join(list, ",") + "}"
@@ -54,27 +62,3 @@ func getDeps(fn string) string {
 func fnName(name string) string {
 	return strings.ToUpper(name[0:1]) + name[1:]
 }
-
-func getList() gson.JSON {
-	code := utils.ExecLine(false, "npx -ys -- uglify-js@3.17.4 -c -m -- lib/js/helper.js")
-
-	script := fmt.Sprintf(`
-		%s
-
-		const list = []
-
-		for (const name in functions) {
-			const reg = new RegExp('^(async )?' + name)
-			const definition = functions[name].toString().replace(reg, '$1function')
-			list.push({name, definition})
-		}
-
-		console.log(JSON.stringify(list))
-	`, string(code))
-
-	tmp := "tmp/helper.js"
-
-	utils.E(utils.OutputFile(tmp, script))
-
-	return gson.NewFrom(utils.ExecLine(false, "node", tmp))
-}
@@ -1,7 +1,8 @@
 // The reason to use an extra js file to hold the functions is the lint and IDE support.
+// The file is embedded by "lib/js", only run "go run ./lib/js/generate" when a function is added, removed,
+// or its dependencies changed. Keep the methods of "functions" indented by two spaces, the parser relies on it.
 // To debug just add "debugger" keyword to the line you want to pause, then run something like:
 //
-//     go run ./lib/js/generate/main.go
 //     go test -run ^TestClick$ -- -rod=show,devtools
 
 const functions = {
@@ -370,6 +371,376 @@ const functions = {
     }
   },
 
+  table() {
+    const grid = []
+    const header = []
+
+    const rows = Array.from(this.rows)
+    rows.forEach((row, y) => {
+      grid[y] = grid[y] || []
+      let x = 0
+      for (const cell of row.cells) {
+        while (grid[y][x] !== undefined) x++
+        const text = cell.innerText.trim()
+        const rowSpan = cell.rowSpan || 1
+        for (let dy = 0; dy < rowSpan && y + dy < rows.length; dy++) {
+          grid[y + dy] = grid[y + dy] || []
+          for (let dx = 0; dx < (cell.colSpan || 1); dx++) {
+            grid[y + dy][x + dx] = text
+          }
+        }
+        x += cell.colSpan || 1
+      }
+    })
+
+    const width = Math.max(0, ...grid.map((r) => r.length))
+    for (const r of grid) {
+      for (let x = 0; x < width; x++) if (r[x] === undefined) r[x] = ''
+    }
+
+    // the rows of the thead, or the first row if all its cells are th
+    let count = this.tHead ? this.tHead.rows.length : 0
+    if (!count && rows.length && Array.from(rows[0].cells).every((c) => c.localName === 'th')) count = 1
+    if (count) header.push(...grid[count - 1])
+
+    return { header, rows: grid.slice(count) }
+  },
+
+  article() {
+    const meta = (selector) => {
+      const el = document.querySelector(selector)
+      return el ? (el.content || el.innerText || '').trim() : ''
+    }
+
+    // the candidate that directly contains the most paragraph text wins
+    let root = document.querySelector('article, [role="main"], main')
+    if (!root) {
+      const scores = new Map()
+      for (const p of document.querySelectorAll('p')) {
+        const parent = p.parentElement
+        if (parent) scores.set(parent, (scores.get(parent) || 0) + p.innerText.trim().length)
+      }
+      let best = 0
+      scores.forEach((score, el) => {
+        if (score > best) {
+          best = score
+          root = el
+        }
+      })
+    }
+    root = root || document.body
+
+    const clone = root.cloneNode(true)
+    const noises =
+      'script, style, noscript, iframe, form, nav, aside, footer, header, button, ' +
+      '[role="navigation"], [role="complementary"], [aria-hidden="true"], [hidden]'
+    for (const el of clone.querySelectorAll(noises)) el.remove()
+
+    // innerText needs the layout, so the clone is attached invisibly
+    const box = document.createElement('div')
+    box.style.cssText = 'position: fixed; left: -99999px; top: 0; width: 800px'
+    box.appendChild(clone)
+    document.body.appendChild(box)
+    const text = clone.innerText.replace(/\n{3,}/g, '\n\n').trim()
+    box.remove()
+
+    const h1 = root.querySelector('h1') || document.querySelector('h1')
+
+    return {
+      title: meta('meta[property="og:title"]') || (h1 ? h1.innerText.trim() : '') || document.title,
+      byline:
+        meta('meta[name="author"]') ||
+        meta('[rel="author"]') ||
+        meta('[itemprop="author"]') ||
+        meta('.byline, .author'),
+      text,
+      html: clone.innerHTML.trim()
+    }
+  },
+
+  dismissCookieBanner(selectors, texts) {
+    const visible = (el) => {
+      const box = el.getBoundingClientRect()
+      const style = window.getComputedStyle(el)
+      return box.width > 0 && box.height > 0 && style.visibility !== 'hidden'
+    }
+
+    // the consent management platforms that have the js api to accept all, each item is [pending, accept]
+    const apis = [
+      [
+        () => window.OneTrust && !window.OneTrust.IsAlertBoxClosed(),
+        () => window.OneTrust.AllowAll()
+      ],
+      [
+        () => window.Didomi && window.Didomi.shouldConsentBeCollected(),
+        () => window.Didomi.setUserAgreeToAll()
+      ],
+      [
+        () => window.Cookiebot && !window.Cookiebot.hasResponse,
+        () => window.Cookiebot.submitCustomConsent(true, true, true)
+      ]
+    ]
+    for (const [pending, accept] of apis) {
+      try {
+        if (pending()) {
+          accept()
+          return true
+        }
+      } catch {
+        // the api may not be ready yet
+      }
+    }
+
+    for (const selector of selectors) {
+      for (const el of document.querySelectorAll(selector)) {
+        if (visible(el)) {
+          el.click()
+          return true
+        }
+      }
+    }
+
+    // only the buttons inside the containers that look like a banner are clicked
+    const banner = /cookie|consent|gdpr|privacy|cmp/i
+    const buttons = document.querySelectorAll(
+      'button, a, [role="button"], input[type="button"], input[type="submit"]'
+    )
+    for (const el of buttons) {
+      const text = (el.innerText || el.value || '').trim().toLowerCase()
+      if (!texts.includes(text) || !visible(el)) continue
+
+      for (let p = el.parentElement; p && p !== document.body; p = p.parentElement) {
+        if (banner.test(p.id + ' ' + p.className + ' ' + (p.getAttribute('aria-label') || ''))) {
+          el.click()
+          return true
+        }
+      }
+    }
+
+    return false
+  },
+
+  detectCaptcha() {
+    const visible = (el) => {
+      const box = el.getBoundingClientRect()
+      return box.width > 0 && box.height > 0 && window.getComputedStyle(el).visibility !== 'hidden'
+    }
+
+    // the key can be in the query or the fragment, such as the "#sitekey=" of hcaptcha
+    const param = (src, key) => {
+      try {
+        const u = new URL(src, location.href)
+        return u.searchParams.get(key) || new URLSearchParams(u.hash.slice(1)).get(key) || ''
+      } catch {
+        return ''
+      }
+    }
+
+    const widget = (type, selector, frame, key) => {
+      const el = Array.from(document.querySelectorAll(selector)).find(visible)
+      if (el) return { type, siteKey: el.dataset.sitekey || '' }
+
+      const iframe = Array.from(document.querySelectorAll(frame)).find(
+        (f) => visible(f) && !f.src.includes('size=invisible')
+      )
+      if (iframe) return { type, siteKey: param(iframe.src, key) }
+    }
+
+    // the interstitial page of the cloudflare browser check
+    if (
+      document.querySelector('#challenge-form, #cf-challenge-running, #challenge-running') ||
+      (window._cf_chl_opt && /^(Just a moment|Attention Required)/.test(document.title))
+    ) {
+      return { type: 'cloudflare', siteKey: '' }
+    }
+
+    return (
+      widget('turnstile', '.cf-turnstile', 'iframe[src*="challenges.cloudflare.com"]', 'sitekey') ||
+      widget(
+        'recaptcha',
+        '.g-recaptcha',
+        'iframe[src*="/recaptcha/api2/"], iframe[src*="/recaptcha/enterprise/"]',
+        'k'
+      ) ||
+      widget('hcaptcha', '.h-captcha', 'iframe[src*="hcaptcha.com"]', 'sitekey') ||
+      null
+    )
+  },
+
+  async storageState() {
+    const dump = (storage) => {
+      const items = {}
+      for (let i = 0; i < storage.length; i++) {
+        const key = storage.key(i)
+        items[key] = storage.getItem(key)
+      }
+      return items
+    }
+
+    const request = (req) =>
+      new Promise((resolve, reject) => {
+        req.onsuccess = () => resolve(req.result)
+        req.onerror = () => reject(req.error)
+      })
+
+    const databases = []
+    for (const info of indexedDB.databases ? await indexedDB.databases() : []) {
+      const db = await request(indexedDB.open(info.name))
+      const stores = []
+      for (const name of db.objectStoreNames) {
+        const store = db.transaction(name, 'readonly').objectStore(name)
+        stores.push({
+          name,
+          keyPath: store.keyPath,
+          autoIncrement: store.autoIncrement,
+          indexes: Array.from(store.indexNames, (n) => {
+            const index = store.index(n)
+            return {
+              name: n,
+              keyPath: index.keyPath,
+              unique: index.unique,
+              multiEntry: index.multiEntry
+            }
+          }),
+          keys: await request(store.getAllKeys()),
+          values: await request(store.getAll())
+        })
+      }
+      db.close()
+      databases.push({ name: info.name, version: info.version, stores })
+    }
+
+    return {
+      origin: location.origin,
+      localStorage: dump(localStorage),
+      sessionStorage: dump(sessionStorage),
+      indexedDB: databases
+    }
+  },
+
+  async setStorageState(state) {
+    for (const [k, v] of Object.entries(state.localStorage || {})) localStorage.setItem(k, v)
+    for (const [k, v] of Object.entries(state.sessionStorage || {})) sessionStorage.setItem(k, v)
+
+    const request = (req) =>
+      new Promise((resolve, reject) => {
+        req.onsuccess = () => resolve(req.result)
+        req.onerror = () => reject(req.error)
+      })
+
+    // A blocked request stays queued until the other connections of the origin are closed, then it completes.
+    // So check the blocking before deleting any database, otherwise it would be deleted later without the restore.
+    // The check tries to upgrade the database and aborts the upgrade, so it changes nothing even when it's queued.
+    const checkBlocked = async (name) => {
+      const info = indexedDB.databases && (await indexedDB.databases()).find((d) => d.name === name)
+      if (!info) return
+
+      const open = indexedDB.open(name, info.version + 1)
+      open.onupgradeneeded = () => open.transaction.abort()
+      await new Promise((resolve, reject) => {
+        open.onsuccess = () => resolve(open.result.close())
+        open.onerror = resolve
+        open.onblocked = () => reject(new Error(`indexedDB "${name}" is blocked by an open connection`))
+      })
+    }
+
+    for (const data of state.indexedDB || []) await checkBlocked(data.name)
+
+    for (const data of state.indexedDB || []) {
+      await request(indexedDB.deleteDatabase(data.name))
+
+      const open = indexedDB.open(data.name, data.version)
+      open.onupgradeneeded = () => {
+        for (const s of data.stores) {
+          const store = open.result.createObjectStore(s.name, {
+            keyPath: s.keyPath,
+            autoIncrement: s.autoIncrement
+          })
+          for (const i of s.indexes) {
+            store.createIndex(i.name, i.keyPath, { unique: i.unique, multiEntry: i.multiEntry })
+          }
+        }
+      }
+      const db = await request(open)
+
+      for (const s of data.stores) {
+        const tx = db.transaction(s.name, 'readwrite')
+        const store = tx.objectStore(s.name)
+        s.values.forEach((v, i) => (s.keyPath === null ? store.put(v, s.keys[i]) : store.put(v)))
+        await new Promise((resolve, reject) => {
+          tx.oncomplete = resolve
+          tx.onerror = () => reject(tx.error)
+        })
+      }
+      db.close()
+    }
+  },
+
+  pageBreaks(pageHeight, selector) {
+    const describe = (el) =>
+      el.localName + (el.id ? '#' + el.id : '') + Array.from(el.classList, (c) => '.' + c).join('')
+
+    const top = (el) => el.getBoundingClientRect().top + scrollY
+    const bottom = (el) => el.getBoundingClientRect().bottom + scrollY
+    const forcedValues = ['page', 'always', 'left', 'right', 'recto', 'verso']
+
+    const forced = []
+    const avoids = []
+    for (const el of document.body.querySelectorAll('*')) {
+      const style = getComputedStyle(el)
+      if (style.display === 'none') continue
+      if (forcedValues.includes(style.breakBefore)) forced.push(top(el))
+      if (forcedValues.includes(style.breakAfter)) forced.push(bottom(el))
+      if (style.breakInside.startsWith('avoid')) avoids.push(el)
+    }
+    forced.sort((a, b) => a - b)
+
+    const height = document.documentElement.scrollHeight
+    const units = Array.from(document.querySelectorAll(selector))
+    const crossing = (list, y) => list.filter((el) => top(el) < y && bottom(el) > y)
+
+    const breaks = []
+    let cur = 0
+    while (cur + pageHeight < height || forced.some((y) => y > cur && y < height)) {
+      let y = cur + pageHeight
+      let isForced = false
+
+      const f = forced.find((v) => v > cur && v <= y)
+      if (f !== undefined) {
+        y = f
+        isForced = true
+      } else {
+        // move the break to the top of the element that avoids being split, if it fits in the page
+        const avoid = crossing(avoids, y)
+          .map(top)
+          .filter((t) => t > cur)
+        if (avoid.length) y = Math.min(...avoid)
+      }
+
+      breaks.push({
+        y,
+        forced: isForced,
+        split: crossing(units, y).map(describe),
+        repeatedHeaders: Array.from(document.querySelectorAll('table'))
+          .filter((t) => {
+            const head = t.tHead
+            return (
+              head &&
+              top(t) < y &&
+              bottom(t) > y &&
+              bottom(head) <= y &&
+              getComputedStyle(head).display === 'table-header-group'
+            )
+          })
+          .map(describe)
+      })
+
+      cur = y
+    }
+
+    return breaks
+  },
+
   resource() {
     return new Promise((resolve, reject) => {
       if (this.complete) {
@@ -396,7 +767,10 @@ const functions = {
       }
 
       s.id = id
-      s.onerror = reject
+      s.onerror = () => {
+        s.remove()
+        reject(new Error('failed to load script: ' + url))
+      }
       document.head.appendChild(s)
     })
   },
@@ -420,7 +794,10 @@ const functions = {
 
       el.id = id
       el.onload = resolve
-      el.onerror = reject
+      el.onerror = () => {
+        el.remove()
+        reject(new Error('failed to load style: ' + url))
+      }
       document.head.appendChild(el)
     })
   },
@@ -563,5 +940,192 @@ const functions = {
     }
     steps.reverse()
     return (steps.length && steps[0].optimized ? '' : '/') + steps.join('/')
+  },
+
+  serialize() {
+    const parents = []
+
+    const walk = (v) => {
+      switch (typeof v) {
+        case 'bigint':
+        case 'symbol':
+          return v.toString()
+        case 'function':
+          return undefined
+        case 'number':
+          return isFinite(v) ? v : String(v)
+        case 'object':
+          break
+        default:
+          return v
+      }
+
+      if (v === null) return null
+      if (parents.includes(v)) return '[Circular]'
+      if (v instanceof Node) {
+        return v.nodeName.toLowerCase() + (v.id ? '#' + v.id : '')
+      }
+      if (v instanceof Date) return isNaN(v) ? null : v.toISOString()
+      if (v instanceof RegExp) return v.toString()
+      if (v instanceof Error) {
+        return { name: v.name, message: v.message, stack: v.stack }
+      }
+
+      parents.push(v)
+
+      let res
+      if (v instanceof Map) {
+        res = {}
+        v.forEach((val, key) => {
+          res[String(key)] = walk(val)
+        })
+      } else if (v instanceof Set || Array.isArray(v) || ArrayBuffer.isView(v)) {
+        res = Array.from(v, (val) => {
+          const r = walk(val)
+          return r === undefined ? null : r
+        })
+      } else {
+        res = {}
+        for (const key of Object.keys(v)) {
+          const r = walk(v[key])
+          if (r !== undefined) res[key] = r
+        }
+      }
+
+      parents.pop()
+
+      return res
+    }
+
+    return walk(this)
+  },
+
+  observeMutations(name, opts) {
+    const describe = (n) => n.nodeName.toLowerCase() + (n.id ? '#' + n.id : '')
+
+    const observer = new MutationObserver((list) => {
+      window[name](
+        list.map((r) => ({
+          type: r.type,
+          target: describe(r.target),
+          attributeName: r.attributeName || undefined,
+          oldValue: r.oldValue === null ? undefined : r.oldValue,
+          addedNodes: r.addedNodes.length,
+          removedNodes: r.removedNodes.length
+        }))
+      )
+    })
+
+    observer.observe(
+      this,
+      opts || { attributes: true, childList: true, characterData: true, subtree: true }
+    )
+
+    return observer
+  },
+
+  observeLongTasks(name, threshold) {
+    if (window[name + '_observer']) return
+    if (!PerformanceObserver.supportedEntryTypes.includes('longtask')) return
+
+    const observer = new PerformanceObserver((list) => {
+      if (!window[name]) return
+
+      const tasks = list
+        .getEntries()
+        .filter((e) => e.duration >= threshold)
+        .map((e) => ({
+          name: e.name,
+          startTime: e.startTime,
+          duration: e.duration,
+          url: location.href,
+          container: (e.attribution[0] && e.attribution[0].containerSrc) || undefined
+        }))
+
+      if (tasks.length) window[name](tasks)
+    })
+
+    observer.observe({ type: 'longtask' })
+    window[name + '_observer'] = observer
+  },
+
+  recordActions(name) {
+    if (window[name + '_recording']) return
+    window[name + '_recording'] = true
+
+    const quote = (s) => JSON.stringify(s)
+
+    const selector = (el) => {
+      if (el.id) return '#' + CSS.escape(el.id)
+
+      for (const attr of ['data-testid', 'data-test', 'name', 'aria-label']) {
+        const v = el.getAttribute(attr)
+        if (v) return el.localName + '[' + attr + '=' + quote(v) + ']'
+      }
+
+      const path = []
+      for (let e = el; e && e !== document.documentElement; e = e.parentElement) {
+        if (e.id) {
+          path.unshift('#' + CSS.escape(e.id))
+          break
+        }
+        let step = e.localName
+        const same = e.parentElement
+          ? Array.from(e.parentElement.children).filter((c) => c.localName === e.localName)
+          : []
+        if (same.length > 1) step += ':nth-of-type(' + (same.indexOf(e) + 1) + ')'
+        path.unshift(step)
+      }
+      return path.join(' > ')
+    }
+
+    const record = (action) => window[name](action)
+
+    // the last recorded value of each input, pressing Enter triggers both keydown and change
+    const values = new WeakMap()
+    const recordInput = (el) => {
+      if (values.get(el) === el.value) return
+      values.set(el, el.value)
+      record({ type: 'input', selector: selector(el), value: el.value })
+    }
+
+    const isText = (el) =>
+      el.localName === 'textarea' ||
+      (el.localName === 'input' &&
+        !['checkbox', 'radio', 'button', 'submit', 'reset', 'file', 'image'].includes(el.type))
+
+    document.addEventListener(
+      'click',
+      (e) => {
+        if (!e.isTrusted || !(e.target instanceof Element) || isText(e.target)) return
+        record({ type: 'click', selector: selector(e.target) })
+      },
+      true
+    )
+
+    document.addEventListener(
+      'change',
+      (e) => {
+        const el = e.target
+        if (!e.isTrusted || !(el instanceof Element)) return
+        if (el.localName === 'select') {
+          const opt = el.selectedOptions[0]
+          record({ type: 'select', selector: selector(el), value: opt ? opt.text : '' })
+        } else if (isText(el)) {
+          recordInput(el)
+        }
+      },
+      true
+    )
+
+    document.addEventListener(
+      'keydown',
+      (e) => {
+        if (!e.isTrusted || e.key !== 'Enter' || !(e.target instanceof Element)) return
+        if (isText(e.target)) recordInput(e.target)
+        record({ type: 'key', selector: selector(e.target), value: e.key })
+      },
+      true
+    )
   }
 }
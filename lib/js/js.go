@@ -1,12 +1,16 @@
 package js
 
+import (
+	_ "embed" // for the helper.js
+	"regexp"
+)
+
 // Function definition
 type Function struct {
 	// Name must be unique and not conflict with the function names in "helper.js"
 	Name string
 
-	// Definition holds the code of a js function from "helper.js",
-	// the js code is compressed by uglify-js.
+	// Definition holds the code of a js function from "helper.js"
 	Definition string
 
 	// Dependencies will be preloaded and assigned to the global js object "functions"
@@ -19,3 +23,41 @@ var Functions = &Function{
 	Definition:   "() => ({})",
 	Dependencies: nil,
 }
+
+//go:embed helper.js
+var helperJS string
+
+var definitions = func() map[string]string {
+	m := map[string]string{}
+	for _, fn := range Parse(helperJS) {
+		m[fn.Name] = fn.Definition
+	}
+	return m
+}()
+
+// definition of the function in the embedded "helper.js"
+func definition(name string) string {
+	return definitions[name]
+}
+
+// the methods of the "functions" object, the body of a method is indented deeper than its head and closing brace
+var regMethod = regexp.MustCompile(`(?ms)^  (async )?(\w+)(\(.*?^  })`)
+
+// Parse the methods of the "functions" object in the code of "helper.js" in the order they appear.
+// The Dependencies of the returned functions are not resolved.
+func Parse(code string) []*Function {
+	list := []*Function{}
+	for _, m := range regMethod.FindAllStringSubmatch(code, -1) {
+		list = append(list, &Function{
+			Name:       m[2],
+			Definition: m[1] + "function" + m[3],
+		})
+	}
+	return list
+}
+
+// Builtin returns true if the name is used by a function in "helper.js"
+func Builtin(name string) bool {
+	_, has := definitions[name]
+	return has || name == Functions.Name
+}
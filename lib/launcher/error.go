@@ -1,6 +1,37 @@
 package launcher
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrAlreadyLaunched is an error that indicates the launcher has already been launched.
 var ErrAlreadyLaunched = errors.New("already launched")
+
+// ErrMissingSharedLib is an error that indicates the browser failed to launch because
+// the OS lacks a shared library it depends on, it usually happens in a fresh docker image.
+type ErrMissingSharedLib struct {
+	// Lib is the name of the missing library, such as "libgbm.so.1"
+	Lib string
+
+	// Output of the browser
+	Output string
+}
+
+func (e *ErrMissingSharedLib) Error() string {
+	return "[launcher] Failed to launch the browser, the doc might help https://go-rod.github.io/#/compatibility?id=os: " +
+		e.Output + "\n" +
+		"[launcher] Missing shared library " + e.Lib + ", to install the dependencies of the browser:\n" +
+		"  Debian/Ubuntu: apt-get install -y libnss3 libxss1 libasound2 libxtst6 libgtk-3-0 libgbm1\n" +
+		"  Alpine: apk add chromium, then use launcher.LookPath to find the bin"
+}
+
+// ErrSelenium is an error returned by the Selenium Grid, the Code is the WebDriver error code, such as "session not created"
+type ErrSelenium struct {
+	Code    string
+	Message string
+}
+
+func (e *ErrSelenium) Error() string {
+	return fmt.Sprintf("selenium error: %s: %s", e.Code, e.Message)
+}
@@ -15,18 +15,28 @@ const (
 	// Headless mode. Whether to run browser in headless mode. A mode without visible UI.
 	Headless Flag = "headless"
 
+	// EnableBeginFrameControl flag. Whether the frames of the pages can be issued by HeadlessExperimental.beginFrame,
+	// it only works in the old headless mode.
+	EnableBeginFrameControl Flag = "enable-begin-frame-control"
+
 	// App flag
 	App Flag = "app"
 
 	// RemoteDebuggingPort flag
 	RemoteDebuggingPort Flag = "remote-debugging-port"
 
+	// RemoteDebuggingPipe flag
+	RemoteDebuggingPipe Flag = "remote-debugging-pipe"
+
 	// NoSandbox flag
 	NoSandbox Flag = "no-sandbox"
 
 	// ProxyServer flag
 	ProxyServer Flag = "proxy-server"
 
+	// HostResolverRules flag, such as "MAP example.com 127.0.0.1"
+	HostResolverRules Flag = "host-resolver-rules"
+
 	// WorkingDir flag
 	WorkingDir Flag = "rod-working-dir"
 
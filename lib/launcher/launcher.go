@@ -14,7 +14,9 @@ import (
 	"sort"
 	"strings"
 	"sync/atomic"
+	"time"
 
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/launcher/flags"
 	"github.com/go-rod/rod/lib/utils"
@@ -152,6 +154,17 @@ func NewAppMode(u string) *Launcher {
 	return l
 }
 
+// NewContainerMode is a preset tuned for docker and CI environments.
+// It disables the sandbox and the GPU which are usually unavailable in a container,
+// and disables the font hinting to make the rendering stable across machines.
+func NewContainerMode() *Launcher {
+	return New().
+		Headless(true).
+		NoSandbox(true).
+		Set("disable-gpu").
+		Set("font-render-hinting", "none")
+}
+
 // Context sets the context
 func (l *Launcher) Context(ctx context.Context) *Launcher {
 	ctx, cancel := context.WithCancel(ctx)
@@ -226,6 +239,26 @@ func (l *Launcher) Headless(enable bool) *Launcher {
 	return l.Delete(flags.Headless)
 }
 
+// HeadlessNew switch. Whether to run browser in the new headless mode, which shares the code of the headful browser,
+// so the behaviors like downloads, PDF, and window bounds are the same as the headful one. It requires Chrome 112+,
+// since Chrome 132 the old mode is removed and the "--headless" flag means the new mode.
+// Rod doesn't adjust its behaviors for either mode, use Browser.HeadlessMode to check the active one.
+func (l *Launcher) HeadlessNew(enable bool) *Launcher {
+	if enable {
+		return l.Set(flags.Headless, "new")
+	}
+	return l.Delete(flags.Headless)
+}
+
+// BeginFrameControl switch. Whether the pages created with the EnableBeginFrameControl option of TargetCreateTarget
+// only render when a frame is issued by HeadlessExperimental.beginFrame, it requires the old headless mode.
+func (l *Launcher) BeginFrameControl(enable bool) *Launcher {
+	if enable {
+		return l.Set(flags.EnableBeginFrameControl)
+	}
+	return l.Delete(flags.EnableBeginFrameControl)
+}
+
 // NoSandbox switch. Whether to run browser in no-sandbox mode.
 // Linux users may face "running as root without --no-sandbox is not supported" in some Linux/Chrome combinations. This function helps switch mode easily.
 // Be aware disabling sandbox is not trivial. Use at your own risk.
@@ -312,6 +345,12 @@ func (l *Launcher) Proxy(host string) *Launcher {
 	return l.Set(flags.ProxyServer, host)
 }
 
+// MapHost makes the browser resolve the host to the ip without touching the system hosts file.
+// The host can have wildcards, such as "*.example.com". It can be called multiple times to map different hosts.
+func (l *Launcher) MapHost(host, ip string) *Launcher {
+	return l.Append(flags.HostResolverRules, fmt.Sprintf("MAP %s %s", host, ip))
+}
+
 // WorkingDir to launch the browser process.
 func (l *Launcher) WorkingDir(path string) *Launcher {
 	return l.Set(flags.WorkingDir, path)
@@ -439,6 +478,77 @@ func (l *Launcher) Launch() (string, error) {
 	return ResolveURL(u)
 }
 
+// MustLaunchPipe is similar to [Launcher.LaunchPipe]
+func (l *Launcher) MustLaunchPipe() *cdp.Pipe {
+	p, err := l.LaunchPipe()
+	utils.E(err)
+	return p
+}
+
+// LaunchPipe is similar to [Launcher.Launch], but it uses the "--remote-debugging-pipe" instead of
+// a debugging port to communicate with the browser, so no port will be exposed. Use it like:
+//
+//	rod.New().Client(cdp.New().Start(launcher.New().MustLaunchPipe())).MustConnect()
+//
+// Leakless is not used, because the browser will exit by itself when the pipe is closed.
+// It's not supported on Windows.
+func (l *Launcher) LaunchPipe() (*cdp.Pipe, error) {
+	if l.hasLaunched() {
+		return nil, ErrAlreadyLaunched
+	}
+
+	defer l.ctxCancel()
+
+	bin, err := l.getBin()
+	if err != nil {
+		return nil, err
+	}
+
+	l.Delete(flags.RemoteDebuggingPort).Set(flags.RemoteDebuggingPipe)
+
+	// the browser reads from fd 3 and writes to fd 4
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		_ = inR.Close()
+		_ = inW.Close()
+		return nil, err
+	}
+	defer func() {
+		_ = inR.Close()
+		_ = outW.Close()
+	}()
+
+	cmd := exec.Command(bin, l.FormatArgs()...)
+	l.setupCmd(cmd)
+	cmd.ExtraFiles = []*os.File{inR, outW}
+
+	// no control url will be printed, the url parser would buffer the whole output of the browser
+	cmd.Stdout = l.logger
+	cmd.Stderr = l.logger
+
+	err = cmd.Start()
+	if err != nil {
+		_ = inW.Close()
+		_ = outR.Close()
+		return nil, err
+	}
+
+	l.pid = cmd.Process.Pid
+
+	go func() {
+		_ = cmd.Wait()
+		_ = inW.Close()
+		_ = outR.Close()
+		close(l.exit)
+	}()
+
+	return cdp.NewPipe(inW, outR), nil
+}
+
 func (l *Launcher) hasLaunched() bool {
 	return !atomic.CompareAndSwapInt32(&l.isLaunched, 0, 1)
 }
@@ -497,6 +607,25 @@ func (l *Launcher) Kill() {
 	}
 }
 
+// WaitOrKill waits for the browser process to exit, if it doesn't exit before the timeout it will be killed.
+// It returns true if the process exited by itself. Use it after the browser is closed gracefully to make sure
+// no process is left behind, such as:
+//
+//	_ = browser.Close()
+//	l.WaitOrKill(5 * time.Second)
+func (l *Launcher) WaitOrKill(timeout time.Duration) (exited bool) {
+	t := time.NewTimer(timeout)
+	defer t.Stop()
+
+	select {
+	case <-l.exit:
+		return true
+	case <-t.C:
+		l.Kill()
+		return false
+	}
+}
+
 // Cleanup wait until the Browser exits and remove UserDataDir
 func (l *Launcher) Cleanup() {
 	<-l.exit
@@ -15,7 +15,9 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/launcher"
 	"github.com/go-rod/rod/lib/launcher/flags"
@@ -162,6 +164,46 @@ func TestAppMode(t *testing.T) {
 	g.Eq(l.Get(flags.App), "http://example.com")
 }
 
+func TestContainerMode(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.NewContainerMode()
+
+	g.True(l.Has(flags.NoSandbox))
+	g.True(l.Has(flags.Headless))
+	g.True(l.Has("disable-gpu"))
+	g.Eq(l.Get("font-render-hinting"), "none")
+}
+
+func TestHeadlessNew(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().HeadlessNew(true)
+	g.Eq(l.Get(flags.Headless), "new")
+	g.Has(l.FormatArgs(), "--headless=new")
+
+	l.HeadlessNew(false)
+	g.False(l.Has(flags.Headless))
+}
+
+func TestBeginFrameControl(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().BeginFrameControl(true)
+	g.Has(l.FormatArgs(), "--enable-begin-frame-control")
+
+	l.BeginFrameControl(false)
+	g.False(l.Has(flags.EnableBeginFrameControl))
+}
+
+func TestMapHost(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New().MapHost("example.com", "127.0.0.1").MapHost("*.test.com", "10.0.0.1")
+
+	g.Has(l.FormatArgs(), "--host-resolver-rules=MAP example.com 127.0.0.1,MAP *.test.com 10.0.0.1")
+}
+
 func TestGetWebSocketDebuggerURLErr(t *testing.T) {
 	g := setup(t)
 
@@ -324,3 +366,39 @@ func TestLaunchMultiTimes(t *testing.T) {
 	_, e = l.Launch()
 	g.Eq(e, launcher.ErrAlreadyLaunched)
 }
+
+func TestLaunchPipe(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	defer l.Kill()
+
+	client := cdp.New().Start(l.MustLaunchPipe())
+	g.False(l.Has(flags.RemoteDebuggingPort))
+
+	res, err := client.Call(g.Context(), "", "Browser.getVersion", nil)
+	g.E(err)
+	g.Has(string(res), "protocolVersion")
+
+	_, err = l.LaunchPipe()
+	g.Eq(err, launcher.ErrAlreadyLaunched)
+}
+
+func TestWaitOrKill(t *testing.T) {
+	g := setup(t)
+
+	l := launcher.New()
+	client := cdp.New().Start(cdp.MustConnectWS(l.MustLaunch()))
+	go func() {
+		for range client.Event() {
+			utils.Noop()
+		}
+	}()
+	_, _ = client.Call(g.Context(), "", "Browser.close", nil)
+	g.True(l.WaitOrKill(10 * time.Second))
+
+	l = launcher.New()
+	l.MustLaunch()
+	g.False(l.WaitOrKill(0))
+	l.Cleanup()
+}
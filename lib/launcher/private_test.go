@@ -149,7 +149,10 @@ func TestURLParserErr(t *testing.T) {
 	g.Eq(u.Err().Error(), "[launcher] Failed to get the debug url: error")
 
 	u.Buffer = "/tmp/rod/chromium-818858/chrome: error while loading shared libraries: libgobject-2.0.so.0: cannot open shared object file: No such file or directory"
-	g.Eq(u.Err().Error(), "[launcher] Failed to launch the browser, the doc might help https://go-rod.github.io/#/compatibility?id=os: /tmp/rod/chromium-818858/chrome: error while loading shared libraries: libgobject-2.0.so.0: cannot open shared object file: No such file or directory")
+	err := u.Err()
+	g.Has(err.Error(), "[launcher] Failed to launch the browser, the doc might help https://go-rod.github.io/#/compatibility?id=os: /tmp/rod/chromium-818858/chrome: error while loading shared libraries: libgobject-2.0.so.0: cannot open shared object file: No such file or directory")
+	g.Has(err.Error(), "apt-get install")
+	g.Eq(err.(*ErrMissingSharedLib).Lib, "libgobject-2.0.so.0")
 }
 
 func TestTestOpen(_ *testing.T) {
@@ -0,0 +1,124 @@
+package launcher
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/goccy/go-json"
+
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// SeleniumSession is a browser session created on a Selenium 4 Grid. The Grid exposes the CDP of the browser
+// via websocket, so rod can control it the same as a local browser:
+//
+//	s := launcher.MustNewSeleniumSession("http://grid:4444", nil)
+//	defer s.Close()
+//	browser := rod.New().ControlURL(s.ControlURL).MustConnect()
+//
+// Or set the "selenium" option of the defaults package to let rod create the session automatically.
+type SeleniumSession struct {
+	// ID of the WebDriver session
+	ID string
+
+	// ControlURL is the websocket url of the CDP, the "se:cdp" capability of the session
+	ControlURL string
+
+	// Capabilities returned by the Grid
+	Capabilities map[string]interface{}
+
+	ctx  context.Context
+	grid string
+}
+
+// MustNewSeleniumSession is similar to NewSeleniumSession
+func MustNewSeleniumSession(gridURL string, capabilities map[string]interface{}) *SeleniumSession {
+	s, err := NewSeleniumSession(context.Background(), gridURL, capabilities)
+	utils.E(err)
+	return s
+}
+
+// NewSeleniumSession creates a new session on the Selenium Grid at gridURL, such as "http://127.0.0.1:4444".
+// The capabilities are used as the "alwaysMatch" of the new session request,
+// if it's nil {"browserName": "chrome"} will be used.
+func NewSeleniumSession(ctx context.Context, gridURL string, capabilities map[string]interface{}) (*SeleniumSession, error) {
+	if capabilities == nil {
+		capabilities = map[string]interface{}{"browserName": "chrome"}
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"capabilities": map[string]interface{}{"alwaysMatch": capabilities},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	grid := strings.TrimSuffix(gridURL, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, grid+"/session", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var res struct {
+		Value struct {
+			SessionID    string                 `json:"sessionId"`
+			Capabilities map[string]interface{} `json:"capabilities"`
+			Error        string                 `json:"error"`
+			Message      string                 `json:"message"`
+		} `json:"value"`
+	}
+	err = seleniumDo(req, &res)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Value.Error != "" {
+		return nil, &ErrSelenium{res.Value.Error, res.Value.Message}
+	}
+
+	s := &SeleniumSession{
+		ID:           res.Value.SessionID,
+		Capabilities: res.Value.Capabilities,
+		ctx:          ctx,
+		grid:         grid,
+	}
+
+	s.ControlURL, _ = res.Value.Capabilities["se:cdp"].(string)
+	if s.ControlURL == "" {
+		_ = s.Close()
+		return nil, &ErrSelenium{"no cdp", "the session doesn't expose the se:cdp capability, the browser may not support CDP"}
+	}
+
+	return s, nil
+}
+
+// Close deletes the session on the Grid, the browser will be closed by the Grid
+func (s *SeleniumSession) Close() error {
+	req, err := http.NewRequestWithContext(s.ctx, http.MethodDelete, s.grid+"/session/"+url.PathEscape(s.ID), nil)
+	if err != nil {
+		return err
+	}
+	return seleniumDo(req, nil)
+}
+
+func seleniumDo(req *http.Request, v interface{}) error {
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	if v == nil {
+		if res.StatusCode >= http.StatusBadRequest {
+			return &ErrSelenium{res.Status, "failed to delete the session"}
+		}
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(v)
+}
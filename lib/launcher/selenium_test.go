@@ -0,0 +1,52 @@
+package launcher_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-rod/rod/lib/launcher"
+	"github.com/ysmood/gson"
+)
+
+func TestSeleniumSession(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+
+	deleted := ""
+	s.Mux.HandleFunc("/session", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		caps := gson.New(body).Get("capabilities.alwaysMatch")
+
+		switch caps.Get("browserName").Str() {
+		case "chrome":
+			g.E(w.Write([]byte(`{"value":{"sessionId":"123","capabilities":{"se:cdp":"ws://grid/session/123/se/cdp"}}}`)))
+		case "firefox":
+			g.E(w.Write([]byte(`{"value":{"sessionId":"456","capabilities":{}}}`)))
+		default:
+			g.E(w.Write([]byte(`{"value":{"error":"session not created","message":"no match"}}`)))
+		}
+	})
+	s.Mux.HandleFunc("/session/", func(w http.ResponseWriter, r *http.Request) {
+		g.Eq(r.Method, http.MethodDelete)
+		deleted = r.URL.Path
+	})
+
+	session := launcher.MustNewSeleniumSession(s.URL()+"/", nil)
+	g.Eq(session.ID, "123")
+	g.Eq(session.ControlURL, "ws://grid/session/123/se/cdp")
+	g.E(session.Close())
+	g.Eq(deleted, "/session/123")
+
+	_, err := launcher.NewSeleniumSession(g.Context(), s.URL(), map[string]interface{}{"browserName": "firefox"})
+	g.Has(err.Error(), "selenium error: no cdp")
+	g.Eq(deleted, "/session/456")
+
+	_, err = launcher.NewSeleniumSession(g.Context(), s.URL(), map[string]interface{}{"browserName": "x"})
+	g.Eq(err.Error(), "selenium error: session not created: no match")
+
+	g.Panic(func() {
+		launcher.MustNewSeleniumSession("://", nil)
+	})
+}
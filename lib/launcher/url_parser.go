@@ -75,15 +75,15 @@ func (r *URLParser) Err() error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	msg := "[launcher] Failed to get the debug url: "
-
-	if strings.Contains(r.Buffer, "error while loading shared libraries") {
-		msg = "[launcher] Failed to launch the browser, the doc might help https://go-rod.github.io/#/compatibility?id=os: "
+	if m := regMissingLib.FindStringSubmatch(r.Buffer); m != nil {
+		return &ErrMissingSharedLib{Lib: m[1], Output: r.Buffer}
 	}
 
-	return errors.New(msg + r.Buffer)
+	return errors.New("[launcher] Failed to get the debug url: " + r.Buffer)
 }
 
+var regMissingLib = regexp.MustCompile(`error while loading shared libraries: ([^:]+)`)
+
 // MustResolveURL is similar to ResolveURL
 func MustResolveURL(u string) string {
 	u, err := ResolveURL(u)
@@ -0,0 +1,273 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+)
+
+// ErrTooDeep is returned when the arrays or dictionaries of the file are nested too deep
+var ErrTooDeep = errors.New("pdf: values are nested too deep")
+
+// the max nesting depth of the arrays and dictionaries
+const maxDepth = 64
+
+// the types of the pdf values
+type (
+	name    string
+	keyword string
+	dict    map[string]interface{}
+	array   []interface{}
+	ref     struct{ num, gen int }
+)
+
+// parser reads the pdf values from the data, the strings are returned as []byte
+type parser struct {
+	data  []byte
+	pos   int
+	depth int
+	err   error // once it's set, the parser stops at the end of the data
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (p *parser) eof() bool {
+	return p.pos >= len(p.data)
+}
+
+func (p *parser) skipSpaces() {
+	for !p.eof() {
+		c := p.data[p.pos]
+		if c == '%' {
+			for !p.eof() && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		if !isSpace(c) {
+			return
+		}
+		p.pos++
+	}
+}
+
+// value returns nil when there's no more value
+func (p *parser) value() interface{} {
+	p.skipSpaces()
+	if p.eof() {
+		return nil
+	}
+
+	switch c := p.data[p.pos]; {
+	case c == '/':
+		return p.name()
+	case c == '(':
+		return p.literal()
+	case c == '<' && p.pos+1 < len(p.data) && p.data[p.pos+1] == '<':
+		if !p.nest() {
+			return nil
+		}
+		defer p.unnest()
+		return p.dict()
+	case c == '<':
+		return p.hex()
+	case c == '[':
+		if !p.nest() {
+			return nil
+		}
+		defer p.unnest()
+		p.pos++
+		arr := array{}
+		for {
+			p.skipSpaces()
+			if p.eof() {
+				return arr
+			}
+			if p.data[p.pos] == ']' {
+				p.pos++
+				return arr
+			}
+			arr = append(arr, p.value())
+		}
+	}
+
+	word := p.word()
+	if word == "" { // skip the unknown char
+		p.pos++
+		return keyword(p.data[p.pos-1 : p.pos])
+	}
+	if n, err := strconv.ParseFloat(word, 64); err == nil {
+		return p.numberOrRef(n)
+	}
+	return keyword(word)
+}
+
+// nest returns false and stops the parser if the max depth is exceeded
+func (p *parser) nest() bool {
+	p.depth++
+	if p.depth > maxDepth {
+		p.err = ErrTooDeep
+		p.pos = len(p.data)
+		return false
+	}
+	return true
+}
+
+func (p *parser) unnest() {
+	p.depth--
+}
+
+func (p *parser) word() string {
+	start := p.pos
+	for !p.eof() && !isSpace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+// the "1 0 R" is a reference
+func (p *parser) numberOrRef(n float64) interface{} {
+	pos := p.pos
+
+	p.skipSpaces()
+	gen, err := strconv.Atoi(p.word())
+	if err == nil && n == float64(int(n)) {
+		p.skipSpaces()
+		if p.word() == "R" {
+			return ref{int(n), gen}
+		}
+	}
+
+	p.pos = pos
+	return n
+}
+
+func (p *parser) name() name {
+	p.pos++
+	raw := p.word()
+
+	b := []byte{}
+	for i := 0; i < len(raw); i++ {
+		if raw[i] == '#' && i+2 < len(raw) {
+			if v, err := strconv.ParseUint(raw[i+1:i+3], 16, 8); err == nil {
+				b = append(b, byte(v))
+				i += 2
+				continue
+			}
+		}
+		b = append(b, raw[i])
+	}
+	return name(b)
+}
+
+func (p *parser) dict() dict {
+	p.pos += 2
+	d := dict{}
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			return d
+		}
+		if bytes.HasPrefix(p.data[p.pos:], []byte(">>")) {
+			p.pos += 2
+			return d
+		}
+
+		key, ok := p.value().(name)
+		if !ok {
+			continue
+		}
+		d[string(key)] = p.value()
+	}
+}
+
+func (p *parser) hex() []byte {
+	p.pos++
+	digits := []byte{}
+	for !p.eof() && p.data[p.pos] != '>' {
+		if c := p.data[p.pos]; !isSpace(c) {
+			digits = append(digits, c)
+		}
+		p.pos++
+	}
+	p.pos++
+
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+
+	b := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		v, _ := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		b = append(b, byte(v))
+	}
+	return b
+}
+
+var escapes = map[byte]byte{'n': '\n', 'r': '\r', 't': '\t', 'b': '\b', 'f': '\f'}
+
+func (p *parser) literal() []byte {
+	p.pos++
+	b := []byte{}
+	depth := 0
+
+	for !p.eof() {
+		c := p.data[p.pos]
+		p.pos++
+
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return b
+			}
+			depth--
+		case '\\':
+			if p.eof() {
+				return b
+			}
+			c = p.data[p.pos]
+			p.pos++
+
+			if e, has := escapes[c]; has {
+				b = append(b, e)
+				continue
+			}
+
+			switch {
+			case c == '\r' || c == '\n': // line continuation
+				if c == '\r' && !p.eof() && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+				continue
+			case c >= '0' && c <= '7':
+				v := int(c - '0')
+				for i := 0; i < 2 && !p.eof() && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+					v = v*8 + int(p.data[p.pos]-'0')
+					p.pos++
+				}
+				b = append(b, byte(v))
+				continue
+			}
+		}
+
+		b = append(b, c)
+	}
+
+	return b
+}
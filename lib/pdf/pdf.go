@@ -0,0 +1,625 @@
+// Package pdf extracts the text from the PDF files, such as the ones generated by Page.PDF of rod or downloaded
+// by the browser. It's useful to assert things like "the invoice contains the order number". It only supports
+// the common features of the PDF files generated by the browsers, such as the Flate streams, the object streams,
+// and the ToUnicode maps of the fonts. The encrypted files are not supported.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ErrNoPages is returned when the page tree of the file can't be found
+var ErrNoPages = errors.New("pdf: no pages found")
+
+// ErrEncrypted is returned when the file is encrypted
+var ErrEncrypted = errors.New("pdf: encrypted file is not supported")
+
+// Text of all the pages, the pages are separated by "\f"
+func Text(r io.Reader) (string, error) {
+	pages, err := Pages(r)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(pages, "\f"), nil
+}
+
+// Pages returns the text of each page, the lines of a page are separated by "\n"
+func Pages(r io.Reader) ([]string, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := parse(data)
+	if doc.err != nil {
+		return nil, doc.err
+	}
+	if doc.encrypted {
+		return nil, ErrEncrypted
+	}
+
+	pages := doc.pages()
+	if len(pages) == 0 {
+		return nil, ErrNoPages
+	}
+
+	list := []string{}
+	for _, page := range pages {
+		t := &textWriter{doc: doc, cmaps: map[int]*cmap{}}
+		for _, content := range doc.contents(page) {
+			t.run(content, doc.resources(page), 0)
+		}
+		if doc.err != nil {
+			return nil, doc.err
+		}
+		list = append(list, t.String())
+	}
+	return list, nil
+}
+
+type object struct {
+	value  interface{}
+	stream []byte // the raw stream data
+}
+
+type document struct {
+	objects   map[int]*object
+	encrypted bool
+	err       error // the first error of the parsers
+}
+
+var regObj = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+var regTrailer = regexp.MustCompile(`\btrailer\b`)
+
+// check records the error of the parser
+func (doc *document) check(p *parser) {
+	if doc.err == nil {
+		doc.err = p.err
+	}
+}
+
+func parse(data []byte) *document {
+	doc := &document{objects: map[int]*object{}}
+
+	pos := 0
+	for {
+		loc := regObj.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			break
+		}
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+
+		p := &parser{data: data, pos: pos + loc[1]}
+		obj := &object{value: p.value()}
+
+		p.skipSpaces()
+		if bytes.HasPrefix(data[p.pos:], []byte("stream")) {
+			obj.stream, p.pos = readStream(data, p.pos+len("stream"), obj.value)
+		}
+
+		doc.objects[num] = obj
+		pos = p.pos
+		doc.check(p)
+	}
+
+	// the Encrypt entry is in the trailer dict, or in the dict of the xref stream since pdf 1.5
+	for _, loc := range regTrailer.FindAllIndex(data, -1) {
+		p := &parser{data: data, pos: loc[1]}
+		if d, ok := p.value().(dict); ok && d["Encrypt"] != nil {
+			doc.encrypted = true
+		}
+	}
+	for _, obj := range doc.objects {
+		if d, ok := obj.value.(dict); ok && d["Type"] == name("XRef") && d["Encrypt"] != nil {
+			doc.encrypted = true
+		}
+	}
+
+	// the objects inside the object streams
+	for _, obj := range doc.objects {
+		if d, ok := obj.value.(dict); ok && d["Type"] == name("ObjStm") {
+			doc.parseObjStm(d, doc.decode(obj))
+		}
+	}
+
+	return doc
+}
+
+// readStream returns the stream data and the position after it
+func readStream(data []byte, start int, header interface{}) ([]byte, int) {
+	if bytes.HasPrefix(data[start:], []byte("\r\n")) {
+		start += 2
+	} else if start < len(data) && (data[start] == '\n' || data[start] == '\r') {
+		start++
+	}
+
+	if d, ok := header.(dict); ok {
+		if l, ok := d["Length"].(float64); ok && l >= 0 && l <= float64(len(data)-start) {
+			end := start + int(l)
+			if bytes.HasPrefix(bytes.TrimLeft(data[end:], "\r\n \t"), []byte("endstream")) {
+				return data[start:end], end
+			}
+		}
+	}
+
+	i := bytes.Index(data[start:], []byte("endstream"))
+	if i < 0 {
+		return data[start:], len(data)
+	}
+	return bytes.TrimRight(data[start:start+i], "\r\n"), start + i
+}
+
+func (doc *document) parseObjStm(d dict, data []byte) {
+	n, _ := doc.resolve(d["N"]).(float64)
+	first, _ := doc.resolve(d["First"]).(float64)
+
+	p := &parser{data: data}
+	offsets := []int{}
+	nums := []int{}
+	for i := 0; i < int(n); i++ {
+		num, ok1 := p.value().(float64)
+		offset, ok2 := p.value().(float64)
+		if !ok1 || !ok2 {
+			break
+		}
+		nums = append(nums, int(num))
+		offsets = append(offsets, int(offset))
+	}
+
+	if first < 0 || first > float64(len(data)) {
+		return
+	}
+
+	for i, num := range nums {
+		if _, has := doc.objects[num]; has {
+			continue
+		}
+		if offsets[i] < 0 || offsets[i] > len(data)-int(first) {
+			continue
+		}
+		p := &parser{data: data, pos: int(first) + offsets[i]}
+		doc.objects[num] = &object{value: p.value()}
+		doc.check(p)
+	}
+}
+
+func (doc *document) resolve(v interface{}) interface{} {
+	for i := 0; i < 32; i++ {
+		r, ok := v.(ref)
+		if !ok {
+			return v
+		}
+		obj, has := doc.objects[r.num]
+		if !has {
+			return nil
+		}
+		v = obj.value
+	}
+	return nil
+}
+
+func (doc *document) dict(v interface{}) dict {
+	d, _ := doc.resolve(v).(dict)
+	return d
+}
+
+// decode the stream of the obj, only the FlateDecode filter is supported
+func (doc *document) decode(obj *object) []byte {
+	d, _ := obj.value.(dict)
+
+	filters := []interface{}{}
+	switch f := doc.resolve(d["Filter"]).(type) {
+	case name:
+		filters = append(filters, f)
+	case array:
+		filters = f
+	}
+
+	data := obj.stream
+	for _, f := range filters {
+		switch doc.resolve(f) {
+		case name("FlateDecode"), name("Fl"):
+			r, err := zlib.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil
+			}
+			// the streams may be truncated, so the error is ignored
+			data, _ = ioutil.ReadAll(r)
+		default:
+			return nil
+		}
+	}
+	return data
+}
+
+func (doc *document) stream(v interface{}) []byte {
+	if r, ok := v.(ref); ok {
+		if obj, has := doc.objects[r.num]; has && obj.stream != nil {
+			return doc.decode(obj)
+		}
+	}
+	return nil
+}
+
+// pages in the order of the page tree
+func (doc *document) pages() []dict {
+	var root dict
+	for _, obj := range doc.objects {
+		if d, ok := obj.value.(dict); ok && d["Type"] == name("Catalog") {
+			root = d
+			break
+		}
+	}
+	if root == nil {
+		return nil
+	}
+
+	list := []dict{}
+	var walk func(node dict, depth int)
+	walk = func(node dict, depth int) {
+		if node == nil || depth > 64 {
+			return
+		}
+		if node["Type"] == name("Page") {
+			list = append(list, node)
+			return
+		}
+		kids, _ := doc.resolve(node["Kids"]).(array)
+		for _, kid := range kids {
+			walk(doc.dict(kid), depth+1)
+		}
+	}
+	walk(doc.dict(root["Pages"]), 0)
+
+	return list
+}
+
+// the resources can be inherited from the parents of the page
+func (doc *document) resources(page dict) dict {
+	for node, i := page, 0; node != nil && i < 64; node, i = doc.dict(node["Parent"]), i+1 {
+		if r := doc.dict(node["Resources"]); r != nil {
+			return r
+		}
+	}
+	return dict{}
+}
+
+func (doc *document) contents(page dict) [][]byte {
+	list := [][]byte{}
+	switch c := page["Contents"].(type) {
+	case ref:
+		if arr, ok := doc.resolve(c).(array); ok {
+			for _, item := range arr {
+				list = append(list, doc.stream(item))
+			}
+		} else {
+			list = append(list, doc.stream(c))
+		}
+	case array:
+		for _, item := range c {
+			list = append(list, doc.stream(item))
+		}
+	}
+	return list
+}
+
+// textWriter runs the content streams and writes the text
+type textWriter struct {
+	doc   *document
+	cmaps map[int]*cmap // the key is the object number of the font ref
+	font  *cmap
+	buf   strings.Builder
+
+	lineY   float64 // the y of the current line
+	started bool    // if any text is written
+}
+
+func (t *textWriter) String() string {
+	lines := strings.Split(t.buf.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimSpace(l)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func (t *textWriter) newLine() {
+	if t.started {
+		t.buf.WriteString("\n")
+	}
+}
+
+// move to y, a different y starts a new line, the same y continues the line with a space
+func (t *textWriter) moveTo(y float64) {
+	if t.started && math.Abs(y-t.lineY) > 0.5 {
+		t.buf.WriteString("\n")
+	} else if t.started {
+		t.buf.WriteString(" ")
+	}
+	t.lineY = y
+}
+
+func (t *textWriter) write(s []byte) {
+	if t.font != nil {
+		t.buf.WriteString(t.font.decode(s))
+	} else {
+		t.buf.WriteString(latin1(s))
+	}
+	t.started = true
+}
+
+func (t *textWriter) run(content []byte, resources dict, depth int) {
+	if depth > 8 {
+		return
+	}
+
+	fonts := t.doc.dict(resources["Font"])
+	xObjects := t.doc.dict(resources["XObject"])
+
+	p := &parser{data: content}
+	operands := []interface{}{}
+	num := func(i int) float64 {
+		if i < len(operands) {
+			n, _ := operands[i].(float64)
+			return n
+		}
+		return 0
+	}
+
+	for {
+		v := p.value()
+		if v == nil {
+			t.doc.check(p)
+			return
+		}
+
+		op, ok := v.(keyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch op {
+		case "BI": // skip the inline image
+			i := bytes.Index(content[p.pos:], []byte("EI"))
+			if i < 0 {
+				return
+			}
+			p.pos += i + 2
+		case "Tf":
+			if len(operands) > 0 {
+				font, _ := operands[0].(name)
+				t.font = t.cmap(fonts[string(font)])
+			}
+		case "Td", "TD":
+			if len(operands) == 2 && num(1) != 0 {
+				t.newLine()
+			}
+		case "Tm":
+			if len(operands) == 6 {
+				t.moveTo(num(5))
+			}
+		case "T*":
+			t.newLine()
+		case "Tj":
+			t.writeOperand(operands, 0)
+		case "'":
+			t.newLine()
+			t.writeOperand(operands, 0)
+		case `"`:
+			t.newLine()
+			t.writeOperand(operands, 2)
+		case "TJ":
+			if len(operands) > 0 {
+				arr, _ := operands[0].(array)
+				for _, item := range arr {
+					switch v := item.(type) {
+					case []byte:
+						t.write(v)
+					case float64:
+						// a large negative adjustment is usually a space between words
+						if v < -200 {
+							t.buf.WriteString(" ")
+						}
+					}
+				}
+			}
+		case "Do":
+			if len(operands) > 0 {
+				n, _ := operands[0].(name)
+				t.runXObject(xObjects[string(n)], resources, depth)
+			}
+		}
+
+		operands = operands[:0]
+	}
+}
+
+func (t *textWriter) writeOperand(operands []interface{}, i int) {
+	if i < len(operands) {
+		if s, ok := operands[i].([]byte); ok {
+			t.write(s)
+		}
+	}
+}
+
+func (t *textWriter) runXObject(v interface{}, resources dict, depth int) {
+	r, ok := v.(ref)
+	if !ok {
+		return
+	}
+	obj, has := t.doc.objects[r.num]
+	if !has {
+		return
+	}
+	d, _ := obj.value.(dict)
+	if d["Subtype"] != name("Form") {
+		return
+	}
+
+	if res := t.doc.dict(d["Resources"]); res != nil {
+		resources = res
+	}
+
+	font := t.font
+	t.run(t.doc.decode(obj), resources, depth+1)
+	t.font = font
+}
+
+// cmap of the font, returns nil if the font has no ToUnicode map.
+// Only the fonts of refs are cached, the direct dicts are parsed each time.
+func (t *textWriter) cmap(font interface{}) *cmap {
+	r, isRef := font.(ref)
+	if c, has := t.cmaps[r.num]; isRef && has {
+		return c
+	}
+
+	var c *cmap
+	if d := t.doc.dict(font); d != nil {
+		if data := t.doc.stream(d["ToUnicode"]); data != nil {
+			p := &parser{data: data}
+			c = parseCMap(p)
+			t.doc.check(p)
+		}
+	}
+	if isRef {
+		t.cmaps[r.num] = c
+	}
+	return c
+}
+
+// cmap maps the character codes to the unicode text
+type cmap struct {
+	width int // the bytes of a code
+	chars map[uint32]string
+}
+
+func parseCMap(p *parser) *cmap {
+	c := &cmap{width: 1, chars: map[uint32]string{}}
+
+	operands := []interface{}{}
+	section := ""
+
+	for {
+		v := p.value()
+		if v == nil {
+			break
+		}
+
+		op, ok := v.(keyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+
+		switch op {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			section = string(op)
+		case "endcodespacerange":
+			if lo, ok := first(operands).([]byte); ok && len(lo) > 0 {
+				c.width = len(lo)
+			}
+			section = ""
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, _ := operands[i].([]byte)
+				dst, _ := operands[i+1].([]byte)
+				c.chars[code(src)] = utf16Text(dst)
+			}
+			section = ""
+		case "endbfrange":
+			c.addRanges(operands)
+			section = ""
+		}
+
+		if section == "" || op == keyword(section) {
+			operands = operands[:0]
+		}
+	}
+
+	return c
+}
+
+func first(list []interface{}) interface{} {
+	if len(list) == 0 {
+		return nil
+	}
+	return list[0]
+}
+
+func (c *cmap) addRanges(operands []interface{}) {
+	for i := 0; i+2 < len(operands); i += 3 {
+		lo, _ := operands[i].([]byte)
+		hi, _ := operands[i+1].([]byte)
+		from, to := code(lo), code(hi)
+		if to < from || to-from > 0xffff {
+			continue
+		}
+
+		switch dst := operands[i+2].(type) {
+		case []byte:
+			base := utf16.Decode(utf16Units(dst))
+			for j := uint32(0); j <= to-from; j++ {
+				runes := append([]rune{}, base...)
+				if len(runes) > 0 {
+					runes[len(runes)-1] += rune(j)
+				}
+				c.chars[from+j] = string(runes)
+			}
+		case array:
+			for j, item := range dst {
+				if b, ok := item.([]byte); ok {
+					c.chars[from+uint32(j)] = utf16Text(b)
+				}
+			}
+		}
+	}
+}
+
+func (c *cmap) decode(s []byte) string {
+	b := strings.Builder{}
+	for i := 0; i+c.width <= len(s); i += c.width {
+		if text, has := c.chars[code(s[i:i+c.width])]; has {
+			b.WriteString(text)
+		}
+	}
+	return b.String()
+}
+
+func code(b []byte) uint32 {
+	var n uint32
+	for _, c := range b {
+		n = n<<8 | uint32(c)
+	}
+	return n
+}
+
+func utf16Units(b []byte) []uint16 {
+	units := []uint16{}
+	for i := 0; i+1 < len(b); i += 2 {
+		units = append(units, uint16(b[i])<<8|uint16(b[i+1]))
+	}
+	return units
+}
+
+func utf16Text(b []byte) string {
+	return string(utf16.Decode(utf16Units(b)))
+}
+
+// the fonts without the ToUnicode map are treated as latin1, it works for the standard fonts in most cases
+func latin1(s []byte) string {
+	runes := make([]rune, len(s))
+	for i, c := range s {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
@@ -0,0 +1,179 @@
+package pdf_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-rod/rod/lib/pdf"
+	"github.com/ysmood/got"
+)
+
+var setup = got.Setup(nil)
+
+// build a pdf file from the objects, the object number starts from 1
+func build(objects ...string) []byte {
+	b := bytes.NewBufferString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	for i, obj := range objects {
+		fmt.Fprintf(b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	b.WriteString("trailer\n<< /Root 1 0 R >>\n%%EOF\n")
+	return b.Bytes()
+}
+
+func stream(header, data string) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", header, len(data), data)
+}
+
+func flate(data string) string {
+	b := bytes.NewBuffer(nil)
+	w := zlib.NewWriter(b)
+	_, _ = w.Write([]byte(data))
+	_ = w.Close()
+	return b.String()
+}
+
+func TestText(t *testing.T) {
+	g := setup(t)
+
+	data := build(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 6 0 R] /Count 2 /Resources << /Font << /F1 4 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		stream("", `BT /F1 12 Tf 72 720 Td (Invoice \(copy\)) Tj 0 -14 Td [(Order) -250 (#) 10 (42)] TJ
+			T* (caf\351) Tj ET % comment`),
+		"<< /Type /Page /Parent 2 0 R /Contents [7 0 R 8 0 R] >>",
+		stream("", `BT /F1 12 Tf 1 0 0 1 72 720 Tm (Total:) Tj 1 0 0 1 120 720 Tm (10) Tj ET`),
+		stream("", `BT /F1 12 Tf 1 0 0 1 72 700 Tm (Thanks) Tj ET`),
+	)
+
+	pages, err := pdf.Pages(bytes.NewReader(data))
+	g.E(err)
+	g.Eq(pages, []string{"Invoice (copy)\nOrder #42\ncafé", "Total: 10\nThanks"})
+
+	text, err := pdf.Text(bytes.NewReader(data))
+	g.E(err)
+	g.Eq(text, "Invoice (copy)\nOrder #42\ncafé\fTotal: 10\nThanks")
+}
+
+func TestTextToUnicode(t *testing.T) {
+	g := setup(t)
+
+	cmap := `/CIDInit /ProcSet findresource begin
+		begincmap
+		1 begincodespacerange <0000> <ffff> endcodespacerange
+		2 beginbfchar <0001> <0048> <0002> <0069> endbfchar
+		1 beginbfrange <0003> <0005> <4f60> endbfrange
+		endcmap`
+
+	data := build(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> /XObject << /X1 7 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /ABC /Encoding /Identity-H /ToUnicode 6 0 R >>",
+		stream("/Filter /FlateDecode", flate(`BT /F1 12 Tf <00010002> Tj 0 -14 Td <000300040005> Tj ET /X1 Do`)),
+		stream("/Filter /FlateDecode", flate(cmap)),
+		stream("/Type /XObject /Subtype /Form", `BT /F1 12 Tf 0 -28 Td <0002> Tj ET`),
+	)
+
+	text, err := pdf.Text(bytes.NewReader(data))
+	g.E(err)
+	g.Eq(text, "Hi\n你佡佢\ni")
+}
+
+func TestTextObjectStream(t *testing.T) {
+	g := setup(t)
+
+	// the objects 5 and 6 are inside the object stream
+	objects := "<< /Type /Catalog /Pages 6 0 R >> << /Type /Pages /Kids [3 0 R] /Count 1 >>"
+	data := build(
+		stream("/Type /ObjStm /N 2 /First 9", "5 0 6 34 "+objects),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		"<< /Type /Page /Parent 6 0 R /Resources << /Font << /F1 2 0 R >> >> /Contents 4 0 R >>",
+		stream("", `BT /F1 12 Tf (ok) Tj ET`),
+	)
+
+	text, err := pdf.Text(bytes.NewReader(data))
+	g.E(err)
+	g.Eq(text, "ok")
+}
+
+func TestTextInlineFont(t *testing.T) {
+	g := setup(t)
+
+	// the fonts are direct objects instead of refs, and the content mentions /Encrypt
+	data := build(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R /Resources << /Font << "+
+			"/F1 << /Type /Font /Subtype /Type1 /BaseFont /Helvetica >> /F2 [1 2] >> >> >>",
+		stream("", `BT /F1 12 Tf (/Encrypt) Tj /F2 12 Tf 0 -14 Td (ok) Tj /F1 12 Tf T* (end) Tj ET`),
+	)
+
+	text, err := pdf.Text(bytes.NewReader(data))
+	g.E(err)
+	g.Eq(text, "/Encrypt\nok\nend")
+}
+
+func TestTextErr(t *testing.T) {
+	g := setup(t)
+
+	_, err := pdf.Text(bytes.NewReader(build("<< /Type /Font >>")))
+	g.Eq(err, pdf.ErrNoPages)
+
+	encrypted := bytes.Replace(build("<< /Type /Catalog /Pages 2 0 R >>"),
+		[]byte("/Root 1 0 R"), []byte("/Root 1 0 R /Encrypt 3 0 R"), 1)
+	_, err = pdf.Text(bytes.NewReader(encrypted))
+	g.Eq(err, pdf.ErrEncrypted)
+
+	_, err = pdf.Text(bytes.NewReader(build(stream("/Type /XRef /Encrypt 3 0 R", ""))))
+	g.Eq(err, pdf.ErrEncrypted)
+
+	_, err = pdf.Text(bytes.NewReader(build(strings.Repeat("[", 10000))))
+	g.Eq(err, pdf.ErrTooDeep)
+
+	_, err = pdf.Text(bytes.NewReader(build(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+		stream("", "BT "+strings.Repeat("<<", 10000)+" ET"),
+	)))
+	g.Eq(err, pdf.ErrTooDeep)
+
+	_, err = pdf.Text(&errReader{})
+	g.Err(err)
+
+	// the broken content won't panic
+	text, err := pdf.Text(strings.NewReader(string(build(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 9 0 R] >>",
+		"<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>",
+		"<< /Filter /FlateDecode /Length 3 >>\nstream\nabc\nendstream",
+	))))
+	g.E(err)
+	g.Eq(text, "")
+
+	// the invalid lengths and offsets won't panic
+	for _, obj := range []string{
+		"<< /Length -500 >>\nstream\nabc\nendstream",
+		"<< /Length 1e300 >>\nstream\nabc\nendstream",
+		"<< /Length 100 >>\nstream\nabc",
+		stream("/Type /ObjStm /N 1 /First -50", "1 0 (a)"),
+		stream("/Type /ObjStm /N 1 /First 1e300", "1 0 (a)"),
+		stream("/Type /ObjStm /N 2 /First 4", "1 -9 2 9223372036854775807 (a)"),
+		stream("/Type /ObjStm /N 1e300 /First 0", "1 0"),
+	} {
+		_, err := pdf.Text(bytes.NewReader(build(obj)))
+		g.Eq(err, pdf.ErrNoPages)
+	}
+}
+
+type errReader struct{}
+
+func (r *errReader) Read([]byte) (int, error) {
+	return 0, errors.New("err")
+}
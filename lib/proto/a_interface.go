@@ -43,6 +43,36 @@ func GetType(methodName string) reflect.Type {
 	return types[methodName]
 }
 
+// ErrUnknownEvent error
+type ErrUnknownEvent struct {
+	Method string
+}
+
+func (e *ErrUnknownEvent) Error() string {
+	return "unknown cdp event: " + e.Method
+}
+
+// DecodeEvent decodes the params of the cdp event into the typed struct of the method, such as
+// the params of "Page.loadEventFired" will be decoded into *proto.PageLoadEventFired.
+// It returns *ErrUnknownEvent if the method is not an event defined in the protocol,
+// and the json error if the shape of the params doesn't match the definition.
+func DecodeEvent(method string, params []byte) (Event, error) {
+	t := GetType(method)
+	if t == nil {
+		return nil, &ErrUnknownEvent{method}
+	}
+
+	v, ok := reflect.New(t).Interface().(Event)
+	if !ok {
+		return nil, &ErrUnknownEvent{method}
+	}
+
+	if len(params) == 0 {
+		return v, nil
+	}
+	return v, json.Unmarshal(params, v)
+}
+
 // ParseMethodName to domain and name
 func ParseMethodName(method string) (domain, name string) {
 	arr := strings.Split(method, ".")
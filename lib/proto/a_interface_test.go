@@ -52,6 +52,25 @@ func (t T) GetType() {
 	t.Eq(reflect.TypeOf(proto.PageEnable{}), method)
 }
 
+func (t T) DecodeEvent() {
+	v, err := proto.DecodeEvent("Page.frameNavigated", []byte(`{"frame":{"id":"1","url":"http://a.com"}}`))
+	t.E(err)
+	t.Eq(v.(*proto.PageFrameNavigated).Frame.URL, "http://a.com")
+
+	v, err = proto.DecodeEvent("Page.loadEventFired", nil)
+	t.E(err)
+	t.Is(v, &proto.PageLoadEventFired{})
+
+	_, err = proto.DecodeEvent("Page.frameNavigated", []byte(`{"frame":1}`))
+	t.Err(err)
+
+	_, err = proto.DecodeEvent("Page.enable", nil)
+	t.Eq(err.Error(), "unknown cdp event: Page.enable")
+
+	_, err = proto.DecodeEvent("Foo.bar", nil)
+	t.Eq(err.(*proto.ErrUnknownEvent).Method, "Foo.bar")
+}
+
 func (t T) TimeCodec() {
 	raw := []byte("123.123")
 	var duration proto.MonotonicTime
@@ -134,6 +153,36 @@ func (t T) CookiesToParams() {
 
 	t.Eq(list[0].Name, "name")
 	t.Eq(list[0].Value, "val")
+	t.Nil(list[0].SourcePort)
+
+	list = proto.CookiesToParams([]*proto.NetworkCookie{{
+		Name:         "name",
+		Expires:      -1,
+		Session:      true,
+		SameSite:     proto.NetworkCookieSameSiteNone,
+		Priority:     proto.NetworkCookiePriorityHigh,
+		SourcePort:   443,
+		PartitionKey: "https://a.com",
+	}})
+
+	t.Eq(list[0].Expires, proto.TimeSinceEpoch(0))
+	t.Eq(list[0].SameSite, proto.NetworkCookieSameSiteNone)
+	t.Eq(list[0].Priority, proto.NetworkCookiePriorityHigh)
+	t.Eq(*list[0].SourcePort, 443)
+	t.Eq(list[0].PartitionKey, "https://a.com")
+
+	// the host-only cookies are set via the url
+	list = proto.CookiesToParams([]*proto.NetworkCookie{
+		{Name: "__Host-a", Domain: "a.com", Path: "/", Secure: true},
+		{Name: "b", Domain: "a.com"},
+		{Name: "c", Domain: ".a.com", Path: "/"},
+	})
+
+	t.Eq(list[0].URL, "https://a.com/")
+	t.Eq(list[0].Domain, "")
+	t.Eq(list[1].URL, "http://a.com/")
+	t.Eq(list[2].URL, "")
+	t.Eq(list[2].Domain, ".a.com")
 }
 
 func (t T) GeneratorOptimize() {
@@ -3,6 +3,7 @@
 package proto
 
 import (
+	"strings"
 	"time"
 )
 
@@ -160,21 +161,48 @@ func (p *InputTouchPoint) MoveTo(x, y float64) {
 	p.Y = y
 }
 
-// CookiesToParams converts Cookies list to NetworkCookieParam list
+// CookiesToParams converts Cookies list to NetworkCookieParam list.
+// All the attributes that can be set are kept, such as the SameSite, Priority, and PartitionKey,
+// so the cookies can round-trip. The Expires of a session cookie is dropped to keep it a session cookie.
+// A host-only cookie, whose Domain doesn't start with ".", is set via the URL instead of the Domain,
+// or it will become a domain cookie, and the cookies with the "__Host-" prefix will be rejected.
 func CookiesToParams(cookies []*NetworkCookie) []*NetworkCookieParam {
 	list := []*NetworkCookieParam{}
 	for _, c := range cookies {
-		list = append(list, &NetworkCookieParam{
-			Name:     c.Name,
-			Value:    c.Value,
-			Domain:   c.Domain,
-			Path:     c.Path,
-			Secure:   c.Secure,
-			HTTPOnly: c.HTTPOnly,
-			SameSite: c.SameSite,
-			Expires:  c.Expires,
-			Priority: c.Priority,
-		})
+		param := &NetworkCookieParam{
+			Name:         c.Name,
+			Value:        c.Value,
+			Domain:       c.Domain,
+			Path:         c.Path,
+			Secure:       c.Secure,
+			HTTPOnly:     c.HTTPOnly,
+			SameSite:     c.SameSite,
+			Expires:      c.Expires,
+			Priority:     c.Priority,
+			SameParty:    c.SameParty,
+			SourceScheme: c.SourceScheme,
+			PartitionKey: c.PartitionKey,
+		}
+		if c.Session {
+			param.Expires = 0
+		}
+		if c.Domain != "" && !strings.HasPrefix(c.Domain, ".") {
+			scheme := "http"
+			if c.Secure || c.SourceScheme == NetworkCookieSourceSchemeSecure {
+				scheme = "https"
+			}
+			path := c.Path
+			if path == "" {
+				path = "/"
+			}
+			param.URL = scheme + "://" + c.Domain + path
+			param.Domain = ""
+		}
+		if c.SourcePort != 0 {
+			port := c.SourcePort
+			param.SourcePort = &port
+		}
+		list = append(list, param)
 	}
 	return list
 }
@@ -0,0 +1,158 @@
+// Package rodtest helps to use rod in go tests.
+// The browser respects the "-rod" flag, so you can debug a test like:
+//
+//	go test -run TestX -rod=show,trace,slow=500ms
+package rodtest
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+// ArtifactsDir is the dir to save the artifacts of the failed tests.
+// The artifacts of each test are saved to a sub dir named after the test.
+var ArtifactsDir = filepath.Join("tmp", "rodtest")
+
+// NewBrowser connects to a new browser, it will be closed when the test ends.
+func NewBrowser(t testing.TB) *rod.Browser {
+	t.Helper()
+
+	b := rod.New()
+	err := b.Connect()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = b.Close() })
+
+	return b
+}
+
+var shared struct {
+	lock    sync.Mutex
+	browser *rod.Browser
+	count   int
+}
+
+// SharedBrowser returns an incognito context of the browser shared by the tests, it's safe to use with t.Parallel.
+// Each test has its own incognito context, so cookies and storages are isolated between tests.
+// The context will be closed when the test ends, the browser will be closed when the last test that uses it ends.
+func SharedBrowser(t testing.TB) *rod.Browser {
+	t.Helper()
+
+	shared.lock.Lock()
+	defer shared.lock.Unlock()
+
+	if shared.browser == nil {
+		b := rod.New()
+		err := b.Connect()
+		if err != nil {
+			t.Fatal(err)
+		}
+		shared.browser = b
+	}
+
+	b, err := shared.browser.Incognito()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shared.count++
+	t.Cleanup(func() {
+		_ = b.Close()
+
+		shared.lock.Lock()
+		defer shared.lock.Unlock()
+
+		shared.count--
+		if shared.count == 0 {
+			_ = shared.browser.Close()
+			shared.browser = nil
+		}
+	})
+
+	return b
+}
+
+// New creates a page of a new browser, they will be closed when the test ends.
+// If the test fails, the screenshot, html, and console logs of the page will be saved to the [ArtifactsDir].
+func New(t testing.TB) *rod.Page {
+	t.Helper()
+	return newPage(t, NewBrowser(t))
+}
+
+// NewShared is similar to [New], but the page is created from the [SharedBrowser].
+func NewShared(t testing.TB) *rod.Page {
+	t.Helper()
+	return newPage(t, SharedBrowser(t))
+}
+
+func newPage(t testing.TB, b *rod.Browser) *rod.Page {
+	t.Helper()
+
+	p, err := b.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := &consoleLogs{}
+	go p.EachEvent(func(e *proto.RuntimeConsoleAPICalled) {
+		logs.add(e)
+	})()
+
+	t.Cleanup(func() {
+		if t.Failed() {
+			saveArtifacts(t, p, logs)
+		}
+	})
+
+	return p
+}
+
+var regUnsafeName = regexp.MustCompile(`[^\w.-]+`)
+
+func saveArtifacts(t testing.TB, p *rod.Page, logs *consoleLogs) {
+	dir := filepath.Join(ArtifactsDir, regUnsafeName.ReplaceAllString(t.Name(), "_"))
+
+	if img, err := p.Screenshot(false, nil); err == nil {
+		_ = utils.OutputFile(filepath.Join(dir, "screenshot.png"), img)
+	}
+	if html, err := p.HTML(); err == nil {
+		_ = utils.OutputFile(filepath.Join(dir, "page.html"), html)
+	}
+	_ = utils.OutputFile(filepath.Join(dir, "console.log"), logs.String())
+
+	t.Logf("[rodtest] artifacts of the failed test are saved to: %s", dir)
+}
+
+type consoleLogs struct {
+	lock sync.Mutex
+	list []string
+}
+
+func (l *consoleLogs) add(e *proto.RuntimeConsoleAPICalled) {
+	args := []string{}
+	for _, arg := range e.Args {
+		if arg.Description != "" {
+			args = append(args, arg.Description)
+		} else {
+			args = append(args, arg.Value.String())
+		}
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.list = append(l.list, "["+string(e.Type)+"] "+strings.Join(args, " "))
+}
+
+func (l *consoleLogs) String() string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return strings.Join(l.list, "\n")
+}
@@ -0,0 +1,77 @@
+package rodtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/rodtest"
+	"github.com/ysmood/got"
+)
+
+// failedT pretends the test is failed
+type failedT struct {
+	testing.TB
+}
+
+func (failedT) Failed() bool { return true }
+
+func TestNew(t *testing.T) {
+	g := got.T(t)
+
+	rodtest.ArtifactsDir = filepath.Join("tmp", g.RandStr(8))
+	defer func() { _ = os.RemoveAll(rodtest.ArtifactsDir) }()
+
+	t.Run("passed", func(t *testing.T) {
+		rodtest.New(t).MustNavigate("about:blank")
+	})
+	g.False(g.PathExists(filepath.Join(rodtest.ArtifactsDir, "TestNew_passed")))
+
+	t.Run("failed", func(t *testing.T) {
+		p := rodtest.New(failedT{t})
+
+		wait := p.WaitEvent(&proto.RuntimeConsoleAPICalled{})
+		p.MustEval(`() => console.log("hello", 1)`)
+		wait()
+	})
+
+	dir := filepath.Join(rodtest.ArtifactsDir, "TestNew_failed")
+	g.True(g.PathExists(filepath.Join(dir, "screenshot.png")))
+	g.Has(g.Read(filepath.Join(dir, "page.html")).String(), "<html>")
+	g.Eq(g.Read(filepath.Join(dir, "console.log")).String(), "[log] hello 1")
+}
+
+func TestShared(t *testing.T) {
+	g := got.T(t)
+
+	var browser *rod.Browser
+	lock := sync.Mutex{}
+
+	t.Run("group", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			t.Run("", func(t *testing.T) {
+				t.Parallel()
+
+				p := rodtest.NewShared(t)
+
+				// the cookies are isolated between tests
+				g.Len(p.MustCookies("http://a.com"), 0)
+				p.MustSetCookies(&proto.NetworkCookieParam{Name: "a", Value: "1", URL: "http://a.com"})
+
+				lock.Lock()
+				browser = p.Browser()
+				lock.Unlock()
+			})
+		}
+	})
+
+	if browser == nil {
+		t.Fatal("none of the tests got the shared browser")
+	}
+
+	// the shared browser should be closed after the last test
+	g.Err(browser.Version())
+}
@@ -16,6 +16,8 @@ func main() {
 
 	lintMustPrefix()
 
+	lintMustDoc()
+
 	checkGitClean()
 }
 
@@ -6,6 +6,7 @@ import (
 	"go/token"
 	"log"
 	"path/filepath"
+	"regexp"
 	"strings"
 
 	"github.com/go-rod/rod/lib/utils"
@@ -46,3 +47,62 @@ func lintMustPrefix() {
 		log.Fatalln("'Must' prefixed function should be declared in file 'must.go'")
 	}
 }
+
+var regMustDoc = regexp.MustCompile(`^Must\w+ is similar to \[(\w+)\.(\w+)\]\.`)
+
+// lintMustDoc makes sure each "Must" prefixed function refers to an existing method,
+// so that the "Must" version and the error version won't drift apart.
+func lintMustDoc() {
+	log.Println("[lint] the doc of 'Must' prefixed functions")
+
+	paths, err := filepath.Glob("*.go")
+	utils.E(err)
+
+	list := token.NewFileSet()
+	methods := map[string]bool{}
+	musts := []*ast.FuncDecl{}
+
+	for _, p := range paths {
+		if strings.HasSuffix(p, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(list, p, nil, parser.ParseComments)
+		utils.E(err)
+
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil {
+				continue
+			}
+
+			methods[recvName(fd)+"."+fd.Name.Name] = true
+
+			if strings.HasPrefix(fd.Name.Name, "Must") {
+				musts = append(musts, fd)
+			}
+		}
+	}
+
+	lintErr := false
+
+	for _, fd := range musts {
+		ms := regMustDoc.FindStringSubmatch(fd.Doc.Text())
+		if ms == nil || !methods[ms[1]+"."+ms[2]] {
+			log.Printf("%s %s\n", list.Position(fd.Name.Pos()), fd.Name.Name)
+			lintErr = true
+		}
+	}
+
+	if lintErr {
+		log.Fatalln("'Must' prefixed function's doc should start with 'MustX is similar to [Type.Method].' and the method should exist")
+	}
+}
+
+func recvName(fd *ast.FuncDecl) string {
+	t := fd.Recv.List[0].Type
+	if s, ok := t.(*ast.StarExpr); ok {
+		t = s.X
+	}
+	return t.(*ast.Ident).Name
+}
@@ -99,6 +99,46 @@ func BackoffSleeper(initInterval, maxInterval time.Duration, algorithm func(time
 	}
 }
 
+// RandomSleeper returns a sleeper that sleeps a random duration in [min, max) every time get called.
+// If max is not greater than min, the sleeper will always sleep min.
+func RandomSleeper(min, max time.Duration) Sleeper {
+	return func(ctx context.Context) error {
+		d := min
+		if max > min {
+			d += time.Duration(mr.Int63n(int64(max - min)))
+		}
+
+		t := time.NewTimer(d)
+		defer t.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-t.C:
+		}
+
+		return nil
+	}
+}
+
+// TimeoutSleeper returns a sleeper that never sleeps, it returns context.DeadlineExceeded
+// when it's called after d since the sleeper is created.
+// Use it with EachSleepers to limit the total time of a retry.
+func TimeoutSleeper(d time.Duration) Sleeper {
+	deadline := time.Now().Add(d)
+
+	return func(ctx context.Context) error {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if time.Now().After(deadline) {
+			return context.DeadlineExceeded
+		}
+		return nil
+	}
+}
+
 // EachSleepers returns a sleeper wakes up when each sleeper is awake.
 // If a sleeper returns error, it will wake up immediately.
 func EachSleepers(list ...Sleeper) Sleeper {
@@ -64,6 +64,39 @@ func TestCountSleeperCancel(t *testing.T) {
 	g.Eq(s(g.Timeout(0)), context.DeadlineExceeded)
 }
 
+func TestRandomSleeper(t *testing.T) {
+	g := setup(t)
+
+	start := time.Now()
+	g.E(utils.RandomSleeper(10*time.Millisecond, 20*time.Millisecond)(g.Context()))
+	g.Gte(time.Since(start), 10*time.Millisecond)
+
+	start = time.Now()
+	g.E(utils.RandomSleeper(10*time.Millisecond, 0)(g.Context()))
+	g.Gte(time.Since(start), 10*time.Millisecond)
+}
+
+func TestRandomSleeperCancel(t *testing.T) {
+	g := setup(t)
+
+	s := utils.RandomSleeper(time.Second, 2*time.Second)
+	g.Eq(s(g.Timeout(0)), context.DeadlineExceeded)
+}
+
+func TestTimeoutSleeper(t *testing.T) {
+	g := setup(t)
+
+	s := utils.TimeoutSleeper(10 * time.Millisecond)
+	g.E(s(g.Context()))
+
+	err := utils.Retry(g.Context(), utils.EachSleepers(s, utils.BackoffSleeper(1, 5, nil)), func() (bool, error) {
+		return false, nil
+	})
+	g.Eq(err, context.DeadlineExceeded)
+
+	g.Eq(utils.TimeoutSleeper(time.Second)(g.Timeout(0)), context.DeadlineExceeded)
+}
+
 func TestEachSleepers(t *testing.T) {
 	g := setup(t)
 
@@ -4,7 +4,11 @@ package utils
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
 	"encoding/hex"
 	"fmt"
 	"image"
@@ -363,3 +367,29 @@ func CropImage(bin []byte, quality, x, y, width, height int) ([]byte, error) {
 
 	return cropped.Bytes(), err
 }
+
+// TOTPPeriod is the time step of the codes generated by [TOTP]
+const TOTPPeriod = 30 * time.Second
+
+// TOTP generates the 6-digit time-based one-time password of the base32 secret at time t, it's compatible
+// with the common authenticator apps. The spaces and the padding of the secret are ignored.
+// Doc: https://datatracker.ietf.org/doc/html/rfc6238
+func TOTP(secret string, t time.Time) (string, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.ReplaceAll(secret, " ", ""), "="))
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(t.Unix()/int64(TOTPPeriod/time.Second)))
+
+	mac := hmac.New(sha1.New, key)
+	_, _ = mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", code%1000000), nil
+}
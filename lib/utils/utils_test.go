@@ -252,3 +252,29 @@ func TestCropImage(t *testing.T) {
 	g.E(jpeg.Encode(bin, img, &jpeg.Options{Quality: 80}))
 	g.E(utils.CropImage(bin.Bytes(), 0, 10, 10, 30, 30))
 }
+
+func TestTOTP(t *testing.T) {
+	g := setup(t)
+
+	// the test vectors of RFC 6238, the secret is "12345678901234567890" in base32
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	for ts, code := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		res, err := utils.TOTP(secret, time.Unix(ts, 0))
+		g.E(err)
+		g.Eq(res, code)
+	}
+
+	res, err := utils.TOTP("gezd gnbv gy3t qojq gezd gnbv gy3t qojq====", time.Unix(59, 0))
+	g.E(err)
+	g.Eq(res, "287082")
+
+	_, err = utils.TOTP("1", time.Now())
+	g.Err(err)
+}
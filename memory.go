@@ -0,0 +1,89 @@
+package rod
+
+import (
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// HeapGrowth is the trend of the js heap size, see [Page.MeasureHeapGrowth]
+type HeapGrowth struct {
+	// Samples of the used js heap size in bytes, the first one is taken before the rounds,
+	// the others are taken after each round.
+	Samples []float64
+
+	// Growth is the difference between the last and the first sample in bytes
+	Growth float64
+
+	// Slope is the growth per round in bytes, it's estimated by the linear regression of the samples,
+	// so a single spike won't affect it much.
+	Slope float64
+}
+
+// Leaking returns true if the heap grows more than the threshold bytes per round.
+func (h *HeapGrowth) Leaking(threshold float64) bool {
+	return h.Slope > threshold
+}
+
+// HeapUsage forces the garbage collection of the page, then returns the used js heap size in bytes.
+func (p *Page) HeapUsage() (float64, error) {
+	err := proto.HeapProfilerCollectGarbage{}.Call(p)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := proto.RuntimeGetHeapUsage{}.Call(p)
+	if err != nil {
+		return 0, err
+	}
+	return res.UsedSize, nil
+}
+
+// MeasureHeapGrowth runs fn for the rounds, the heap usage is sampled by [Page.HeapUsage] before the rounds
+// and after each round. Such as to detect the leaks of a SPA by opening and closing a dialog repeatedly:
+//
+//	growth, _ := page.MeasureHeapGrowth(20, openAndCloseDialog)
+//	if growth.Leaking(10 * 1024) { ... }
+func (p *Page) MeasureHeapGrowth(rounds int, fn func() error) (*HeapGrowth, error) {
+	sample, err := p.HeapUsage()
+	if err != nil {
+		return nil, err
+	}
+	samples := []float64{sample}
+
+	for i := 0; i < rounds; i++ {
+		err = fn()
+		if err != nil {
+			return nil, err
+		}
+
+		sample, err = p.HeapUsage()
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, sample)
+	}
+
+	return &HeapGrowth{
+		Samples: samples,
+		Growth:  samples[len(samples)-1] - samples[0],
+		Slope:   slope(samples),
+	}, nil
+}
+
+// slope of the least squares line of the ys, the xs are their indexes
+func slope(ys []float64) float64 {
+	n := float64(len(ys))
+	if n < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range ys {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
@@ -0,0 +1,42 @@
+package rod_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestMeasureHeapGrowth(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	g.Gt(p.MustHeapUsage(), 0)
+
+	growth := p.MustMeasureHeapGrowth(5, func() {
+		p.MustEval(`() => { window.leak = (window.leak || []).concat(new Array(1e5).fill('x')) }`)
+	})
+	g.Len(growth.Samples, 6)
+	g.Gt(growth.Growth, 0)
+	g.True(growth.Leaking(1024))
+
+	growth = p.MustMeasureHeapGrowth(5, func() {
+		p.MustEval(`() => { new Array(1e5).fill('x') }`)
+	})
+	g.False(growth.Leaking(100 * 1024))
+
+	_, err := p.MeasureHeapGrowth(1, func() error { return errors.New("err") })
+	g.Eq(err.Error(), "err")
+
+	g.mc.stubErr(1, proto.HeapProfilerCollectGarbage{})
+	g.Err(p.HeapUsage())
+
+	g.mc.stubErr(1, proto.RuntimeGetHeapUsage{})
+	g.Err(p.HeapUsage())
+
+	g.mc.stubErr(1, proto.HeapProfilerCollectGarbage{})
+	g.Err(p.MeasureHeapGrowth(1, func() error { return nil }))
+
+	g.mc.stubErr(2, proto.HeapProfilerCollectGarbage{})
+	g.Err(p.MeasureHeapGrowth(1, func() error { return nil }))
+}
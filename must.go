@@ -10,6 +10,7 @@ package rod
 
 import (
 	"errors"
+	"image"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -18,6 +19,7 @@ import (
 	"time"
 
 	"github.com/go-rod/rod/lib/devices"
+	"github.com/go-rod/rod/lib/imgdiff"
 	"github.com/go-rod/rod/lib/input"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -64,6 +66,12 @@ func (b *Browser) MustIncognito() *Browser {
 	return p
 }
 
+// MustDisposeContext is similar to [Browser.DisposeContext].
+func (b *Browser) MustDisposeContext(id proto.BrowserBrowserContextID) *Browser {
+	b.e(b.DisposeContext(id))
+	return b
+}
+
 // MustPage is similar to [Browser.Page].
 // The url list will be joined by "/".
 func (b *Browser) MustPage(url ...string) *Page {
@@ -79,7 +87,7 @@ func (b *Browser) MustPages() Pages {
 	return list
 }
 
-// MustPageFromTargetID is similar to [Browser.PageFromTargetID].
+// MustPageFromTargetID is similar to [Browser.PageFromTarget].
 func (b *Browser) MustPageFromTargetID(targetID proto.TargetTargetID) *Page {
 	p, err := b.PageFromTarget(targetID)
 	b.e(err)
@@ -132,6 +140,25 @@ func (b *Browser) MustWaitDownload() func() []byte {
 	}
 }
 
+// MustSetDownloadDir is similar to [Browser.SetDownloadDir].
+func (b *Browser) MustSetDownloadDir(dir string) *Browser {
+	b.e(b.SetDownloadDir(dir))
+	return b
+}
+
+// MustStorageState is similar to [Browser.StorageState].
+func (b *Browser) MustStorageState(origins ...string) *StorageState {
+	s, err := b.StorageState(origins...)
+	b.e(err)
+	return s
+}
+
+// MustSetStorageState is similar to [Browser.SetStorageState].
+func (b *Browser) MustSetStorageState(s *StorageState) *Browser {
+	b.e(b.SetStorageState(s))
+	return b
+}
+
 // MustVersion is similar to [Browser.Version].
 func (b *Browser) MustVersion() *proto.BrowserGetVersionResult {
 	v, err := b.Version()
@@ -139,7 +166,28 @@ func (b *Browser) MustVersion() *proto.BrowserGetVersionResult {
 	return v
 }
 
-// MustFind is similar to [Browser.Find].
+// MustMajorVersion is similar to [Browser.MajorVersion].
+func (b *Browser) MustMajorVersion() int {
+	v, err := b.MajorVersion()
+	b.e(err)
+	return v
+}
+
+// MustFeatures is similar to [Browser.Features].
+func (b *Browser) MustFeatures() *Features {
+	f, err := b.Features()
+	b.e(err)
+	return f
+}
+
+// MustHeadlessMode is similar to [Browser.HeadlessMode].
+func (b *Browser) MustHeadlessMode() HeadlessMode {
+	m, err := b.HeadlessMode()
+	b.e(err)
+	return m
+}
+
+// MustFind is similar to [Pages.Find].
 func (ps Pages) MustFind(selector string) *Page {
 	p, err := ps.Find(selector)
 	if err != nil {
@@ -154,7 +202,7 @@ func (ps Pages) MustFind(selector string) *Page {
 	return p
 }
 
-// MustFindByURL is similar to [Page.FindByURL].
+// MustFindByURL is similar to [Pages.FindByURL].
 func (ps Pages) MustFindByURL(regex string) *Page {
 	p, err := ps.FindByURL(regex)
 	if err != nil {
@@ -184,6 +232,13 @@ func (p *Page) MustInfo() *proto.TargetTargetInfo {
 	return info
 }
 
+// MustFrameTree is similar to [Page.FrameTree].
+func (p *Page) MustFrameTree() *proto.PageFrameTree {
+	tree, err := p.FrameTree()
+	p.e(err)
+	return tree
+}
+
 // MustHTML is similar to [Page.HTML].
 func (p *Page) MustHTML() string {
 	html, err := p.HTML()
@@ -233,6 +288,13 @@ func (p *Page) MustNavigate(url string) *Page {
 	return p
 }
 
+// MustNavigateWithResponse is similar to [Page.NavigateWithResponse].
+func (p *Page) MustNavigateWithResponse(url string) *NavigateResponse {
+	res, err := p.NavigateWithResponse(url)
+	p.e(err)
+	return res
+}
+
 // MustReload is similar to [Page.Reload].
 func (p *Page) MustReload() *Page {
 	p.e(p.Reload())
@@ -276,7 +338,7 @@ func (p *Page) MustSetWindow(left, top, width, height int) *Page {
 	return p
 }
 
-// MustWindowMinimize is similar to [Page.WindowMinimize].
+// MustWindowMinimize is similar to [Page.SetWindow].
 func (p *Page) MustWindowMinimize() *Page {
 	p.e(p.SetWindow(&proto.BrowserBounds{
 		WindowState: proto.BrowserWindowStateMinimized,
@@ -284,7 +346,7 @@ func (p *Page) MustWindowMinimize() *Page {
 	return p
 }
 
-// MustWindowMaximize is similar to [Page.WindowMaximize].
+// MustWindowMaximize is similar to [Page.SetWindow].
 func (p *Page) MustWindowMaximize() *Page {
 	p.e(p.SetWindow(&proto.BrowserBounds{
 		WindowState: proto.BrowserWindowStateMaximized,
@@ -292,7 +354,7 @@ func (p *Page) MustWindowMaximize() *Page {
 	return p
 }
 
-// MustWindowFullscreen is similar to [Page.WindowFullscreen].
+// MustWindowFullscreen is similar to [Page.SetWindow].
 func (p *Page) MustWindowFullscreen() *Page {
 	p.e(p.SetWindow(&proto.BrowserBounds{
 		WindowState: proto.BrowserWindowStateFullscreen,
@@ -300,7 +362,7 @@ func (p *Page) MustWindowFullscreen() *Page {
 	return p
 }
 
-// MustWindowNormal is similar to [Page.WindowNormal].
+// MustWindowNormal is similar to [Page.SetWindow].
 func (p *Page) MustWindowNormal() *Page {
 	p.e(p.SetWindow(&proto.BrowserBounds{
 		WindowState: proto.BrowserWindowStateNormal,
@@ -319,12 +381,50 @@ func (p *Page) MustSetViewport(width, height int, deviceScaleFactor float64, mob
 	return p
 }
 
+// MustSetDeviceOrientation is similar to [Page.SetDeviceOrientation].
+func (p *Page) MustSetDeviceOrientation(alpha, beta, gamma float64) *Page {
+	p.e(p.SetDeviceOrientation(&proto.DeviceOrientationSetDeviceOrientationOverride{
+		Alpha: alpha,
+		Beta:  beta,
+		Gamma: gamma,
+	}))
+	return p
+}
+
+// MustSetIdleState is similar to [Page.SetIdleState].
+func (p *Page) MustSetIdleState(userActive, screenUnlocked bool) *Page {
+	p.e(p.SetIdleState(&proto.EmulationSetIdleOverride{
+		IsUserActive:     userActive,
+		IsScreenUnlocked: screenUnlocked,
+	}))
+	return p
+}
+
 // MustEmulate is similar to [Page.Emulate].
 func (p *Page) MustEmulate(device devices.Device) *Page {
 	p.e(p.Emulate(device))
 	return p
 }
 
+// MustRecordActions is similar to [Page.RecordActions].
+func (p *Page) MustRecordActions() *ActionRecorder {
+	r, err := p.RecordActions()
+	p.e(err)
+	return r
+}
+
+// MustClearEmulation is similar to [Page.ClearEmulation].
+func (p *Page) MustClearEmulation() *Page {
+	p.e(p.ClearEmulation())
+	return p
+}
+
+// MustSetTouchEmulation is similar to [Page.SetTouchEmulation].
+func (p *Page) MustSetTouchEmulation(enabled bool, maxPoints int) *Page {
+	p.e(p.SetTouchEmulation(enabled, maxPoints))
+	return p
+}
+
 // MustStopLoading is similar to [Page.StopLoading].
 func (p *Page) MustStopLoading() *Page {
 	p.e(p.StopLoading())
@@ -365,6 +465,14 @@ func (p *Page) MustScreenshot(toFile ...string) []byte {
 	return bin
 }
 
+// MustScreenshotScaled is similar to [Page.ScreenshotScaled].
+func (p *Page) MustScreenshotScaled(scale float64, toFile ...string) []byte {
+	bin, err := p.ScreenshotScaled(scale, false, nil)
+	p.e(err)
+	p.e(saveFile(saveFileTypeScreenshot, bin, toFile))
+	return bin
+}
+
 // MustCaptureDOMSnapshot is similar to [Page.CaptureDOMSnapshot].
 func (p *Page) MustCaptureDOMSnapshot() (domSnapshot *proto.DOMSnapshotCaptureSnapshotResult) {
 	domSnapshot, err := p.CaptureDOMSnapshot()
@@ -372,13 +480,13 @@ func (p *Page) MustCaptureDOMSnapshot() (domSnapshot *proto.DOMSnapshotCaptureSn
 	return domSnapshot
 }
 
-// MustTriggerFavicon is similar to [PageTriggerFavicon].
+// MustTriggerFavicon is similar to [Page.TriggerFavicon].
 func (p *Page) MustTriggerFavicon() *Page {
 	p.e(p.TriggerFavicon())
 	return p
 }
 
-// MustScreenshotFullPage is similar to [Page.ScreenshotFullPage].
+// MustScreenshotFullPage is similar to [Page.Screenshot].
 // If the toFile is "", it Page.will save output to "tmp/screenshots" folder, time as the file name.
 func (p *Page) MustScreenshotFullPage(toFile ...string) []byte {
 	bin, err := p.Screenshot(true, nil)
@@ -387,6 +495,29 @@ func (p *Page) MustScreenshotFullPage(toFile ...string) []byte {
 	return bin
 }
 
+// MustScrollScreenshot is similar to [Page.ScrollScreenshot].
+// If the toFile is "", it Page.will save output to "tmp/screenshots" folder, time as the file name.
+func (p *Page) MustScrollScreenshot(toFile ...string) []byte {
+	bin, err := p.ScrollScreenshot(nil)
+	p.e(err)
+	p.e(saveFile(saveFileTypeScreenshot, bin, toFile))
+	return bin
+}
+
+// MustScreenshotImage is similar to [Page.ScreenshotImage].
+func (p *Page) MustScreenshotImage(fullPage bool) image.Image {
+	img, err := p.ScreenshotImage(fullPage, nil)
+	p.e(err)
+	return img
+}
+
+// MustScreenshotCompare is similar to [Page.ScreenshotCompare].
+func (p *Page) MustScreenshotCompare(fullPage bool, golden string) *imgdiff.Result {
+	res, err := p.ScreenshotCompare(fullPage, golden, nil)
+	p.e(err)
+	return res
+}
+
 // MustPDF is similar to [Page.PDF].
 // If the toFile is "", it Page.will save output to "tmp/pdf" folder, time as the file name.
 func (p *Page) MustPDF(toFile ...string) []byte {
@@ -399,6 +530,41 @@ func (p *Page) MustPDF(toFile ...string) []byte {
 	return bin
 }
 
+// MustOpen is similar to [Page.Open].
+func (p *Page) MustOpen(url, features string) *Page {
+	page, err := p.Open(url, features)
+	p.e(err)
+	return page
+}
+
+// MustOpener is similar to [Page.Opener].
+func (p *Page) MustOpener() *Page {
+	page, err := p.Opener()
+	p.e(err)
+	return page
+}
+
+// MustArticle is similar to [Page.Article].
+func (p *Page) MustArticle() *Article {
+	a, err := p.Article()
+	p.e(err)
+	return a
+}
+
+// MustLinks is similar to [Page.Links].
+func (p *Page) MustLinks(sameOrigin bool) []*Link {
+	list, err := p.Links(sameOrigin)
+	p.e(err)
+	return list
+}
+
+// MustResources is similar to [Page.Resources].
+func (p *Page) MustResources() []*PageResource {
+	list, err := p.Resources()
+	p.e(err)
+	return list
+}
+
 // MustWaitOpen is similar to [Page.WaitOpen].
 func (p *Page) MustWaitOpen() (wait func() (newPage *Page)) {
 	w := p.WaitOpen()
@@ -414,6 +580,92 @@ func (p *Page) MustWaitNavigation() func() {
 	return p.WaitNavigation(proto.PageLifecycleEventNameNetworkAlmostIdle)
 }
 
+// MustWaitFrameLifecycle is similar to [Page.WaitFrameLifecycle].
+func (p *Page) MustWaitFrameLifecycle(frameID proto.PageFrameID, name proto.PageLifecycleEventName) (wait func()) {
+	return p.WaitFrameLifecycle(frameID, name)
+}
+
+// MustMeasureFrames is similar to [Page.MeasureFrames].
+func (p *Page) MustMeasureFrames(fn func()) *FrameStats {
+	stats, err := p.MeasureFrames(func() error {
+		fn()
+		return nil
+	})
+	p.e(err)
+	return stats
+}
+
+// MustShowFPSCounter is similar to [Page.ShowFPSCounter].
+func (p *Page) MustShowFPSCounter(show bool) *Page {
+	p.e(p.ShowFPSCounter(show))
+	return p
+}
+
+// MustObserveLongTasks is similar to [Page.ObserveLongTasks].
+func (p *Page) MustObserveLongTasks(threshold time.Duration, fn func(*LongTask)) (stop func()) {
+	s, err := p.ObserveLongTasks(threshold, fn)
+	p.e(err)
+	return func() { p.e(s()) }
+}
+
+// MustDismissCookieBanner is similar to [Page.DismissCookieBanner].
+func (p *Page) MustDismissCookieBanner() bool {
+	dismissed, err := p.DismissCookieBanner()
+	p.e(err)
+	return dismissed
+}
+
+// MustAutoDismissCookieBanners is similar to [Page.AutoDismissCookieBanners].
+func (p *Page) MustAutoDismissCookieBanners() (stop func()) {
+	s, err := p.AutoDismissCookieBanners()
+	p.e(err)
+	return func() { p.e(s()) }
+}
+
+// MustDetectCaptcha is similar to [Page.DetectCaptcha].
+func (p *Page) MustDetectCaptcha() *Captcha {
+	c, err := p.DetectCaptcha()
+	p.e(err)
+	return c
+}
+
+// MustOAuthLogin is similar to [Page.OAuthLogin].
+func (p *Page) MustOAuthLogin(opts OAuthLogin) *OAuthResult {
+	res, err := p.OAuthLogin(opts)
+	p.e(err)
+	return res
+}
+
+// MustEmulatePrint is similar to [Page.EmulatePrint].
+func (p *Page) MustEmulatePrint(enable bool) *Page {
+	p.e(p.EmulatePrint(enable))
+	return p
+}
+
+// MustPageBreaks is similar to [Page.PageBreaks].
+func (p *Page) MustPageBreaks(pageHeight float64, selector string) []*PageBreak {
+	list, err := p.PageBreaks(pageHeight, selector)
+	p.e(err)
+	return list
+}
+
+// MustHeapUsage is similar to [Page.HeapUsage].
+func (p *Page) MustHeapUsage() float64 {
+	size, err := p.HeapUsage()
+	p.e(err)
+	return size
+}
+
+// MustMeasureHeapGrowth is similar to [Page.MeasureHeapGrowth].
+func (p *Page) MustMeasureHeapGrowth(rounds int, fn func()) *HeapGrowth {
+	growth, err := p.MeasureHeapGrowth(rounds, func() error {
+		fn()
+		return nil
+	})
+	p.e(err)
+	return growth
+}
+
 // MustWaitRequestIdle is similar to [Page.WaitRequestIdle].
 func (p *Page) MustWaitRequestIdle(excludes ...string) (wait func()) {
 	return p.WaitRequestIdle(300*time.Millisecond, nil, excludes, nil)
@@ -488,12 +740,36 @@ func (p *Page) MustWait(js string, params ...interface{}) *Page {
 	return p
 }
 
+// MustWaitTitle is similar to [Page.WaitTitle].
+func (p *Page) MustWaitTitle(regex string) *Page {
+	p.e(p.WaitTitle(regex))
+	return p
+}
+
+// MustWaitURL is similar to [Page.WaitURL].
+func (p *Page) MustWaitURL(regex string) *Page {
+	p.e(p.WaitURL(regex))
+	return p
+}
+
 // MustWaitElementsMoreThan is similar to [Page.WaitElementsMoreThan].
 func (p *Page) MustWaitElementsMoreThan(selector string, num int) *Page {
 	p.e(p.WaitElementsMoreThan(selector, num))
 	return p
 }
 
+// MustWaitElementsLessThan is similar to [Page.WaitElementsLessThan].
+func (p *Page) MustWaitElementsLessThan(selector string, num int) *Page {
+	p.e(p.WaitElementsLessThan(selector, num))
+	return p
+}
+
+// MustWaitElementsEqual is similar to [Page.WaitElementsEqual].
+func (p *Page) MustWaitElementsEqual(selector string, num int) *Page {
+	p.e(p.WaitElementsEqual(selector, num))
+	return p
+}
+
 // MustObjectToJSON is similar to [Page.ObjectToJSON].
 func (p *Page) MustObjectToJSON(obj *proto.RuntimeRemoteObject) gson.JSON {
 	j, err := p.ObjectToJSON(obj)
@@ -501,7 +777,7 @@ func (p *Page) MustObjectToJSON(obj *proto.RuntimeRemoteObject) gson.JSON {
 	return j
 }
 
-// MustObjectsToJSON is similar to [Page.ObjectsToJSON].
+// MustObjectsToJSON is similar to [Page.ObjectToJSON].
 func (p *Page) MustObjectsToJSON(list []*proto.RuntimeRemoteObject) gson.JSON {
 	arr := []interface{}{}
 	for _, obj := range list {
@@ -526,6 +802,13 @@ func (p *Page) MustElementFromPoint(left, top int) *Element {
 	return el
 }
 
+// MustElementFromViewportPoint is similar to [Page.ElementFromViewportPoint].
+func (p *Page) MustElementFromViewportPoint(left, top int) *Element {
+	el, err := p.ElementFromViewportPoint(left, top)
+	p.e(err)
+	return el
+}
+
 // MustRelease is similar to [Page.Release].
 func (p *Page) MustRelease(obj *proto.RuntimeRemoteObject) *Page {
 	p.e(p.Release(obj))
@@ -562,6 +845,13 @@ func (p *Page) MustSearch(query string) *Element {
 	return res.First
 }
 
+// MustSearchAll is similar to [Page.SearchAll].
+func (p *Page) MustSearchAll(query string) Elements {
+	list, err := p.SearchAll(query)
+	p.e(err)
+	return list
+}
+
 // MustElement is similar to [Page.Element].
 func (p *Page) MustElement(selector string) *Element {
 	el, err := p.Element(selector)
@@ -631,7 +921,7 @@ func (rc *RaceContext) MustDo() *Element {
 	return el
 }
 
-// MustMoveTo is similar to [Mouse.Move].
+// MustMoveTo is similar to [Mouse.MoveTo].
 func (m *Mouse) MustMoveTo(x, y float64) *Mouse {
 	m.page.e(m.MoveTo(proto.NewPoint(x, y)))
 	return m
@@ -667,6 +957,21 @@ func (k *Keyboard) MustType(key ...input.Key) *Keyboard {
 	return k
 }
 
+// MustTypeDelay is similar to [Keyboard.TypeDelay].
+func (k *Keyboard) MustTypeDelay(sleeper utils.Sleeper, keys ...input.Key) *Keyboard {
+	k.page.e(k.TypeDelay(sleeper, keys...))
+	return k
+}
+
+// MustWithModifiers is similar to [Keyboard.WithModifiers].
+func (k *Keyboard) MustWithModifiers(fn func(), keys ...input.Key) *Keyboard {
+	k.page.e(k.WithModifiers(func() error {
+		fn()
+		return nil
+	}, keys...))
+	return k
+}
+
 // MustDo is similar to [KeyActions.Do].
 func (ka *KeyActions) MustDo() {
 	ka.keyboard.page.e(ka.Do())
@@ -708,6 +1013,42 @@ func (t *Touch) MustTap(x, y float64) *Touch {
 	return t
 }
 
+// MustScroll is similar to [Touch.Scroll].
+func (t *Touch) MustScroll(x, y, distanceX, distanceY float64) *Touch {
+	t.page.e(t.Scroll(x, y, distanceX, distanceY))
+	return t
+}
+
+// MustPinch is similar to [Touch.Pinch].
+func (t *Touch) MustPinch(x, y, scale float64) *Touch {
+	t.page.e(t.Pinch(x, y, scale))
+	return t
+}
+
+// MustMoveTo is similar to [Pen.MoveTo].
+func (pen *Pen) MustMoveTo(p PenPoint) *Pen {
+	pen.page.e(pen.MoveTo(p))
+	return pen
+}
+
+// MustDown is similar to [Pen.Down].
+func (pen *Pen) MustDown(p PenPoint) *Pen {
+	pen.page.e(pen.Down(p))
+	return pen
+}
+
+// MustUp is similar to [Pen.Up].
+func (pen *Pen) MustUp() *Pen {
+	pen.page.e(pen.Up())
+	return pen
+}
+
+// MustStroke is similar to [Pen.Stroke].
+func (pen *Pen) MustStroke(points ...PenPoint) *Pen {
+	pen.page.e(pen.Stroke(points...))
+	return pen
+}
+
 // WithPanic returns an element clone with the specified panic function.
 // The fail must stop the current goroutine's execution immediately, such as use [runtime.Goexit] or panic inside it.
 func (el *Element) WithPanic(fail func(interface{})) *Element {
@@ -761,12 +1102,30 @@ func (el *Element) MustClick() *Element {
 	return el
 }
 
+// MustClickAt is similar to [Element.ClickAt].
+func (el *Element) MustClickAt(x, y float64) *Element {
+	el.e(el.ClickAt(proto.InputMouseButtonLeft, 1, x, y))
+	return el
+}
+
+// MustClickAtRatio is similar to [Element.ClickAtRatio].
+func (el *Element) MustClickAtRatio(rx, ry float64) *Element {
+	el.e(el.ClickAtRatio(proto.InputMouseButtonLeft, 1, rx, ry))
+	return el
+}
+
 // MustDoubleClick is similar to [Element.Click].
 func (el *Element) MustDoubleClick() *Element {
 	el.e(el.Click(proto.InputMouseButtonLeft, 2))
 	return el
 }
 
+// MustRightClick is similar to [Element.Click].
+func (el *Element) MustRightClick() *Element {
+	el.e(el.Click(proto.InputMouseButtonRight, 1))
+	return el
+}
+
 // MustTap is similar to [Element.Tap].
 func (el *Element) MustTap() *Element {
 	el.e(el.Tap())
@@ -789,12 +1148,24 @@ func (el *Element) MustWaitInteractable() *Element {
 	return el
 }
 
+// MustWaitActionable is similar to [Element.WaitActionable].
+func (el *Element) MustWaitActionable() *Element {
+	el.e(el.WaitActionable())
+	return el
+}
+
 // MustType is similar to [Element.Type].
 func (el *Element) MustType(keys ...input.Key) *Element {
 	el.e(el.Type(keys...))
 	return el
 }
 
+// MustTypeDelay is similar to [Element.TypeDelay].
+func (el *Element) MustTypeDelay(sleeper utils.Sleeper, keys ...input.Key) *Element {
+	el.e(el.TypeDelay(sleeper, keys...))
+	return el
+}
+
 // MustKeyActions is similar to [Element.KeyActions].
 func (el *Element) MustKeyActions() *KeyActions {
 	ka, err := el.KeyActions()
@@ -820,6 +1191,12 @@ func (el *Element) MustInput(text string) *Element {
 	return el
 }
 
+// MustInputTOTP is similar to [Element.InputTOTP].
+func (el *Element) MustInputTOTP(secret string) *Element {
+	el.e(el.InputTOTP(secret))
+	return el
+}
+
 // MustInputTime is similar to [Element.Input].
 func (el *Element) MustInputTime(t time.Time) *Element {
 	el.e(el.InputTime(t))
@@ -892,6 +1269,13 @@ func (el *Element) MustText() string {
 	return s
 }
 
+// MustTable is similar to [Element.Table].
+func (el *Element) MustTable() *Table {
+	t, err := el.Table()
+	el.e(err)
+	return t
+}
+
 // MustHTML is similar to [Element.HTML].
 func (el *Element) MustHTML() string {
 	s, err := el.HTML()
@@ -930,7 +1314,7 @@ func (el *Element) MustWaitVisible() *Element {
 	return el
 }
 
-// MustWaitInvisible is similar to [Element.WaitInvisible]..
+// MustWaitInvisible is similar to [Element.WaitInvisible].
 func (el *Element) MustWaitInvisible() *Element {
 	el.e(el.WaitInvisible())
 	return el
@@ -948,6 +1332,23 @@ func (el *Element) MustWaitWritable() *Element {
 	return el
 }
 
+// MustObserveMutations is similar to [Element.ObserveMutations].
+func (el *Element) MustObserveMutations(opts *MutationOptions, fn func([]*Mutation)) (stop func()) {
+	s, err := el.ObserveMutations(opts, fn)
+	el.e(err)
+	return func() { el.e(s()) }
+}
+
+// MustWaitMutation is similar to [Element.WaitMutation].
+func (el *Element) MustWaitMutation(opts *MutationOptions) (wait func() []*Mutation) {
+	w := el.WaitMutation(opts)
+	return func() []*Mutation {
+		list, err := w()
+		el.e(err)
+		return list
+	}
+}
+
 // MustShape is similar to [Element.Shape].
 func (el *Element) MustShape() *proto.DOMGetContentQuadsResult {
 	shape, err := el.Shape()
@@ -955,6 +1356,13 @@ func (el *Element) MustShape() *proto.DOMGetContentQuadsResult {
 	return shape
 }
 
+// MustBoxModel is similar to [Element.BoxModel].
+func (el *Element) MustBoxModel() *proto.DOMBoxModel {
+	m, err := el.BoxModel()
+	el.e(err)
+	return m
+}
+
 // MustCanvasToImage is similar to [Element.CanvasToImage].
 func (el *Element) MustCanvasToImage() []byte {
 	bin, err := el.CanvasToImage("", -1)
@@ -976,6 +1384,13 @@ func (el *Element) MustBackgroundImage() []byte {
 	return bin
 }
 
+// MustScreenshotImage is similar to [Element.ScreenshotImage].
+func (el *Element) MustScreenshotImage() image.Image {
+	img, err := el.ScreenshotImage()
+	el.e(err)
+	return img
+}
+
 // MustScreenshot is similar to [Element.Screenshot].
 func (el *Element) MustScreenshot(toFile ...string) []byte {
 	bin, err := el.Screenshot(proto.PageCaptureScreenshotFormatPng, 0)
@@ -984,6 +1399,14 @@ func (el *Element) MustScreenshot(toFile ...string) []byte {
 	return bin
 }
 
+// MustScreenshotScaled is similar to [Element.ScreenshotScaled].
+func (el *Element) MustScreenshotScaled(scale float64, toFile ...string) []byte {
+	bin, err := el.ScreenshotScaled(scale, proto.PageCaptureScreenshotFormatPng, 0)
+	el.e(err)
+	el.e(saveFile(saveFileTypeScreenshot, bin, toFile))
+	return bin
+}
+
 // MustRelease is similar to [Element.Release].
 func (el *Element) MustRelease() {
 	el.e(el.Release())
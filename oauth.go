@@ -0,0 +1,114 @@
+package rod
+
+import (
+	"net/url"
+	"regexp"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// OAuthStep is an action in the login popup, see [OAuthLogin]
+type OAuthStep struct {
+	// Selector of the element, the step waits until the element appears,
+	// so the steps can span multiple pages of the popup.
+	Selector string
+
+	// Input to type into the element, if it's empty the element will be clicked.
+	Input string
+}
+
+// OAuthLogin is the options of [Page.OAuthLogin]
+type OAuthLogin struct {
+	// Trigger opens the login popup, such as clicking the "Sign in with Google" button of the page
+	Trigger func() error
+
+	// Steps to fill the credentials in the popup, such as:
+	//
+	//	[]rod.OAuthStep{
+	//		{Selector: "#email", Input: "a@b.com"},
+	//		{Selector: "#next"},
+	//		{Selector: "#password", Input: "secret"},
+	//		{Selector: "#submit"},
+	//	}
+	Steps []OAuthStep
+
+	// RedirectURL is the pattern of the url that the popup is redirected back to after the login,
+	// the doc of the pattern is the same as "proto.FetchRequestPattern.URLPattern".
+	RedirectURL string
+}
+
+// OAuthResult of [Page.OAuthLogin]
+type OAuthResult struct {
+	// URL that the popup is redirected back to
+	URL string
+
+	// Params of the query and the fragment of the URL, such as "code", "state", or "access_token"
+	Params url.Values
+
+	// Cookies of the browser context after the login
+	Cookies []*proto.NetworkCookie
+}
+
+// OAuthLogin automates a popup-based OAuth or SSO login flow. It calls the Trigger to open the popup,
+// runs the Steps in the popup, then waits for the popup to be redirected to the RedirectURL.
+func (p *Page) OAuthLogin(opts OAuthLogin) (*OAuthResult, error) {
+	reg := regexp.MustCompile(proto.PatternToReg(opts.RedirectURL))
+
+	b, cancel := p.browser.Context(p.ctx).WithCancel()
+	defer cancel()
+
+	// the popup usually closes itself right after the redirect, so its url is tracked via the target events
+	info := &proto.TargetTargetInfoChanged{}
+	waitRedirect := b.WaitEventUntil(info, func() bool {
+		return info.TargetInfo.OpenerID == p.TargetID && reg.MatchString(info.TargetInfo.URL)
+	})
+
+	wait := p.WaitOpen()
+	err := opts.Trigger()
+	if err != nil {
+		return nil, err
+	}
+	popup, err := wait()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range opts.Steps {
+		el, err := popup.Element(step.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		if step.Input == "" {
+			err = el.Click(proto.InputMouseButtonLeft, 1)
+		} else {
+			err = el.Input(step.Input)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	waitRedirect()
+	if err := b.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(info.TargetInfo.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	params := u.Query()
+	fragment, _ := url.ParseQuery(u.Fragment)
+	for k, vs := range fragment {
+		params[k] = append(params[k], vs...)
+	}
+
+	cookies, err := b.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuthResult{URL: u.String(), Params: params, Cookies: cookies}, nil
+}
@@ -0,0 +1,59 @@
+package rod_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/go-rod/rod"
+)
+
+func TestOAuthLogin(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html><button onclick="window.open('/login', '_blank', 'popup')">sign in</button></html>`)
+	s.Route("/login", ".html", `<html>
+		<input id="user">
+		<button id="next" onclick="document.getElementById('step2').hidden = false">next</button>
+		<div id="step2" hidden>
+			<input id="pass">
+			<button id="submit" onclick="
+				location = '/callback?code=' + document.getElementById('user').value + '#state=' +
+					document.getElementById('pass').value
+			">submit</button>
+		</div>
+	</html>`)
+	s.Mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "ok"})
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><script>setTimeout(() => window.close(), 100)</script></html>`))
+	})
+
+	b := g.browser.MustIncognito()
+	defer b.MustClose()
+
+	p := b.MustPage(s.URL())
+
+	res := p.MustOAuthLogin(rod.OAuthLogin{
+		Trigger: func() error { return p.MustElement("button").Click("left", 1) },
+		Steps: []rod.OAuthStep{
+			{Selector: "#user", Input: "jack"},
+			{Selector: "#next"},
+			{Selector: "#pass", Input: "secret"},
+			{Selector: "#submit"},
+		},
+		RedirectURL: s.URL("/callback*"),
+	})
+
+	g.Eq(res.URL, s.URL("/callback?code=jack#state=secret"))
+	g.Eq(res.Params.Get("code"), "jack")
+	g.Eq(res.Params.Get("state"), "secret")
+	g.Len(res.Cookies, 1)
+	g.Eq(res.Cookies[0].Name, "session")
+
+	_, err := p.OAuthLogin(rod.OAuthLogin{
+		Trigger: func() error { return errors.New("err") },
+	})
+	g.Eq(err.Error(), "err")
+}
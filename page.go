@@ -1,17 +1,30 @@
 package rod
 
 import (
+	"bytes"
 	"context"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/goccy/go-json"
+
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/devices"
+	"github.com/go-rod/rod/lib/imgdiff"
 	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
@@ -63,13 +76,18 @@ type Page struct {
 	Mouse    *Mouse
 	Keyboard *Keyboard
 	Touch    *Touch
+	Pen      *Pen
 
 	element *Element // iframe only
 
+	beginFrameControl bool // see Page.WaitRepaint
+
 	jsCtxLock   *sync.Mutex
 	jsCtxID     *proto.RuntimeRemoteObjectID // use pointer so that page clones can share the change
 	helpersLock *sync.Mutex
 	helpers     map[proto.RuntimeRemoteObjectID]map[string]proto.RuntimeRemoteObjectID
+
+	lifecycle *lifecycleEvents // see Page.EnableLifecycleEvents
 }
 
 // String interface
@@ -101,6 +119,15 @@ func (p *Page) Info() (*proto.TargetTargetInfo, error) {
 	return p.browser.pageInfo(p.TargetID)
 }
 
+// FrameTree of the page, it contains the main frame and all the nested iframes.
+func (p *Page) FrameTree() (*proto.PageFrameTree, error) {
+	res, err := proto.PageGetFrameTree{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+	return res.FrameTree, nil
+}
+
 // HTML of the page
 func (p *Page) HTML() (string, error) {
 	el, err := p.Element("html")
@@ -133,6 +160,11 @@ func (p *Page) SetCookies(cookies []*proto.NetworkCookieParam) error {
 	if cookies == nil {
 		return proto.NetworkClearBrowserCookies{}.Call(p)
 	}
+
+	err := validateCookies(cookies)
+	if err != nil {
+		return err
+	}
 	return proto.NetworkSetCookies{Cookies: cookies}.Call(p)
 }
 
@@ -168,7 +200,77 @@ func (p *Page) SetBlockedURLs(urls []string) error {
 
 // Navigate to the url. If the url is empty, "about:blank" will be used.
 // It will return immediately after the server responds the http header.
+// Use [Browser.FailOnHTTPError] to make it fail on the 4xx and 5xx status codes.
 func (p *Page) Navigate(url string) error {
+	if p.browser.failOnHTTPError {
+		_, err := p.NavigateWithResponse(url)
+		return err
+	}
+
+	_, err := p.navigate(url)
+	return err
+}
+
+// NavigateResponse is the response of the main document of a navigation
+type NavigateResponse struct {
+	*proto.NetworkResponse
+
+	// Redirects are the redirect responses in order before the final response, such as 301 and 302
+	Redirects []*proto.NetworkResponse
+}
+
+// NavigateWithResponse is similar to [Page.Navigate], but it also returns the response of the main document,
+// such as the status code, headers, and remote ip, so you can assert on them directly.
+// The NetworkResponse of the returned value is nil if the document isn't loaded from the network,
+// such as "about:blank" or the same-document navigations.
+func (p *Page) NavigateWithResponse(url string) (*NavigateResponse, error) {
+	p, cancel := p.WithCancel()
+	defer cancel()
+
+	var loaderID proto.NetworkLoaderID
+	res := &NavigateResponse{Redirects: []*proto.NetworkResponse{}}
+
+	isDoc := func(id proto.NetworkLoaderID, t proto.NetworkResourceType, frameID proto.PageFrameID) bool {
+		return id == loaderID && t == proto.NetworkResourceTypeDocument && frameID == p.FrameID
+	}
+
+	wait := p.EachEvent(func(e *proto.NetworkRequestWillBeSent) {
+		if isDoc(e.LoaderID, e.Type, e.FrameID) && e.RedirectResponse != nil {
+			res.Redirects = append(res.Redirects, e.RedirectResponse)
+		}
+	}, func(e *proto.NetworkResponseReceived) bool {
+		if isDoc(e.LoaderID, e.Type, e.FrameID) {
+			res.NetworkResponse = e.Response
+			return true
+		}
+		return false
+	}, func(e *proto.NetworkLoadingFailed) bool {
+		return proto.NetworkLoaderID(e.RequestID) == loaderID
+	}, func(e *proto.PageFrameNavigated) bool {
+		// the documents that aren't loaded from the network have no response
+		return e.Frame.ID == p.FrameID && e.Frame.LoaderID == loaderID
+	})
+
+	loaderID, err := p.navigate(url)
+	if err != nil || loaderID == "" {
+		cancel()
+		wait()
+		return res, err
+	}
+
+	wait()
+
+	if p.browser.failOnHTTPError && res.NetworkResponse != nil && res.Status >= 400 {
+		return res, &ErrHTTPStatus{Status: res.Status, URL: res.URL}
+	}
+
+	return res, p.ctx.Err()
+}
+
+func (p *Page) navigate(url string) (loaderID proto.NetworkLoaderID, err error) {
+	done := p.tryHook(nil, "navigate", url)
+	defer func() { done(err) }()
+
 	if url == "" {
 		url = "about:blank"
 	}
@@ -178,15 +280,15 @@ func (p *Page) Navigate(url string) error {
 
 	res, err := proto.PageNavigate{URL: url}.Call(p)
 	if err != nil {
-		return err
+		return "", err
 	}
 	if res.ErrorText != "" {
-		return &ErrNavigation{res.ErrorText}
+		return "", &ErrNavigation{res.ErrorText}
 	}
 
 	p.root.unsetJSCtxID()
 
-	return nil
+	return res.LoaderID, nil
 }
 
 // NavigateBack history.
@@ -273,6 +375,29 @@ func (p *Page) SetViewport(params *proto.EmulationSetDeviceMetricsOverride) erro
 	return params.Call(p)
 }
 
+// SetIdleState overrides the state that the Idle Detection API reports, such as
+//
+//	page.SetIdleState(&proto.EmulationSetIdleOverride{IsUserActive: false, IsScreenUnlocked: true})
+//
+// If params is nil, the override will be cleared.
+// The page still needs the "idleDetection" permission to use the API, check [proto.BrowserGrantPermissions].
+func (p *Page) SetIdleState(params *proto.EmulationSetIdleOverride) error {
+	if params == nil {
+		return proto.EmulationClearIdleOverride{}.Call(p)
+	}
+	return params.Call(p)
+}
+
+// SetDeviceOrientation overrides the device orientation that the "deviceorientation" event reports.
+// If params is nil, the override will be cleared.
+// To emulate the screen orientation, use the ScreenOrientation of [Page.SetViewport] or [devices.Device.Landscape].
+func (p *Page) SetDeviceOrientation(params *proto.DeviceOrientationSetDeviceOrientationOverride) error {
+	if params == nil {
+		return proto.DeviceOrientationClearDeviceOrientationOverride{}.Call(p)
+	}
+	return params.Call(p)
+}
+
 // SetDocumentContent sets the page document html content
 func (p *Page) SetDocumentContent(html string) error {
 	return proto.PageSetDocumentContent{
@@ -281,8 +406,28 @@ func (p *Page) SetDocumentContent(html string) error {
 	}.Call(p)
 }
 
-// Emulate the device, such as iPhone9. If device is devices.Clear, it will clear the override.
+// Emulate the metrics, touch, user agent, and client hints of the device together, such as:
+//
+//	page.Emulate(devices.IPad.Landscape())
+//
+// The Chrome version of the device's user agent and client hints is replaced with the one of the browser,
+// so they won't conflict with the features of the browser, see [devices.Device.WithChromeVersion].
+// If device is devices.Clear, it's the same as [Page.ClearEmulation].
 func (p *Page) Emulate(device devices.Device) error {
+	if device.IsClear() {
+		return p.ClearEmulation()
+	}
+
+	if device.UserAgentMetadata() != nil {
+		v, err := p.browser.Version()
+		if err != nil {
+			return err
+		}
+		if m := regChromeProduct.FindStringSubmatch(v.Product); m != nil {
+			device = device.WithChromeVersion(m[1])
+		}
+	}
+
 	err := p.SetViewport(device.MetricsEmulation())
 	if err != nil {
 		return err
@@ -296,13 +441,57 @@ func (p *Page) Emulate(device devices.Device) error {
 	return p.SetUserAgent(device.UserAgentEmulation())
 }
 
+var regChromeProduct = regexp.MustCompile(`Chrome/([\d.]+)`)
+
+// ClearEmulation clears the metrics and touch overrides of [Page.Emulate], including the ones of
+// [Browser.DefaultDevice], so the page uses the metrics of the browser window. The user agent is set to
+// the default one of [Page.SetUserAgent] rather than removed, so a headless browser won't expose "HeadlessChrome".
+func (p *Page) ClearEmulation() error {
+	err := p.SetViewport(nil)
+	if err != nil {
+		return err
+	}
+
+	err = devices.Clear.TouchEmulation().Call(p)
+	if err != nil {
+		return err
+	}
+
+	return p.SetUserAgent(nil)
+}
+
+// SetTouchEmulation makes the page believe it's on a touch device with maxPoints touch points, such as
+// "ontouchstart" in window and navigator.maxTouchPoints, and the [Mouse] events will be dispatched as touch events.
+// Set enabled to false to disable it.
+func (p *Page) SetTouchEmulation(enabled bool, maxPoints int) error {
+	req := proto.EmulationSetTouchEmulationEnabled{Enabled: enabled}
+	if enabled {
+		req.MaxTouchPoints = gson.Int(maxPoints)
+	}
+
+	err := req.Call(p)
+	if err != nil {
+		return err
+	}
+
+	return proto.EmulationSetEmitTouchEventsForMouse{
+		Enabled:       enabled,
+		Configuration: proto.EmulationSetEmitTouchEventsForMouseConfigurationMobile,
+	}.Call(p)
+}
+
 // StopLoading forces the page stop navigation and pending resource fetches.
 func (p *Page) StopLoading() error {
 	return proto.PageStopLoading{}.Call(p)
 }
 
 // Close tries to close page, running its beforeunload hooks, if has any.
+// If [Browser.ReusePages] is enabled, the page may be kept as a blank page for reuse instead.
 func (p *Page) Close() error {
+	if p.browser.recycleBlankPage(p) {
+		return nil
+	}
+
 	p.browser.targetsLock.Lock()
 	defer p.browser.targetsLock.Unlock()
 
@@ -358,11 +547,15 @@ func (p *Page) Close() error {
 func (p *Page) TriggerFavicon() error {
 	// check if browser whether in headless mode
 	// if not in headless mode then raise error
-	if !p.browser.isHeadless() {
+	headless, err := p.browser.isHeadless()
+	if err != nil {
+		return err
+	}
+	if !headless {
 		return errors.New("browser is no-headless")
 	}
 
-	_, err := p.Evaluate(evalHelper(js.TriggerFavicon).ByPromise())
+	_, err = p.Evaluate(EvalHelper(js.TriggerFavicon).ByPromise())
 	if err != nil {
 		return err
 	}
@@ -423,28 +616,43 @@ func (p *Page) HandleFileDialog() (func([]string) error, error) {
 
 // Screenshot captures the screenshot of current page.
 func (p *Page) Screenshot(fullPage bool, req *proto.PageCaptureScreenshot) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+	err := p.ScreenshotTo(buf, fullPage, req)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ScreenshotTo is similar to [Page.Screenshot], but it decodes the base64 image data from the browser into w
+// incrementally, so a large screenshot, such as a 4K full page one, won't be copied in memory several times.
+func (p *Page) ScreenshotTo(w io.Writer, fullPage bool, req *proto.PageCaptureScreenshot) error {
 	if req == nil {
 		req = &proto.PageCaptureScreenshot{}
 	}
 	if fullPage {
 		metrics, err := proto.PageGetLayoutMetrics{}.Call(p)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		if metrics.CSSContentSize == nil {
-			return nil, errors.New("failed to get css content size")
+		size := metrics.CSSContentSize
+		if size == nil { // browsers older than chromium 92 only have the deprecated field
+			size = metrics.ContentSize
+		}
+		if size == nil {
+			return errors.New("failed to get css content size")
 		}
 
 		oldView := proto.EmulationSetDeviceMetricsOverride{}
 		set := p.LoadState(&oldView)
 		view := oldView
-		view.Width = int(metrics.CSSContentSize.Width)
-		view.Height = int(metrics.CSSContentSize.Height)
+		view.Width = int(size.Width)
+		view.Height = int(size.Height)
 
 		err = p.SetViewport(&view)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		defer func() { // try to recover the viewport
@@ -457,11 +665,86 @@ func (p *Page) Screenshot(fullPage bool, req *proto.PageCaptureScreenshot) ([]by
 		}()
 	}
 
-	shot, err := req.Call(p)
+	res, err := p.Call(p.ctx, string(p.SessionID), req.ProtoReq(), req)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return writeBase64Field(w, res, "data")
+}
+
+// ResponseBodyTo writes the body of the response of the request to w, the request id can be got from the
+// events such as [proto.NetworkResponseReceived]. Like [Page.ScreenshotTo], a base64 body, such as an image,
+// is decoded into w incrementally.
+func (p *Page) ResponseBodyTo(w io.Writer, requestID proto.NetworkRequestID) error {
+	req := proto.NetworkGetResponseBody{RequestID: requestID}
+	res, err := p.Call(p.ctx, string(p.SessionID), req.ProtoReq(), req)
+	if err != nil {
+		return err
+	}
+
+	// the body is skipped without being copied
+	var encoding struct {
+		Base64Encoded bool `json:"base64Encoded"`
+	}
+	err = json.Unmarshal(res, &encoding)
+	if err != nil {
+		return err
+	}
+	if encoding.Base64Encoded {
+		return writeBase64Field(w, res, "body")
 	}
-	return shot.Data, nil
+
+	var body proto.NetworkGetResponseBodyResult
+	err = json.Unmarshal(res, &body)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, body.Body)
+	return err
+}
+
+// ScreenshotScaled is similar to [Page.Screenshot], but it renders the page at the device scale factor,
+// such as 2 for the retina-quality golden images. The device metrics of the page are adjusted temporarily,
+// the size of the viewport in CSS pixels doesn't change.
+func (p *Page) ScreenshotScaled(scale float64, fullPage bool, req *proto.PageCaptureScreenshot) ([]byte, error) {
+	var bin []byte
+	err := p.withScaleFactor(scale, func() (err error) {
+		bin, err = p.Screenshot(fullPage, req)
+		return
+	})
+	return bin, err
+}
+
+// withScaleFactor calls fn with the device scale factor of the page set to scale, then restores the device metrics
+func (p *Page) withScaleFactor(scale float64, fn func() error) error {
+	oldView := proto.EmulationSetDeviceMetricsOverride{}
+	set := p.LoadState(&oldView)
+	view := oldView
+
+	if !set {
+		res, err := p.eval(`() => ({ width: innerWidth, height: innerHeight })`)
+		if err != nil {
+			return err
+		}
+		view.Width = res.Value.Get("width").Int()
+		view.Height = res.Value.Get("height").Int()
+	}
+	view.DeviceScaleFactor = scale
+
+	err := p.SetViewport(&view)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if !set {
+			_ = proto.EmulationClearDeviceMetricsOverride{}.Call(p)
+			return
+		}
+		_ = p.SetViewport(&oldView)
+	}()
+
+	return fn()
 }
 
 // CaptureDOMSnapshot Returns a document snapshot, including the full DOM tree of the root node
@@ -487,6 +770,187 @@ func (p *Page) CaptureDOMSnapshot() (domSnapshot *proto.DOMSnapshotCaptureSnapsh
 	return snapshot, nil
 }
 
+// ScreenshotImage is similar to [Page.Screenshot], but it returns the decoded image,
+// so that it can be cropped, scaled, or compared without guessing the format.
+func (p *Page) ScreenshotImage(fullPage bool, req *proto.PageCaptureScreenshot) (image.Image, error) {
+	bin, err := p.Screenshot(fullPage, req)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(bin))
+	return img, err
+}
+
+// ScrollScreenshotOptions is the options for the [Page.ScrollScreenshot]
+type ScrollScreenshotOptions struct {
+	// Format (optional) of the output image, only png and jpeg are supported, default is png.
+	Format proto.PageCaptureScreenshotFormat
+
+	// Quality (optional) of the jpeg image, from range [0..100], default is 80.
+	Quality *int
+
+	// KeepFixed (optional) keeps the fixed and sticky elements in every slice.
+	// By default they are hidden after the first slice, so a fixed header only appears once on the top.
+	KeepFixed bool
+
+	// WaitPerScroll (optional) is the time to wait after each scroll, such as for the lazy loading images.
+	// Default is 300ms.
+	WaitPerScroll time.Duration
+}
+
+// ScrollScreenshot scrolls through the page from the top, captures the viewport on each scroll,
+// then stitches the slices into one tall image. Unlike the full page [Page.Screenshot] it doesn't resize
+// the viewport, so it works for the pages that exceed the texture size limit of the browser.
+// The scroll position of the page is restored after the capture.
+func (p *Page) ScrollScreenshot(opts *ScrollScreenshotOptions) ([]byte, error) {
+	if opts == nil {
+		opts = &ScrollScreenshotOptions{}
+	}
+	o := *opts
+	opts = &o
+	if opts.WaitPerScroll == 0 {
+		opts.WaitPerScroll = 300 * time.Millisecond
+	}
+
+	switch opts.Format {
+	case "", proto.PageCaptureScreenshotFormatPng, proto.PageCaptureScreenshotFormatJpeg:
+	default:
+		return nil, fmt.Errorf("unsupported format of scroll screenshot: %s", opts.Format)
+	}
+
+	res, err := p.eval(`() => ({
+		width: innerWidth, height: innerHeight,
+		scrollHeight: document.documentElement.scrollHeight,
+		x: scrollX, y: scrollY,
+	})`)
+	if err != nil {
+		return nil, err
+	}
+	size := res.Value
+	defer func() {
+		_, _ = p.eval(`(x, y) => window.scrollTo(x, y)`, size.Get("x").Num(), size.Get("y").Num())
+	}()
+
+	var canvas *image.RGBA
+	var scale float64
+
+	for y := 0; y < size.Get("scrollHeight").Int(); y += size.Get("height").Int() {
+		res, err := p.eval(`y => { window.scrollTo(0, y); return scrollY }`, y)
+		if err != nil {
+			return nil, err
+		}
+		err = utils.RandomSleeper(opts.WaitPerScroll, opts.WaitPerScroll)(p.ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		img, err := p.ScreenshotImage(false, &proto.PageCaptureScreenshot{
+			Format: proto.PageCaptureScreenshotFormatPng,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if canvas == nil {
+			scale = float64(img.Bounds().Dx()) / size.Get("width").Num()
+			canvas = image.NewRGBA(image.Rect(0, 0,
+				img.Bounds().Dx(), int(size.Get("scrollHeight").Num()*scale)))
+
+			if !opts.KeepFixed {
+				restore, err := p.hideFixed()
+				if err != nil {
+					return nil, err
+				}
+				defer restore()
+			}
+		}
+
+		top := int(res.Value.Num() * scale)
+		draw.Draw(canvas, img.Bounds().Add(image.Pt(0, top)), img, image.Point{}, draw.Src)
+
+		if res.Value.Int() < y { // the page can't scroll further
+			break
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	if opts.Format == proto.PageCaptureScreenshotFormatJpeg {
+		quality := 80
+		if opts.Quality != nil {
+			quality = *opts.Quality
+		}
+		err = jpeg.Encode(buf, canvas, &jpeg.Options{Quality: quality})
+	} else {
+		err = png.Encode(buf, canvas)
+	}
+
+	return buf.Bytes(), err
+}
+
+// hideFixed hides the fixed and sticky elements by injecting css, it returns the function to show them again.
+func (p *Page) hideFixed() (func(), error) {
+	_, err := p.eval(`() => {
+		for (const el of document.querySelectorAll('body *')) {
+			const pos = getComputedStyle(el).position
+			if (pos === 'fixed' || pos === 'sticky') el.setAttribute('data-rod-hide-fixed', '')
+		}
+		const style = document.createElement('style')
+		style.id = 'rod-hide-fixed'
+		style.textContent = '[data-rod-hide-fixed] { visibility: hidden !important; }'
+		document.head.append(style)
+	}`)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		_, _ = p.eval(`() => {
+			document.getElementById('rod-hide-fixed')?.remove()
+			for (const el of document.querySelectorAll('[data-rod-hide-fixed]')) el.removeAttribute('data-rod-hide-fixed')
+		}`)
+	}, nil
+}
+
+// ScreenshotCompare takes a png screenshot and compares it with the golden image file.
+// If the golden file doesn't exist, the screenshot will be saved as the golden file.
+// If opts is nil, the [imgdiff.DefaultOptions] will be used.
+// Use [imgdiff.Result.Ratio] to decide if the test should fail, and [imgdiff.Result.Diff] to inspect the difference.
+func (p *Page) ScreenshotCompare(fullPage bool, golden string, opts *imgdiff.Options) (*imgdiff.Result, error) {
+	bin, err := p.Screenshot(fullPage, &proto.PageCaptureScreenshot{
+		Format: proto.PageCaptureScreenshotFormatPng,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.FileExists(golden) {
+		err = utils.OutputFile(golden, bin)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := os.Open(golden)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	expected, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _, err := image.Decode(bytes.NewReader(bin))
+	if err != nil {
+		return nil, err
+	}
+
+	return imgdiff.Compare(expected, actual, opts)
+}
+
 // PDF prints page as PDF
 func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 	req.TransferMode = proto.PagePrintToPDFTransferModeReturnAsStream
@@ -498,11 +962,52 @@ func (p *Page) PDF(req *proto.PagePrintToPDF) (*StreamReader, error) {
 	return NewStreamReader(p, res.Stream), nil
 }
 
+var regPDFPlaceholder = regexp.MustCompile(`\{\{\s*(\w*)\s*\}\}`)
+
+var regPDFExternal = regexp.MustCompile(`(?i)(src\s*=\s*["']?|url\(\s*["']?)(https?:|//)`)
+
+// PDFTemplate converts the tpl to a header or footer template for [proto.PagePrintToPDF].
+// The placeholders {{date}}, {{title}}, {{url}}, {{pageNumber}}, and {{totalPages}} will be replaced
+// by the printing values. Because the browser renders the template with zero font size and won't load
+// external resources, the result is wrapped with a default font size, and an error will be returned if
+// tpl uses an unknown placeholder or an external resource. Remember to set the DisplayHeaderFooter and
+// enough margin to make the header or footer visible, such as:
+//
+//	header, _ := rod.PDFTemplate(`<span>{{title}}</span> <span>{{pageNumber}}/{{totalPages}}</span>`)
+//	page.PDF(&proto.PagePrintToPDF{DisplayHeaderFooter: true, HeaderTemplate: header, MarginTop: &margin})
+func PDFTemplate(tpl string) (string, error) {
+	if m := regPDFExternal.FindString(tpl); m != "" {
+		return "", fmt.Errorf("pdf template can't load external resources, use data uri instead: %s", m)
+	}
+
+	var err error
+	out := regPDFPlaceholder.ReplaceAllStringFunc(tpl, func(s string) string {
+		name := regPDFPlaceholder.FindStringSubmatch(s)[1]
+		switch name {
+		case "date", "title", "url", "pageNumber", "totalPages":
+			return `<span class="` + name + `"></span>`
+		}
+		if err == nil {
+			err = fmt.Errorf("unknown pdf template placeholder: %s", s)
+		}
+		return s
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return `<div style="font-size: 10px; width: 100%">` + out + `</div>`, nil
+}
+
 // GetResource content by the url. Such as image, css, html, etc.
 // Use the [proto.PageGetResourceTree] to list all the resources.
 func (p *Page) GetResource(url string) ([]byte, error) {
+	return p.getResource(p.FrameID, url)
+}
+
+func (p *Page) getResource(frameID proto.PageFrameID, url string) ([]byte, error) {
 	res, err := proto.PageGetResourceContent{
-		FrameID: p.FrameID,
+		FrameID: frameID,
 		URL:     url,
 	}.Call(p)
 	if err != nil {
@@ -522,7 +1027,166 @@ func (p *Page) GetResource(url string) ([]byte, error) {
 	return bin, nil
 }
 
-// WaitOpen waits for the next new page opened by the current one
+// Article is the main content of an article page, see [Page.Article]
+type Article struct {
+	Title  string `json:"title"`
+	Byline string `json:"byline"`
+
+	// Text of the main content
+	Text string `json:"text"`
+
+	// HTML of the main content, the scripts, navigations, forms, and other noises are removed
+	HTML string `json:"html"`
+}
+
+// Article extracts the main content of an article page in the readability style, such as for news pipelines.
+// The main content is the article or main element, or the element that directly contains the most paragraph text.
+func (p *Page) Article() (*Article, error) {
+	res, err := p.Evaluate(EvalHelper(js.Article))
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Article{}
+	err = res.Value.Unmarshal(a)
+	return a, err
+}
+
+// Link is an anchor of the page, see [Page.Links]
+type Link struct {
+	// URL is the absolute url of the href without the fragment
+	URL string
+
+	// Text of the anchor
+	Text string
+
+	// Rel is the values of the rel attribute, such as "nofollow"
+	Rel []string
+}
+
+// Links returns the http and https links of the anchors in the page, the hrefs are resolved against the
+// base url of the document. If sameOrigin is true, only the links with the same origin as the page are returned.
+func (p *Page) Links(sameOrigin bool) ([]*Link, error) {
+	res, err := p.eval(`() => ({
+		base: document.baseURI,
+		location: location.href,
+		links: Array.from(document.querySelectorAll('a[href], area[href]'), (el) => ({
+			href: el.getAttribute('href'),
+			text: (el.innerText || el.getAttribute('alt') || '').trim(),
+			rel: el.getAttribute('rel') || '',
+		})),
+	})`)
+	if err != nil {
+		return nil, err
+	}
+
+	var data struct {
+		Base     string
+		Location string
+		Links    []struct{ Href, Text, Rel string }
+	}
+	err = res.Value.Unmarshal(&data)
+	if err != nil {
+		return nil, err
+	}
+
+	base, err := url.Parse(data.Base)
+	if err != nil {
+		return nil, err
+	}
+	location, err := url.Parse(data.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*Link{}
+	for _, l := range data.Links {
+		u, err := base.Parse(strings.TrimSpace(l.Href))
+		if err != nil || !(u.Scheme == "http" || u.Scheme == "https") {
+			continue
+		}
+		if sameOrigin && !(u.Scheme == location.Scheme && strings.EqualFold(u.Host, location.Host)) {
+			continue
+		}
+
+		u.Fragment = ""
+		u.RawFragment = ""
+		u.Host = strings.ToLower(u.Host)
+
+		list = append(list, &Link{URL: u.String(), Text: l.Text, Rel: strings.Fields(strings.ToLower(l.Rel))})
+	}
+
+	return list, nil
+}
+
+// PageResource is a resource loaded by a frame, see [Page.Resources]
+type PageResource struct {
+	// FrameID of the frame that loads the resource
+	FrameID proto.PageFrameID
+
+	URL      string
+	Type     proto.NetworkResourceType
+	MIMEType string
+
+	// Content of the resource, it's decoded if the browser sends it as base64
+	Content []byte
+}
+
+// Resources returns the resources of all the frames of the page with their contents, including the documents
+// of the frames, such as the html, images, scripts and styles. The contents are read from the browser,
+// so an archiver can save the assets of the page without requesting them again.
+// The failed, canceled, or evicted resources are skipped.
+func (p *Page) Resources() ([]*PageResource, error) {
+	tree, err := proto.PageGetResourceTree{}.Call(p)
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*PageResource{}
+
+	var walk func(*proto.PageFrameResourceTree) error
+	walk = func(t *proto.PageFrameResourceTree) error {
+		resources := append([]*proto.PageFrameResource{{
+			URL:      t.Frame.URL,
+			Type:     proto.NetworkResourceTypeDocument,
+			MIMEType: t.Frame.MIMEType,
+		}}, t.Resources...)
+
+		for _, r := range resources {
+			if r.Failed || r.Canceled {
+				continue
+			}
+
+			bin, err := p.getResource(t.Frame.ID, r.URL)
+			if errors.Is(err, cdp.ErrNoResource) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			list = append(list, &PageResource{
+				FrameID:  t.Frame.ID,
+				URL:      r.URL,
+				Type:     r.Type,
+				MIMEType: r.MIMEType,
+				Content:  bin,
+			})
+		}
+
+		for _, child := range t.ChildFrames {
+			err := walk(child)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return list, walk(tree.FrameTree)
+}
+
+// WaitOpen waits for the next new page opened by the current one, such as via [Page.Open], window.open,
+// or a link or form with target="_blank", see [Page.Opener].
 func (p *Page) WaitOpen() func() (*Page, error) {
 	var targetID proto.TargetTargetID
 
@@ -539,6 +1203,33 @@ func (p *Page) WaitOpen() func() (*Page, error) {
 	}
 }
 
+// Open a new page via the window.open of the current page, so the new page has the current page as its opener.
+// The features is the same as the windowFeatures of window.open, such as "popup,width=400,height=300".
+// The "noopener" feature is not supported, because the new page can't be tracked without the opener.
+func (p *Page) Open(url, features string) (*Page, error) {
+	wait := p.WaitOpen()
+
+	_, err := p.Evaluate(Eval(`(u, f) => { window.open(u, '_blank', f) }`, url, features).ByUser())
+	if err != nil {
+		return nil, err
+	}
+
+	return wait()
+}
+
+// Opener returns the page that opened the current page, such as via [Page.Open], window.open, or a link with
+// target="_blank". It returns nil if the current page isn't opened by another page.
+func (p *Page) Opener() (*Page, error) {
+	info, err := p.Info()
+	if err != nil {
+		return nil, err
+	}
+	if info.OpenerID == "" {
+		return nil, nil
+	}
+	return p.browser.Context(p.ctx).PageFromTarget(info.OpenerID)
+}
+
 // EachEvent of the specified event types, if any callback returns true the wait function will resolve,
 // The type of each callback is (? means optional):
 //
@@ -561,13 +1252,23 @@ func (p *Page) EachEvent(callbacks ...interface{}) (wait func()) {
 // WaitEvent waits for the next event for one time. It will also load the data into the event object.
 func (p *Page) WaitEvent(e proto.Event) (wait func()) {
 	defer p.tryTrace(TraceTypeWait, "event", e.ProtoEvent())()
-	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e)
+	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e, nil)
+}
+
+// WaitEventUntil is similar to [Page.WaitEvent], but it skips the events until match returns true.
+// Each event is loaded into e before match is called, so match can check the fields of e, such as:
+//
+//	e := &proto.NetworkResponseReceived{}
+//	wait := page.WaitEventUntil(e, func() bool { return strings.HasSuffix(e.Response.URL, "/api") })
+func (p *Page) WaitEventUntil(e proto.Event, match func() bool) (wait func()) {
+	defer p.tryTrace(TraceTypeWait, "event", e.ProtoEvent())()
+	return p.browser.Context(p.ctx).waitEvent(p.SessionID, e, match)
 }
 
 // WaitNavigation wait for a page lifecycle event when navigating.
 // Usually you will wait for [proto.PageLifecycleEventNameNetworkAlmostIdle]
 func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
-	_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p)
+	restore := p.EnableLifecycleEvents()
 
 	wait := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
 		return e.Name == name
@@ -576,7 +1277,88 @@ func (p *Page) WaitNavigation(name proto.PageLifecycleEventName) func() {
 	return func() {
 		defer p.tryTrace(TraceTypeWait, "navigation", name)()
 		wait()
-		_ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(p)
+		restore()
+	}
+}
+
+// EnableLifecycleEvents enables the [proto.PageLifecycleEvent] of all the frames of the page.
+// It returns a restore function to restore the previous state, so it won't disable the events
+// that are enabled by others. The callers are counted, the events are disabled when the last one restores.
+func (p *Page) EnableLifecycleEvents() (restore func()) {
+	l := p.lifecycle
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.count == 0 {
+		// enabled by others, such as the proto.PageSetLifecycleEventsEnabled is called directly
+		state := proto.PageSetLifecycleEventsEnabled{}
+		if p.LoadState(&state) && state.Enabled {
+			return func() {}
+		}
+
+		_ = proto.PageSetLifecycleEventsEnabled{Enabled: true}.Call(p)
+	}
+	l.count++
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			l.lock.Lock()
+			defer l.lock.Unlock()
+
+			l.count--
+			if l.count == 0 {
+				_ = proto.PageSetLifecycleEventsEnabled{Enabled: false}.Call(p)
+			}
+		})
+	}
+}
+
+// lifecycleEvents counts the callers of [Page.EnableLifecycleEvents]
+type lifecycleEvents struct {
+	lock  sync.Mutex
+	count int
+}
+
+// ObserveLifecycle calls fn with the lifecycle events of all the frames of the page until stop is called.
+// Use the FrameID of the event to tell the frames apart, the main frame's is the [Page.FrameID] of the page.
+// The lifecycle of a frame restarts with the "init" event on each navigation of the frame.
+func (p *Page) ObserveLifecycle(fn func(*proto.PageLifecycleEvent)) (stop func()) {
+	restore := p.EnableLifecycleEvents()
+
+	p, cancel := p.WithCancel()
+	wait := p.EachEvent(fn)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		wait()
+	}()
+
+	return func() {
+		cancel()
+		<-done
+		restore()
+	}
+}
+
+// WaitFrameLifecycle is similar to [Page.WaitNavigation], but it only waits for the lifecycle event of
+// the frame with the frameID. If frameID is empty, the [Page.FrameID] will be used.
+func (p *Page) WaitFrameLifecycle(frameID proto.PageFrameID, name proto.PageLifecycleEventName) func() {
+	if frameID == "" {
+		frameID = p.FrameID
+	}
+
+	restore := p.EnableLifecycleEvents()
+
+	wait := p.EachEvent(func(e *proto.PageLifecycleEvent) bool {
+		return e.FrameID == frameID && e.Name == name
+	})
+
+	return func() {
+		defer p.tryTrace(TraceTypeWait, "frame lifecycle", frameID, name)()
+		wait()
+		restore()
 	}
 }
 
@@ -713,22 +1495,32 @@ func (p *Page) WaitStable(d time.Duration) error {
 
 // WaitIdle waits until the next window.requestIdleCallback is called.
 func (p *Page) WaitIdle(timeout time.Duration) (err error) {
-	_, err = p.Evaluate(evalHelper(js.WaitIdle, timeout.Milliseconds()).ByPromise())
+	_, err = p.Evaluate(EvalHelper(js.WaitIdle, timeout.Milliseconds()).ByPromise())
 	return err
 }
 
-// WaitRepaint waits until the next repaint.
+// WaitRepaint waits until the next frame is painted, so the screenshots taken after it won't capture
+// a half-rendered state of the DOM changes.
+// If the page is created with [proto.TargetCreateTarget.EnableBeginFrameControl] (the browser should be launched
+// with [launcher.Launcher.BeginFrameControl]), the frame is issued by HeadlessExperimental.beginFrame,
+// because the page won't render by itself.
 // Doc: https://developer.mozilla.org/en-US/docs/Web/API/window/requestAnimationFrame
 func (p *Page) WaitRepaint() error {
-	// we use root here because iframe doesn't trigger requestAnimationFrame
-	_, err := p.root.Eval(`() => new Promise(r => requestAnimationFrame(r))`)
+	if p.root.beginFrameControl {
+		_, err := proto.HeadlessExperimentalBeginFrame{}.Call(p.root)
+		return err
+	}
+
+	// we use root here because iframe doesn't trigger requestAnimationFrame.
+	// The callback of requestAnimationFrame runs before the paint, the task queued by it runs after the paint.
+	_, err := p.root.eval(`() => new Promise(r => requestAnimationFrame(() => setTimeout(r)))`)
 	return err
 }
 
 // WaitLoad waits for the `window.onload` event, it returns immediately if the event is already fired.
 func (p *Page) WaitLoad() error {
 	defer p.tryTrace(TraceTypeWait, "load")()
-	_, err := p.Evaluate(evalHelper(js.WaitLoad).ByPromise())
+	_, err := p.Evaluate(EvalHelper(js.WaitLoad).ByPromise())
 	return err
 }
 
@@ -736,7 +1528,7 @@ func (p *Page) WaitLoad() error {
 func (p *Page) AddScriptTag(url, content string) error {
 	hash := md5.Sum([]byte(url + content))
 	id := hex.EncodeToString(hash[:])
-	_, err := p.Evaluate(evalHelper(js.AddScriptTag, id, url, content).ByPromise())
+	_, err := p.Evaluate(EvalHelper(js.AddScriptTag, id, url, content).ByPromise())
 	return err
 }
 
@@ -744,7 +1536,7 @@ func (p *Page) AddScriptTag(url, content string) error {
 func (p *Page) AddStyleTag(url, content string) error {
 	hash := md5.Sum([]byte(url + content))
 	id := hex.EncodeToString(hash[:])
-	_, err := p.Evaluate(evalHelper(js.AddStyleTag, id, url, content).ByPromise())
+	_, err := p.Evaluate(EvalHelper(js.AddStyleTag, id, url, content).ByPromise())
 	return err
 }
 
@@ -764,7 +1556,9 @@ func (p *Page) EvalOnNewDocument(js string) (remove func() error, err error) {
 	return
 }
 
-// Wait until the js returns true
+// Wait until the js returns a truthy value, such as:
+//
+//	page.Wait(rod.Eval(`() => window.appReady`))
 func (p *Page) Wait(opts *EvalOptions) error {
 	return utils.Retry(p.ctx, p.sleeper(), func() (bool, error) {
 		res, err := p.Evaluate(opts)
@@ -772,16 +1566,89 @@ func (p *Page) Wait(opts *EvalOptions) error {
 			return true, err
 		}
 
-		return res.Value.Bool(), nil
+		return truthy(res), nil
 	})
 }
 
+// truthy checks the remote object the same way as the js boolean context does
+func truthy(obj *proto.RuntimeRemoteObject) bool {
+	switch obj.Type {
+	case proto.RuntimeRemoteObjectTypeUndefined:
+		return false
+	case proto.RuntimeRemoteObjectTypeObject:
+		return obj.Subtype != proto.RuntimeRemoteObjectSubtypeNull
+	case proto.RuntimeRemoteObjectTypeBoolean:
+		return obj.Value.Bool()
+	case proto.RuntimeRemoteObjectTypeString:
+		return obj.Value.Str() != ""
+	case proto.RuntimeRemoteObjectTypeNumber:
+		if obj.UnserializableValue != "" {
+			return obj.UnserializableValue != "NaN" && obj.UnserializableValue != "-0"
+		}
+		return obj.Value.Num() != 0
+	case proto.RuntimeRemoteObjectTypeBigint:
+		return obj.UnserializableValue != "0n"
+	}
+	return true
+}
+
+// WaitTitle waits until the title of the page matches the regex.
+func (p *Page) WaitTitle(regex string) error {
+	return p.waitInfo(regex, func(info *proto.TargetTargetInfo) string { return info.Title })
+}
+
+// WaitURL waits until the url of the page matches the regex, such as after a form submission or a redirect.
+func (p *Page) WaitURL(regex string) error {
+	return p.waitInfo(regex, func(info *proto.TargetTargetInfo) string { return info.URL })
+}
+
+// wait for the target info of the page to match via the [proto.TargetTargetInfoChanged] events,
+// the browser emits it on each title change and navigation, including the same-document ones.
+func (p *Page) waitInfo(regex string, field func(*proto.TargetTargetInfo) string) error {
+	reg, err := regexp.Compile(regex)
+	if err != nil {
+		return err
+	}
+
+	b, cancel := p.browser.Context(p.ctx).WithCancel()
+	defer cancel()
+
+	// listen before the check, so the change between them won't be missed
+	e := &proto.TargetTargetInfoChanged{}
+	wait := b.WaitEventUntil(e, func() bool {
+		return e.TargetInfo.TargetID == p.TargetID && reg.MatchString(field(e.TargetInfo))
+	})
+
+	info, err := p.Info()
+	if err != nil {
+		return err
+	}
+	if reg.MatchString(field(info)) {
+		return nil
+	}
+
+	wait()
+	return b.ctx.Err()
+}
+
 // WaitElementsMoreThan waits until there are more than num elements that match the selector.
 func (p *Page) WaitElementsMoreThan(selector string, num int) error {
 	return p.Wait(Eval(`(s, n) => document.querySelectorAll(s).length > n`, selector, num))
 }
 
-// ObjectToJSON by object id
+// WaitElementsLessThan waits until there are less than num elements that match the selector.
+func (p *Page) WaitElementsLessThan(selector string, num int) error {
+	return p.Wait(Eval(`(s, n) => document.querySelectorAll(s).length < n`, selector, num))
+}
+
+// WaitElementsEqual waits until there are exactly num elements that match the selector.
+func (p *Page) WaitElementsEqual(selector string, num int) error {
+	return p.Wait(Eval(`(s, n) => document.querySelectorAll(s).length === n`, selector, num))
+}
+
+// ObjectToJSON by object id. The object will be deep serialized, it handles the values that
+// json can't represent: dates become ISO strings, maps become objects, sets become arrays,
+// DOM nodes become descriptions like "div#app", and cyclic references become "[Circular]".
 func (p *Page) ObjectToJSON(obj *proto.RuntimeRemoteObject) (gson.JSON, error) {
 	if obj.ObjectID == "" {
 		return obj.Value, nil
@@ -789,7 +1656,7 @@ func (p *Page) ObjectToJSON(obj *proto.RuntimeRemoteObject) (gson.JSON, error) {
 
 	res, err := proto.RuntimeCallFunctionOn{
 		ObjectID:            obj.ObjectID,
-		FunctionDeclaration: `function() { return this }`,
+		FunctionDeclaration: js.Serialize.Definition,
 		ReturnByValue:       true,
 	}.Call(p)
 	if err != nil {
@@ -869,6 +1736,17 @@ func (p *Page) ElementFromPoint(x, y int) (*Element, error) {
 	})
 }
 
+// ElementFromViewportPoint is similar to [Page.ElementFromPoint], but the point is relative to the viewport,
+// such as the points of [Element.Shape] or the ones of mouse events.
+func (p *Page) ElementFromViewportPoint(x, y int) (*Element, error) {
+	scroll, err := p.root.Context(p.ctx).Evaluate(Eval(`() => ({ x: window.scrollX, y: window.scrollY })`).ByPromise())
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ElementFromPoint(x+scroll.Value.Get("x").Int(), y+scroll.Value.Get("y").Int())
+}
+
 // Release the remote object. Usually, you don't need to call it.
 // When a page is closed or reloaded, all remote objects will be released automatically.
 // It's useful if the page never closes or reloads.
@@ -877,9 +1755,15 @@ func (p *Page) Release(obj *proto.RuntimeRemoteObject) error {
 	return err
 }
 
-// Call implements the [proto.Client]
+// Call implements the [proto.Client].
+// If the session of the page is gone, it returns [ErrPageClosed] and cancels all the ongoing actions of the page.
 func (p *Page) Call(ctx context.Context, sessionID, methodName string, params interface{}) (res []byte, err error) {
-	return p.browser.Call(ctx, sessionID, methodName, params)
+	res, err = p.browser.Call(ctx, sessionID, methodName, params)
+	if sessionID == string(p.SessionID) && errors.Is(err, cdp.ErrSessionNotFound) {
+		p.sessionCancel()
+		return nil, &ErrPageClosed{err}
+	}
+	return
 }
 
 // Event of the page
@@ -911,24 +1795,18 @@ func (p *Page) Event() <-chan *Message {
 
 func (p *Page) initEvents() {
 	p.event = goob.New(p.ctx)
-	event := p.browser.Context(p.ctx).Event()
+	p.browser.sessionsLock.Lock()
+	p.browser.sessions.Store(p.SessionID, p)
+	p.browser.sessionsLock.Unlock()
 
 	go func() {
-		for msg := range event {
-			detached := proto.TargetDetachedFromTarget{}
-			destroyed := proto.TargetTargetDestroyed{}
-
-			if (msg.Load(&detached) && detached.SessionID == p.SessionID) ||
-				(msg.Load(destroyed) && destroyed.TargetID == p.TargetID) {
-				p.sessionCancel()
-				return
-			}
-
-			if msg.SessionID != p.SessionID {
-				continue
-			}
+		<-p.ctx.Done()
 
-			p.event.Publish(msg)
+		// only delete the entry of this page, the session id may be taken by a newer page
+		p.browser.sessionsLock.Lock()
+		defer p.browser.sessionsLock.Unlock()
+		if cur, ok := p.browser.sessions.Load(p.SessionID); ok && cur == p {
+			p.browser.sessions.Delete(p.SessionID)
 		}
 	}()
 }
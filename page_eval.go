@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/js"
@@ -53,7 +52,10 @@ func Eval(js string, args ...interface{}) *EvalOptions {
 	}
 }
 
-func evalHelper(fn *js.Function, args ...interface{}) *EvalOptions {
+// EvalHelper creates a [EvalOptions] to call the js helper fn with the args.
+// The fn and its dependencies are loaded to the js context once and cached, they can reference each other
+// via the "functions" object, such as "functions.element(selector)". Use [Browser.Helpers] to inject custom helpers.
+func EvalHelper(fn *js.Function, args ...interface{}) *EvalOptions {
 	return &EvalOptions{
 		ByValue: true,
 		JSArgs:  append([]interface{}{fn}, args...),
@@ -114,7 +116,15 @@ func (e *EvalOptions) formatToJSFunc() string {
 }
 
 // Eval is a shortcut for [Page.Evaluate] with AwaitPromise, ByValue set to true.
-func (p *Page) Eval(js string, args ...interface{}) (*proto.RuntimeRemoteObject, error) {
+func (p *Page) Eval(js string, args ...interface{}) (res *proto.RuntimeRemoteObject, err error) {
+	done := p.tryHook(nil, "eval", append([]interface{}{js}, args...)...)
+	defer func() { done(err) }()
+
+	return p.eval(js, args...)
+}
+
+// eval is similar to [Page.Eval] but without the action hooks, it's for the internal calls of rod
+func (p *Page) eval(js string, args ...interface{}) (*proto.RuntimeRemoteObject, error) {
 	return p.Evaluate(Eval(js, args...).ByPromise())
 }
 
@@ -132,9 +142,9 @@ func (p *Page) Evaluate(opts *EvalOptions) (res *proto.RuntimeRemoteObject, err
 			}
 
 			if backoff == nil {
-				backoff = utils.BackoffSleeper(30*time.Millisecond, 3*time.Second, nil)
-			} else {
-				_ = backoff(p.ctx)
+				backoff = DefaultEvalSleeper()
+			} else if err := backoff(p.ctx); err != nil {
+				return nil, err
 			}
 
 			p.unsetJSCtxID()
@@ -261,6 +271,14 @@ func (p *Page) ensureJSHelper(fn *js.Function) (proto.RuntimeRemoteObjectID, err
 		}
 		fnID = res.Result.ObjectID
 		p.setHelper(jsCtxID, js.Functions.Name, fnID)
+
+		// inject the custom helpers alongside the built-in ones
+		for _, h := range p.browser.helpers {
+			_, err := p.ensureJSHelper(h)
+			if err != nil {
+				return "", err
+			}
+		}
 	}
 
 	id, has := p.getHelper(jsCtxID, fn.Name)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-rod/rod"
 	"github.com/go-rod/rod/lib/cdp"
+	"github.com/go-rod/rod/lib/js"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -72,6 +73,27 @@ func TestPageEvaluateRetry(t *testing.T) {
 	g.Eq(1, page.MustEval(`() => 1`).Int())
 }
 
+func TestPageEvaluateRetrySleeper(t *testing.T) {
+	g := setup(t)
+
+	page := g.page.MustNavigate(g.blank())
+
+	old := rod.DefaultEvalSleeper
+	defer func() { rod.DefaultEvalSleeper = old }()
+	rod.DefaultEvalSleeper = func() utils.Sleeper { return utils.CountSleeper(1) }
+
+	var fail func(send StubSend) (gson.JSON, error)
+	fail = func(send StubSend) (gson.JSON, error) {
+		g.mc.stub(1, proto.RuntimeCallFunctionOn{}, fail)
+		return gson.New(nil), cdp.ErrCtxNotFound
+	}
+	g.mc.stub(1, proto.RuntimeCallFunctionOn{}, fail)
+	defer g.mc.resetCall()
+
+	_, err := page.Eval(`() => 1`)
+	g.Is(err, &utils.ErrMaxSleepCount{})
+}
+
 func TestPageUpdateJSCtxIDErr(t *testing.T) {
 	g := setup(t)
 
@@ -181,6 +203,26 @@ func TestObjectLeak(t *testing.T) {
 	})
 }
 
+func TestPageObjectToJSON(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	obj := p.MustEvaluate(rod.Eval(`() => {
+		const obj = {
+			date: new Date(0),
+			map: new Map([['a', 1]]),
+			set: new Set([1, 2]),
+			node: document.body,
+			fn() {},
+		}
+		obj.self = obj
+		return obj
+	}`).ByObject())
+
+	g.Eq(p.MustObjectToJSON(obj).JSON("", ""),
+		`{"date":"1970-01-01T00:00:00.000Z","map":{"a":1},"node":"body","self":"[Circular]","set":[1,2]}`)
+}
+
 func TestPageObjectErr(t *testing.T) {
 	g := setup(t)
 
@@ -273,6 +315,38 @@ func TestEnsureJSHelperErr(t *testing.T) {
 	g.Err(p.Elements(`button`))
 }
 
+func TestBrowserHelpers(t *testing.T) {
+	g := setup(t)
+
+	byText := &js.Function{
+		Name:         "byText",
+		Definition:   `function(s) { return [...functions.elements.call(this, '*')].find(e => e.textContent === s) }`,
+		Dependencies: []*js.Function{js.Elements},
+	}
+	tag := &js.Function{
+		Name:       "tagByText",
+		Definition: `function(s) { return functions.byText.call(this, s).tagName }`,
+	}
+
+	b := *g.browser
+	b.Helpers(byText, tag)
+
+	p := b.MustPage(g.srcFile("fixtures/click.html"))
+	defer p.MustClose()
+
+	el, err := p.ElementByJS(rod.EvalHelper(byText, "click me"))
+	g.E(err)
+	g.Eq(el.MustText(), "click me")
+	g.Eq(p.MustEvaluate(rod.EvalHelper(tag, "click me")).Value.Str(), "BUTTON")
+
+	g.Panic(func() {
+		b.Helpers(&js.Function{Name: "element"})
+	})
+
+	g.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+	g.Err(p.MustNavigate(g.blank()).Evaluate(rod.EvalHelper(tag, "")))
+}
+
 func TestEvalOptionsString(t *testing.T) {
 	g := setup(t)
 
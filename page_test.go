@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"image/jpeg"
 	"image/png"
 	"math"
 	"net/http"
@@ -11,6 +12,8 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -19,6 +22,7 @@ import (
 	"github.com/go-rod/rod/lib/cdp"
 	"github.com/go-rod/rod/lib/defaults"
 	"github.com/go-rod/rod/lib/devices"
+	"github.com/go-rod/rod/lib/pdf"
 	"github.com/go-rod/rod/lib/proto"
 	"github.com/go-rod/rod/lib/utils"
 	"github.com/ysmood/gson"
@@ -37,6 +41,20 @@ func TestGetPageURL(t *testing.T) {
 	g.Regex(`/fixtures/click-iframe.html\z`, g.page.MustInfo().URL)
 }
 
+func TestPageFrameTree(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click-iframes.html")).MustWaitLoad()
+
+	tree := p.MustFrameTree()
+	g.Eq(tree.Frame.ID, p.FrameID)
+	g.Len(tree.ChildFrames, 1)
+	g.Regex(`/fixtures/click-iframe.html\z`, tree.ChildFrames[0].Frame.URL)
+
+	g.mc.stubErr(1, proto.PageGetFrameTree{})
+	g.Err(p.FrameTree())
+}
+
 func TestSetCookies(t *testing.T) {
 	g := setup(t)
 
@@ -76,6 +94,62 @@ func TestSetCookies(t *testing.T) {
 	})
 }
 
+func TestSetCookiesAttributes(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage()
+
+	g.E(p.SetCookies([]*proto.NetworkCookieParam{{
+		Name:         "a",
+		Value:        "1",
+		URL:          "https://test.com",
+		Secure:       true,
+		SameSite:     proto.NetworkCookieSameSiteNone,
+		Priority:     proto.NetworkCookiePriorityHigh,
+		PartitionKey: "https://example.com",
+	}}))
+
+	cookies := p.MustCookies("https://test.com")
+	g.Len(cookies, 1)
+	g.Eq(cookies[0].SameSite, proto.NetworkCookieSameSiteNone)
+	g.Eq(cookies[0].Priority, proto.NetworkCookiePriorityHigh)
+	g.Eq(cookies[0].PartitionKey, "https://example.com")
+
+	// round-trip
+	p.MustSetCookies()
+	p.MustSetCookies(proto.CookiesToParams(cookies)...)
+	g.Eq(p.MustCookies("https://test.com")[0].PartitionKey, "https://example.com")
+
+	invalid := func(c *proto.NetworkCookieParam, reason string) {
+		g.Helper()
+		c.Value = "1"
+		c.URL = "https://test.com"
+		err := p.SetCookies([]*proto.NetworkCookieParam{c})
+		g.Is(err, &rod.ErrInvalidCookie{})
+		g.Has(err.Error(), reason)
+	}
+
+	invalid(&proto.NetworkCookieParam{Name: "a", SameSite: "Loose"}, "unknown SameSite Loose")
+	invalid(&proto.NetworkCookieParam{Name: "a", Priority: "Top"}, "unknown Priority Top")
+	invalid(&proto.NetworkCookieParam{Name: "a", SameSite: proto.NetworkCookieSameSiteNone}, "SameSite=None requires Secure")
+	invalid(&proto.NetworkCookieParam{Name: "a", PartitionKey: "https://a.com"}, "partitioned cookie requires Secure")
+	invalid(&proto.NetworkCookieParam{Name: "__Secure-a"}, "__Secure- prefix")
+	invalid(&proto.NetworkCookieParam{Name: "__Host-a", Secure: true, Path: "/a"}, "__Host- prefix")
+
+	g.Is(g.browser.SetCookies([]*proto.NetworkCookieParam{{Name: "__Host-a", Secure: true, Domain: "a.com"}}),
+		&rod.ErrInvalidCookie{})
+
+	// the __Host- cookies can round-trip
+	p.MustSetCookies(&proto.NetworkCookieParam{Name: "__Host-b", Value: "1", URL: "https://test.com", Secure: true, Path: "/"})
+	host := p.MustCookies("https://test.com")
+	p.MustSetCookies()
+	p.MustSetCookies(proto.CookiesToParams(host)...)
+	host = p.MustCookies("https://test.com")
+	g.Len(host, 1)
+	g.Eq(host[0].Name, "__Host-b")
+	g.Eq(host[0].Domain, "test.com")
+}
+
 func TestSetBlockedURLs(t *testing.T) {
 	g := setup(t)
 	page := g.newPage()
@@ -169,6 +243,40 @@ func TestMustWaitElementsMoreThan(t *testing.T) {
 	g.Gt(len(p.MustElements("li")), 5)
 }
 
+func TestPageWaitTitleAndURL(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html", `<html><title>a</title></html>`)
+	p := g.page.MustNavigate(s.URL())
+
+	p.MustEval(`() => setTimeout(() => { document.title = 'done 1' }, 300)`)
+	p.MustWaitTitle(`^done \d$`)
+
+	p.MustEval(`() => setTimeout(() => { location.hash = 'ok' }, 300)`)
+	p.MustWaitURL(`#ok$`)
+
+	g.Err(p.WaitTitle(`(`))
+
+	g.mc.stubErr(1, proto.TargetGetTargetInfo{})
+	g.Err(p.WaitURL(``))
+
+	// the change of another page doesn't count
+	other := g.newPage(s.URL())
+	other.MustEval(`() => setTimeout(() => { document.title = 'other' }, 100)`)
+	g.Is(p.Timeout(300*time.Millisecond).WaitTitle(`^other$`), context.DeadlineExceeded)
+}
+
+func TestMustWaitElementsLessThanAndEqual(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/wait_elements.html")).MustWaitElementsEqual("li", 6)
+	g.Len(p.MustElements("li"), 6)
+
+	p.MustEval(`() => setTimeout(() => document.querySelector('li').remove(), 300)`)
+	p.MustWaitElementsLessThan("li", 6)
+	g.Len(p.MustElements("li"), 5)
+}
+
 func TestPageCloseCancel(t *testing.T) {
 	g := setup(t)
 
@@ -267,6 +375,71 @@ func TestSetViewport(t *testing.T) {
 	g.Neq(int(317), res.Get("0").Int())
 }
 
+func TestSetDeviceOrientation(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html", `<html><script>
+		window.addEventListener('deviceorientation', e => { window.ori = [e.alpha, e.beta, e.gamma] })
+	</script></html>`)
+	page := g.newPage(s.URL()).MustWaitLoad()
+
+	page.MustSetDeviceOrientation(10, 20, 30)
+	page.MustWait(`() => window.ori && window.ori.join() === '10,20,30'`)
+
+	g.E(page.SetDeviceOrientation(nil))
+
+	g.mc.stubErr(1, proto.DeviceOrientationSetDeviceOrientationOverride{})
+	g.Err(page.SetDeviceOrientation(&proto.DeviceOrientationSetDeviceOrientationOverride{}))
+}
+
+func TestSetTouchEmulation(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.html(`<html><body style="margin: 0; height: 100vh"><script>
+		document.addEventListener('touchstart', () => { window.touched = true })
+	</script></body></html>`)).MustWaitLoad()
+
+	page.MustSetTouchEmulation(true, 5)
+	g.Eq(page.MustEval(`() => navigator.maxTouchPoints`).Int(), 5)
+
+	page.Mouse.MustMoveTo(10, 10).MustClick(proto.InputMouseButtonLeft)
+	page.MustWait(`() => window.touched`)
+
+	page.MustSetTouchEmulation(false, 0)
+	g.Eq(page.MustEval(`() => navigator.maxTouchPoints`).Int(), 0)
+
+	g.mc.stubErr(1, proto.EmulationSetTouchEmulationEnabled{})
+	g.Err(page.SetTouchEmulation(true, 1))
+
+	g.mc.stubErr(1, proto.EmulationSetEmitTouchEventsForMouse{})
+	g.Err(page.SetTouchEmulation(true, 1))
+}
+
+func TestSetIdleState(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve().Route("/", ".html", `<html></html>`)
+	page := g.newPage(s.URL())
+
+	g.E(proto.BrowserGrantPermissions{
+		Permissions: []proto.BrowserPermissionType{proto.BrowserPermissionTypeIdleDetection},
+		Origin:      s.URL(),
+	}.Call(g.browser))
+
+	page.MustSetIdleState(false, false)
+	res := page.MustEvaluate(rod.Eval(`async () => {
+		const d = new IdleDetector()
+		await d.start()
+		return [d.userState, d.screenState]
+	}`).ByPromise())
+	g.Eq(res.Value.JSON("", ""), `["idle","locked"]`)
+
+	g.E(page.SetIdleState(nil))
+
+	g.mc.stubErr(1, proto.EmulationSetIdleOverride{})
+	g.Err(page.SetIdleState(&proto.EmulationSetIdleOverride{}))
+}
+
 func TestSetDocumentContent(t *testing.T) {
 	g := setup(t)
 
@@ -349,6 +522,39 @@ func TestEmulateDevice(t *testing.T) {
 	})
 }
 
+func TestClearEmulation(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+	page.MustEmulate(devices.Pixel2)
+	g.Eq(page.MustEval(`() => navigator.userAgentData.platform`).Str(), "Android")
+	g.True(page.MustEval(`() => navigator.maxTouchPoints > 0`).Bool())
+
+	// the brands match the version of the browser rather than the one of the device's user agent
+	g.Eq(page.MustEval(`() => navigator.userAgentData.brands.find(b => b.brand === 'Chromium').version`).Str(),
+		strconv.Itoa(g.browser.MustMajorVersion()))
+
+	page.MustClearEmulation()
+	g.Eq(page.MustEval(`() => navigator.userAgent`).Str(), devices.LaptopWithMDPIScreen.UserAgent)
+	g.Eq(page.MustEval(`() => navigator.maxTouchPoints`).Int(), 0)
+
+	page.MustEmulate(devices.Pixel2).MustEmulate(devices.Clear)
+	g.Eq(page.MustEval(`() => navigator.userAgent`).Str(), devices.LaptopWithMDPIScreen.UserAgent)
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.EmulationClearDeviceMetricsOverride{})
+		page.MustClearEmulation()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.EmulationSetTouchEmulationEnabled{})
+		page.MustClearEmulation()
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.BrowserGetVersion{})
+		page.MustEmulate(devices.Pixel2)
+	})
+}
+
 func TestPageCloseErr(t *testing.T) {
 	g := setup(t)
 
@@ -385,6 +591,12 @@ func TestPageAddScriptTag(t *testing.T) {
 	g.E(p.AddScriptTag("", `let ok = 'yes'`))
 	res = p.MustEval(`() => ok`)
 	g.Eq("yes", res.String())
+
+	// the failed tag should be removed so that it can be added again
+	s := g.Serve()
+	err := p.AddScriptTag(s.URL("/not-exists.js"), "")
+	g.Has(err.Error(), "failed to load script")
+	g.Len(p.MustElements("script"), 2)
 }
 
 func TestPageAddStyleTag(t *testing.T) {
@@ -402,6 +614,11 @@ func TestPageAddStyleTag(t *testing.T) {
 	g.E(p.AddStyleTag("", "h4 { color: green; }"))
 	res = p.MustElement("h4").MustEval(`() => getComputedStyle(this).color`)
 	g.Eq("rgb(0, 128, 0)", res.String())
+
+	s := g.Serve()
+	err := p.AddStyleTag(s.URL("/not-exists.css"), "")
+	g.Has(err.Error(), "failed to load style")
+	g.Len(p.MustElements("link"), 1)
 }
 
 func TestPageWaitOpen(t *testing.T) {
@@ -417,6 +634,31 @@ func TestPageWaitOpen(t *testing.T) {
 	defer newPage.MustClose()
 
 	g.Eq("new page", newPage.MustEval("() => window.a").String())
+	g.Eq(newPage.MustOpener().TargetID, page.TargetID)
+}
+
+func TestPageOpen(t *testing.T) {
+	g := setup(t)
+
+	page := g.newPage(g.blank())
+
+	popup := page.MustOpen(g.srcFile("fixtures/click.html"), "popup,width=400,height=300")
+	defer popup.MustClose()
+
+	popup.MustElement("button")
+	g.Eq(popup.MustInfo().OpenerID, page.TargetID)
+	g.True(popup.MustEval(`() => window.opener !== null`).Bool())
+	g.Eq(popup.MustOpener().TargetID, page.TargetID)
+	g.Nil(page.MustOpener())
+
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+		page.MustOpen("", "")
+	})
+	g.Panic(func() {
+		g.mc.stubErr(1, proto.TargetGetTargetInfo{})
+		popup.MustOpener()
+	})
 }
 
 func TestPageWait(t *testing.T) {
@@ -425,6 +667,17 @@ func TestPageWait(t *testing.T) {
 	page := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
 	page.MustWait(`() => document.querySelector('button') !== null`)
 
+	// truthy values
+	page.MustWait(`() => document.querySelector('button')`)
+	page.MustWait(`(s) => s`, "ok")
+	page.MustWait(`() => Infinity`)
+	page.MustWait(`() => 1n`)
+
+	// falsy values
+	for _, js := range []string{`() => null`, `() => undefined`, `() => ''`, `() => NaN`, `() => -0`, `() => 0n`} {
+		g.Err(page.Sleeper(rod.NotFoundSleeper).Wait(rod.Eval(js)))
+	}
+
 	g.Panic(func() {
 		g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
 		page.MustWait(``)
@@ -437,6 +690,71 @@ func TestPageNavigateBlank(t *testing.T) {
 	g.page.MustNavigate("")
 }
 
+func TestPageNavigateWithResponse(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/404", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusNotFound)
+		g.E(w.Write([]byte("not found")))
+	})
+	s.Mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	s.Route("/ok", ".html", `<html>ok</html>`)
+
+	p := g.newPage()
+
+	res := p.MustNavigateWithResponse(s.URL("/404"))
+	g.Eq(res.Status, http.StatusNotFound)
+	g.Eq(res.Headers["Cache-Control"].Str(), "no-store")
+	g.Eq(res.RemoteIPAddress, "127.0.0.1")
+	g.Len(res.Redirects, 0)
+
+	res = p.MustNavigateWithResponse(s.URL("/redirect"))
+	g.Eq(res.Status, http.StatusOK)
+	g.Eq(res.URL, s.URL("/ok"))
+	g.Len(res.Redirects, 1)
+	g.Eq(res.Redirects[0].Status, http.StatusMovedPermanently)
+
+	// same-document navigation
+	res = p.MustNavigateWithResponse(s.URL("/ok#a"))
+	g.Nil(res.NetworkResponse)
+
+	res = p.MustNavigateWithResponse("about:blank")
+	g.Nil(res.NetworkResponse)
+
+	g.mc.stubErr(1, proto.PageNavigate{})
+	g.Err(p.NavigateWithResponse(s.URL("/ok")))
+}
+
+func TestPageFailOnHTTPError(t *testing.T) {
+	g := setup(t)
+
+	g.browser.FailOnHTTPError(true)
+	defer g.browser.FailOnHTTPError(false)
+
+	s := g.Serve()
+	s.Mux.HandleFunc("/500", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	s.Route("/ok", ".html", `<html>ok</html>`)
+
+	p := g.newPage()
+
+	err := p.Navigate(s.URL("/500"))
+	g.Is(err, &rod.ErrHTTPStatus{})
+	g.Eq(err.Error(), "navigation failed: http status 500: "+s.URL("/500"))
+
+	res, err := p.NavigateWithResponse(s.URL("/500"))
+	g.Is(err, &rod.ErrHTTPStatus{})
+	g.Eq(res.Status, http.StatusInternalServerError)
+
+	p.MustNavigate(s.URL("/ok"))
+	p.MustNavigate("about:blank")
+}
+
 func TestPageWaitNavigation(t *testing.T) {
 	g := setup(t)
 
@@ -594,6 +912,25 @@ func TestPageWaitEvent(t *testing.T) {
 	wait()
 }
 
+func TestPageWaitEventUntil(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+	defer p.EnableLifecycleEvents()()
+
+	count := 0
+	e := &proto.PageLifecycleEvent{}
+	wait := p.WaitEventUntil(e, func() bool {
+		count++
+		return e.Name == proto.PageLifecycleEventNameNetworkIdle
+	})
+	p.MustNavigate(g.blank())
+	wait()
+
+	g.Eq(e.Name, proto.PageLifecycleEventNameNetworkIdle)
+	g.Gt(count, 1)
+}
+
 func TestPageWaitEventParseEventOnlyOnce(t *testing.T) {
 	g := setup(t)
 
@@ -703,6 +1040,73 @@ func TestPageScreenshot(t *testing.T) {
 	})
 }
 
+func TestPageScreenshotTo(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	p.MustElement("button")
+
+	buf := bytes.NewBuffer(nil)
+	g.E(p.ScreenshotTo(buf, false, nil))
+	img, err := png.Decode(buf)
+	g.E(err)
+	g.Eq(1280, img.Bounds().Dx())
+
+	g.mc.stub(1, proto.PageCaptureScreenshot{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(map[string]int{"data": 1}), nil
+	})
+	g.Err(p.ScreenshotTo(buf, false, nil))
+
+	g.mc.stub(1, proto.PageCaptureScreenshot{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(map[string]int{}), nil
+	})
+	g.Err(p.ScreenshotTo(buf, false, nil))
+}
+
+func TestPageResponseBodyTo(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/page", ".html", `<html><body>ok<img src="/icon.png"></body></html>`)
+	s.Route("/icon.png", slash("fixtures/icon.png"))
+
+	p := g.newPage()
+
+	// the browser drops the bodies once the network domain is disabled
+	defer p.EnableDomain(&proto.NetworkEnable{})()
+
+	ids := map[string]proto.NetworkRequestID{}
+	wait := p.EachEvent(func(e *proto.NetworkResponseReceived) bool {
+		ids[e.Response.URL] = e.RequestID
+		return len(ids) == 2
+	})
+	p.MustNavigate(s.URL("/page")).MustWaitLoad()
+	wait()
+
+	// text body
+	buf := bytes.NewBuffer(nil)
+	g.E(p.ResponseBodyTo(buf, ids[s.URL("/page")]))
+	g.Has(buf.String(), "<body>ok")
+
+	// base64 body
+	buf.Reset()
+	g.E(p.ResponseBodyTo(buf, ids[s.URL("/icon.png")]))
+	g.Eq(buf.Bytes(), g.Read(slash("fixtures/icon.png")).Bytes())
+
+	g.mc.stubErr(1, proto.NetworkGetResponseBody{})
+	g.Err(p.ResponseBodyTo(buf, ids[s.URL("/page")]))
+
+	g.mc.stub(1, proto.NetworkGetResponseBody{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(map[string]int{"base64Encoded": 1}), nil
+	})
+	g.Err(p.ResponseBodyTo(buf, ids[s.URL("/page")]))
+
+	g.mc.stub(1, proto.NetworkGetResponseBody{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(map[string]int{"body": 1}), nil
+	})
+	g.Err(p.ResponseBodyTo(buf, ids[s.URL("/page")]))
+}
+
 func TestScreenshotFullPage(t *testing.T) {
 	g := setup(t)
 
@@ -741,6 +1145,80 @@ func TestScreenshotFullPage(t *testing.T) {
 		})
 		p.MustScreenshotFullPage()
 	})
+
+	// old browsers only have the deprecated contentSize
+	g.mc.stub(1, proto.PageGetLayoutMetrics{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(proto.PageGetLayoutMetricsResult{
+			ContentSize: &proto.DOMRect{Width: 300, Height: 400},
+		}), nil
+	})
+	img, err = png.Decode(bytes.NewBuffer(p.MustScreenshotFullPage()))
+	g.E(err)
+	g.Eq(img.Bounds().Dx(), 300)
+	g.Eq(img.Bounds().Dy(), 400)
+}
+
+func TestScrollScreenshot(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/scroll.html"))
+	p.MustElement("button")
+	p.MustEval(`() => {
+		let header = document.createElement('div')
+		header.style = 'position: fixed; top: 0; width: 100%; height: 50px; background: red;'
+		document.body.append(header)
+		window.scrollTo(0, 100)
+	}`)
+
+	data := p.MustScrollScreenshot()
+	img, err := png.Decode(bytes.NewBuffer(data))
+	g.E(err)
+	res := p.MustEval(`() => ({w: innerWidth, h: document.documentElement.scrollHeight, y: scrollY})`)
+	g.Eq(res.Get("w").Int(), img.Bounds().Dx())
+	g.Eq(res.Get("h").Int(), img.Bounds().Dy())
+	g.Eq(res.Get("y").Int(), 100)
+	g.Eq(p.MustElements("[data-rod-hide-fixed]").Empty(), true)
+
+	q := 50
+	data, err = p.ScrollScreenshot(&rod.ScrollScreenshotOptions{
+		Format:        proto.PageCaptureScreenshotFormatJpeg,
+		Quality:       &q,
+		KeepFixed:     true,
+		WaitPerScroll: time.Millisecond,
+	})
+	g.E(err)
+	_, err = jpeg.Decode(bytes.NewBuffer(data))
+	g.E(err)
+
+	// the options of the caller are not changed
+	opts := &rod.ScrollScreenshotOptions{}
+	_, err = p.ScrollScreenshot(opts)
+	g.E(err)
+	g.Eq(opts.WaitPerScroll, time.Duration(0))
+
+	// the wait after each scroll stops when the context is done
+	start := time.Now()
+	_, err = p.Timeout(100 * time.Millisecond).ScrollScreenshot(&rod.ScrollScreenshotOptions{WaitPerScroll: time.Hour})
+	g.Is(err, context.DeadlineExceeded)
+	g.Lt(time.Since(start), time.Minute)
+
+	// the unsupported format fails before the capture
+	start = time.Now()
+	_, err = p.ScrollScreenshot(&rod.ScrollScreenshotOptions{Format: proto.PageCaptureScreenshotFormatWebp})
+	g.Err(err)
+	g.Lt(time.Since(start), 300*time.Millisecond)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ScrollScreenshot(nil))
+
+	g.mc.stubErr(2, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ScrollScreenshot(nil))
+
+	g.mc.stubErr(1, proto.PageCaptureScreenshot{})
+	g.Err(p.ScrollScreenshot(nil))
+
+	g.mc.stubErr(3, proto.RuntimeCallFunctionOn{})
+	g.Err(p.ScrollScreenshot(nil))
 }
 
 func TestScreenshotFullPageInit(t *testing.T) {
@@ -776,6 +1254,38 @@ func TestFonts(t *testing.T) {
 	p.MustPDF("tmp", "fonts.pdf") // download the file from Github Actions Artifacts
 }
 
+func TestPageScreenshotImage(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	p.MustElement("button")
+
+	img := p.MustScreenshotImage(false)
+	g.Eq(img.Bounds().Dx(), 1280)
+
+	g.mc.stubErr(1, proto.PageCaptureScreenshot{})
+	g.Err(p.ScreenshotImage(false, nil))
+}
+
+func TestPageScreenshotCompare(t *testing.T) {
+	g := setup(t)
+
+	golden := filepath.Join("tmp", "screenshots", g.RandStr(16)+".png")
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	p.MustElement("button")
+
+	res := p.MustScreenshotCompare(false, golden)
+	g.True(g.PathExists(golden))
+	g.Eq(res.DiffPixels, 0)
+
+	p.MustElement("button").MustEval(`() => this.style.background = 'red'`)
+	res = p.MustScreenshotCompare(false, golden)
+	g.Gt(res.DiffPixels, 0)
+
+	g.mc.stubErr(1, proto.PageCaptureScreenshot{})
+	g.Err(p.ScreenshotCompare(false, golden, nil))
+}
+
 func TestPagePDF(t *testing.T) {
 	g := setup(t)
 
@@ -783,6 +1293,9 @@ func TestPagePDF(t *testing.T) {
 
 	s, err := p.PDF(&proto.PagePrintToPDF{})
 	g.E(err)
+	text, err := pdf.Text(s)
+	g.E(err)
+	g.Has(text, "click me")
 	g.Nil(s.Close())
 
 	p.MustPDF("")
@@ -793,6 +1306,31 @@ func TestPagePDF(t *testing.T) {
 	})
 }
 
+func TestPDFTemplate(t *testing.T) {
+	g := setup(t)
+
+	tpl, err := rod.PDFTemplate(`<p>{{ title }} {{pageNumber}}/{{totalPages}}</p>`)
+	g.E(err)
+	g.Eq(tpl, `<div style="font-size: 10px; width: 100%"><p><span class="title"></span> `+
+		`<span class="pageNumber"></span>/<span class="totalPages"></span></p></div>`)
+
+	_, err = rod.PDFTemplate(`{{page}}`)
+	g.Eq(err.Error(), "unknown pdf template placeholder: {{page}}")
+
+	_, err = rod.PDFTemplate(`<img src="https://a.com/logo.png">`)
+	g.Has(err.Error(), "can't load external resources")
+
+	margin := 1.0
+	p := g.page.MustNavigate(g.srcFile("fixtures/click.html"))
+	s, err := p.PDF(&proto.PagePrintToPDF{
+		DisplayHeaderFooter: true,
+		HeaderTemplate:      tpl,
+		MarginTop:           &margin,
+	})
+	g.E(err)
+	g.Nil(s.Close())
+}
+
 func TestPageNavigateNetworkErr(t *testing.T) {
 	g := setup(t)
 	p := g.newPage()
@@ -829,6 +1367,124 @@ func TestPageNavigateErr(t *testing.T) {
 	})
 }
 
+func TestPageArticle(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.html(`<html>
+		<head>
+			<title>Site - News</title>
+			<meta name="author" content="Jack">
+		</head>
+		<body>
+			<nav>home | news</nav>
+			<div class="side"><p>ad</p></div>
+			<div class="content">
+				<h1>Headline</h1>
+				<p>The first paragraph.</p>
+				<script>var a = 1</script>
+				<p>The second paragraph.</p>
+				<aside>related</aside>
+			</div>
+			<footer>copyright</footer>
+		</body>
+	</html>`))
+
+	a := p.MustArticle()
+	g.Eq(a.Title, "Headline")
+	g.Eq(a.Byline, "Jack")
+	g.Has(a.Text, "The first paragraph.")
+	g.Has(a.Text, "The second paragraph.")
+	g.False(strings.Contains(a.Text, "home | news"))
+	g.Has(a.HTML, "<p>The second paragraph.</p>")
+	g.False(strings.Contains(a.HTML, "script"))
+	g.False(strings.Contains(a.HTML, "related"))
+
+	p.MustNavigate(g.html(`<html><head><title>Title</title></head>
+		<body><article><p>text</p></article><p>other</p></body></html>`))
+	a = p.MustArticle()
+	g.Eq(a.Title, "Title")
+	g.Eq(a.Text, "text")
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Article())
+}
+
+func TestPageLinks(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/dir/page", ".html", `<html>
+		<head><base href="/base/"></head>
+		<body>
+			<a href="a#top">A</a>
+			<a href="/b" rel="NoFollow noopener"> B </a>
+			<a href="https://Other.com/c">C</a>
+			<a href="mailto:a@b.com">mail</a>
+			<a href="javascript:void(0)">js</a>
+			<map><area href="d" alt="D"></map>
+		</body>
+	</html>`)
+
+	p := g.page.MustNavigate(s.URL("/dir/page"))
+
+	links := p.MustLinks(false)
+	g.Len(links, 4)
+	g.Eq(links[0], &rod.Link{URL: s.URL("/base/a"), Text: "A", Rel: []string{}})
+	g.Eq(links[1], &rod.Link{URL: s.URL("/b"), Text: "B", Rel: []string{"nofollow", "noopener"}})
+	g.Eq(links[2].URL, "https://other.com/c")
+	g.Eq(links[3], &rod.Link{URL: s.URL("/base/d"), Text: "D", Rel: []string{}})
+
+	links = p.MustLinks(true)
+	g.Len(links, 3)
+	g.Eq(links[2].URL, s.URL("/base/d"))
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.Links(false))
+}
+
+func TestPageResources(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/resource.html"))
+	p.MustElement("img").MustWaitLoad()
+
+	list := p.MustResources()
+	g.Eq(list[0].Type, proto.NetworkResourceTypeDocument)
+	g.Has(string(list[0].Content), "icon.png")
+
+	icon := list[1]
+	g.Eq(icon.Type, proto.NetworkResourceTypeImage)
+	g.Eq(icon.MIMEType, "image/png")
+	g.Eq(icon.FrameID, p.FrameID)
+	g.Eq(len(icon.Content), 22661)
+
+	g.mc.stub(1, proto.PageGetResourceContent{}, func(send StubSend) (gson.JSON, error) {
+		return gson.New(nil), cdp.ErrNoResource
+	})
+	g.Len(p.MustResources(), 1)
+
+	g.mc.stubErr(1, proto.PageGetResourceTree{})
+	g.Err(p.Resources())
+
+	g.mc.stubErr(1, proto.PageGetResourceContent{})
+	g.Err(p.Resources())
+}
+
+func TestPageWaitRepaint(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.blank())
+	p.MustEval(`() => {
+		window.painted = false
+		requestAnimationFrame(() => window.painted = true)
+	}`)
+	g.E(p.WaitRepaint())
+	g.True(p.MustEval(`() => window.painted`).Bool())
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.WaitRepaint())
+}
+
 func TestPageWaitLoadErr(t *testing.T) {
 	g := setup(t)
 
@@ -865,6 +1521,80 @@ func TestPageNavigation(t *testing.T) {
 	g.Err(p.Reload())
 }
 
+func TestPageLifecycle(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage()
+
+	lock := sync.Mutex{}
+	frames := map[proto.PageFrameID][]proto.PageLifecycleEventName{}
+	stop := p.ObserveLifecycle(func(e *proto.PageLifecycleEvent) {
+		lock.Lock()
+		defer lock.Unlock()
+		if e.Name == proto.PageLifecycleEventNameInit {
+			frames[e.FrameID] = nil
+		}
+		frames[e.FrameID] = append(frames[e.FrameID], e.Name)
+	})
+
+	// a nested listener shouldn't disable the events of the observer
+	wait := p.WaitNavigation(proto.PageLifecycleEventNameLoad)
+	p.MustNavigate(g.srcFile("fixtures/click-iframe.html"))
+	wait()
+
+	frame := p.MustElement("iframe").MustFrame()
+	wait = p.MustWaitFrameLifecycle(frame.FrameID, proto.PageLifecycleEventNameLoad)
+	frame.MustReload()
+	wait()
+
+	wait = p.MustWaitFrameLifecycle("", proto.PageLifecycleEventNameLoad)
+	p.MustReload()
+	wait()
+
+	stop()
+
+	lock.Lock()
+	defer lock.Unlock()
+	g.Has(frames[p.FrameID], proto.PageLifecycleEventNameDOMContentLoaded)
+	g.Has(frames[p.FrameID], proto.PageLifecycleEventNameLoad)
+	g.Has(frames[frame.FrameID], proto.PageLifecycleEventNameLoad)
+
+	state := proto.PageSetLifecycleEventsEnabled{}
+	p.LoadState(&state)
+	g.False(state.Enabled)
+}
+
+func TestPageEnableLifecycleEvents(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.blank())
+
+	enabled := func() bool {
+		state := proto.PageSetLifecycleEventsEnabled{}
+		p.LoadState(&state)
+		return state.Enabled
+	}
+
+	wait := p.WaitNavigation(proto.PageLifecycleEventNameLoad)
+	var list []*proto.PageLifecycleEvent
+	stop := p.ObserveLifecycle(func(e *proto.PageLifecycleEvent) { list = append(list, e) })
+
+	p.MustNavigate(g.blank())
+	wait()
+
+	// the restore of WaitNavigation won't disable the events under the running observer
+	g.True(enabled())
+
+	restore := p.EnableLifecycleEvents()
+	restore()
+	restore()
+	g.True(enabled())
+
+	stop()
+	g.False(enabled())
+	g.Gt(len(list), 0)
+}
+
 func TestPagePool(t *testing.T) {
 	g := setup(t)
 
@@ -882,7 +1612,11 @@ func TestPageUseNonExistSession(t *testing.T) {
 
 	p := g.browser.PageFromSession("not-exist")
 	err := proto.PageClose{}.Call(p)
-	g.Eq(err, cdp.ErrSessionNotFound)
+	g.Is(err, cdp.ErrSessionNotFound)
+	g.Is(err, &rod.ErrPageClosed{})
+
+	// the ongoing actions should be canceled
+	g.Eq(p.GetContext().Err(), context.Canceled)
 }
 
 func TestPageElementFromObjectErr(t *testing.T) {
@@ -919,6 +1653,13 @@ func TestPageTriggerFavicon(t *testing.T) {
 		g.Eq(err.Error(), "browser is no-headless")
 	}
 
+	// fails to get the command line of the browser
+	{
+		page := g.newPage()
+		g.mc.stubErr(1, proto.BrowserGetBrowserCommandLine{})
+		g.Err(page.TriggerFavicon())
+	}
+
 	// test browser in headless mode to trigger favicon request
 	{
 		faviconURL := fmt.Sprintf(s.HostURL.String(), "/favicon.ico")
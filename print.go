@@ -0,0 +1,58 @@
+package rod
+
+import (
+	"errors"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+// EmulatePrint switches the css media type of the page to "print" or back to "screen",
+// so the print styles can be verified without generating a PDF.
+// The emulated media features of the page are kept.
+func (p *Page) EmulatePrint(enable bool) error {
+	media := proto.EmulationSetEmulatedMedia{}
+	p.LoadState(&media)
+
+	media.Media = ""
+	if enable {
+		media.Media = "print"
+	}
+	return media.Call(p)
+}
+
+// PageBreak is a position that the document breaks into a new page when printing, see [Page.PageBreaks]
+type PageBreak struct {
+	// Y offset of the break from the top of the document in CSS pixels
+	Y float64 `json:"y"`
+
+	// Forced is true if the break is forced by the css, such as "break-before: page"
+	Forced bool `json:"forced"`
+
+	// Split is the elements that match the selector and are split by the break, such as the orphaned table rows.
+	// Each element is described like "tr#id.class".
+	Split []string `json:"split"`
+
+	// RepeatedHeaders is the tables split by the break whose thead will be repeated on the next page
+	RepeatedHeaders []string `json:"repeatedHeaders"`
+}
+
+// PageBreaks estimates where the document breaks into pages when it's printed, the pageHeight is the height
+// of the printable area in CSS pixels, such as 1123 minus the margins for A4 paper.
+// It respects the "break-before", "break-after", and "break-inside: avoid" of the elements, the elements that
+// match the selector and are split by the breaks are reported, such as "tr, img".
+// Call [Page.EmulatePrint] and set the width of the viewport to the printable width before it.
+func (p *Page) PageBreaks(pageHeight float64, selector string) ([]*PageBreak, error) {
+	if pageHeight <= 0 {
+		return nil, errors.New("page height must be positive")
+	}
+
+	res, err := p.Evaluate(EvalHelper(js.PageBreaks, pageHeight, selector))
+	if err != nil {
+		return nil, err
+	}
+
+	list := []*PageBreak{}
+	err = res.Value.Unmarshal(&list)
+	return list, err
+}
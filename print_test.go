@@ -0,0 +1,75 @@
+package rod_test
+
+import (
+	"testing"
+
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestEmulatePrint(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.html(`<html>
+		<style>@media print { .no-print { display: none } }</style>
+		<div class="no-print">menu</div>
+	</html>`))
+
+	g.True(p.MustElement(".no-print").MustVisible())
+
+	p.MustEmulatePrint(true)
+	g.False(p.MustElement(".no-print").MustVisible())
+
+	p.MustEmulatePrint(false)
+	g.True(p.MustElement(".no-print").MustVisible())
+
+	g.mc.stubErr(1, proto.EmulationSetEmulatedMedia{})
+	g.Err(p.EmulatePrint(true))
+}
+
+func TestPageBreaks(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.html(`<html>
+		<style>
+			body { margin: 0 }
+			div, tr { height: 100px }
+			table { border-spacing: 0 }
+			td { padding: 0 }
+			.avoid { break-inside: avoid; height: 150px }
+			.forced { break-before: page }
+		</style>
+		<div id="a"></div>
+		<div class="avoid"></div>
+		<div class="forced"></div>
+		<table id="t">
+			<thead><tr><th>head</th></tr></thead>
+			<tbody>
+				<tr id="r1"><td>1</td></tr>
+				<tr id="r2"><td>2</td></tr>
+				<tr id="r3"><td>3</td></tr>
+			</tbody>
+		</table>
+	</html>`)).MustEmulatePrint(true)
+
+	list := p.MustPageBreaks(220, "tr")
+	g.Len(list, 4)
+
+	// moved to the top of the element that avoids being split
+	g.Eq(list[0].Y, 100.0)
+	g.False(list[0].Forced)
+
+	g.Eq(list[1].Y, 250.0)
+	g.True(list[1].Forced)
+
+	g.Eq(list[2].Y, 470.0)
+	g.Eq(list[2].Split, []string{"tr#r1"})
+	g.Eq(list[2].RepeatedHeaders, []string{"table#t"})
+
+	g.Eq(list[3].Y, 690.0)
+
+	_, err := p.PageBreaks(0, "tr")
+	g.Err(err)
+
+	g.mc.stubErr(1, proto.RuntimeCallFunctionOn{})
+	g.Err(p.PageBreaks(100, "tr"))
+}
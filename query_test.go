@@ -181,6 +181,17 @@ func TestSearchIframes(t *testing.T) {
 	g.True(el.MustClick().MustMatches("[a=ok]"))
 }
 
+func TestSearchAll(t *testing.T) {
+	g := setup(t)
+
+	p := g.page.MustNavigate(g.srcFile("fixtures/selector.html"))
+
+	g.Len(p.MustSearchAll("button"), 4)
+
+	g.mc.stubErr(1, proto.DOMPerformSearch{})
+	g.Err(p.SearchAll("button"))
+}
+
 func TestSearchIframesAfterReload(t *testing.T) {
 	g := setup(t)
 
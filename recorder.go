@@ -0,0 +1,388 @@
+package rod
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+// GIFRecorder captures a screenshot after each action of the browser, such as [Page.Navigate] and [Element.Click],
+// and assembles them into an animated gif or apng. It's handy to attach a short repro to a bug report.
+type GIFRecorder struct {
+	// Delay between frames, the default is 500ms
+	Delay time.Duration
+
+	// MaxWidth of the frames, larger screenshots will be scaled down. The default is 640.
+	MaxWidth int
+
+	lock   *sync.Mutex
+	frames []*image.Paletted
+	stop   func()
+}
+
+// NewGIFRecorder starts to record the actions of the browser.
+// It wraps the existing [Browser.AfterAction] hook, so the hook still works.
+// Call [GIFRecorder.Stop] to restore the hook.
+func NewGIFRecorder(b *Browser) *GIFRecorder {
+	r := &GIFRecorder{
+		Delay:    500 * time.Millisecond,
+		MaxWidth: 640,
+		lock:     &sync.Mutex{},
+	}
+
+	prev := b.afterAction
+	b.AfterAction(func(a *Action) {
+		if prev != nil {
+			prev(a)
+		}
+		r.Capture(a.Page)
+	})
+
+	r.stop = func() { b.AfterAction(prev) }
+
+	return r
+}
+
+// Stop recording the actions and restore the previous [Browser.AfterAction] hook.
+// The captured frames are kept.
+func (r *GIFRecorder) Stop() {
+	r.stop()
+}
+
+// Capture a frame of the page manually
+func (r *GIFRecorder) Capture(p *Page) {
+	if p == nil {
+		return
+	}
+
+	img, err := p.ScreenshotImage(false, nil)
+	if err != nil {
+		return
+	}
+
+	frame := r.toFrame(img)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.frames = append(r.frames, frame)
+}
+
+// Len returns the count of the captured frames
+func (r *GIFRecorder) Len() int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return len(r.frames)
+}
+
+// Encode the frames to w as an animated gif
+func (r *GIFRecorder) Encode(w io.Writer) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	g := &gif.GIF{}
+	for _, f := range r.frames {
+		g.Image = append(g.Image, f)
+		g.Delay = append(g.Delay, int(r.Delay/(10*time.Millisecond)))
+	}
+
+	return gif.EncodeAll(w, g)
+}
+
+// EncodeAPNG encodes the frames to w as an animated png, unlike gif it isn't limited to 256 colors per frame
+// and is supported by the modern browsers.
+func (r *GIFRecorder) EncodeAPNG(w io.Writer) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.frames) == 0 {
+		return errors.New("apng: must provide at least one image")
+	}
+
+	buf := bytes.NewBufferString("\x89PNG\r\n\x1a\n")
+	bounds := r.frames[0].Rect
+	seq := uint32(0)
+	delay := uint16(r.Delay / (10 * time.Millisecond))
+
+	for i, f := range r.frames {
+		if !f.Rect.In(bounds) {
+			return fmt.Errorf("apng: frame %d is larger than the first one", i)
+		}
+
+		chunks := pngChunks(f)
+
+		if i == 0 {
+			for _, c := range chunks {
+				if c.typ == "IDAT" || c.typ == "IEND" {
+					break
+				}
+				writePNGChunk(buf, c.typ, c.data)
+			}
+
+			actl := make([]byte, 8)
+			binary.BigEndian.PutUint32(actl, uint32(len(r.frames)))
+			writePNGChunk(buf, "acTL", actl)
+		}
+
+		fctl := make([]byte, 26)
+		binary.BigEndian.PutUint32(fctl, seq)
+		binary.BigEndian.PutUint32(fctl[4:], uint32(f.Rect.Dx()))
+		binary.BigEndian.PutUint32(fctl[8:], uint32(f.Rect.Dy()))
+		binary.BigEndian.PutUint16(fctl[20:], delay)
+		binary.BigEndian.PutUint16(fctl[22:], 100)
+		seq++
+		writePNGChunk(buf, "fcTL", fctl)
+
+		for _, c := range chunks {
+			if c.typ != "IDAT" {
+				continue
+			}
+			if i == 0 {
+				writePNGChunk(buf, "IDAT", c.data)
+				continue
+			}
+			fdat := make([]byte, 4, 4+len(c.data))
+			binary.BigEndian.PutUint32(fdat, seq)
+			seq++
+			writePNGChunk(buf, "fdAT", append(fdat, c.data...))
+		}
+	}
+
+	writePNGChunk(buf, "IEND", nil)
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// Save the frames to the path, it's an animated png if the extension of the path is ".png",
+// or an animated gif otherwise.
+func (r *GIFRecorder) Save(path string) error {
+	buf := bytes.NewBuffer(nil)
+
+	encode := r.Encode
+	if strings.EqualFold(filepath.Ext(path), ".png") {
+		encode = r.EncodeAPNG
+	}
+
+	err := encode(buf)
+	if err != nil {
+		return err
+	}
+
+	return utils.OutputFile(path, buf.Bytes())
+}
+
+type pngChunk struct {
+	typ  string
+	data []byte
+}
+
+// encode the img as a png and split it into chunks, the signature is skipped
+func pngChunks(img image.Image) []pngChunk {
+	buf := bytes.NewBuffer(nil)
+	utils.E(png.Encode(buf, img))
+
+	b := buf.Bytes()[8:]
+	list := []pngChunk{}
+	for len(b) >= 12 {
+		l := binary.BigEndian.Uint32(b)
+		list = append(list, pngChunk{string(b[4:8]), b[8 : 8+l]})
+		b = b[12+l:]
+	}
+	return list
+}
+
+func writePNGChunk(buf *bytes.Buffer, typ string, data []byte) {
+	b := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(b, uint32(len(data)))
+	copy(b[4:], typ)
+	b = append(b, data...)
+	b = append(b, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(b[len(b)-4:], crc32.ChecksumIEEE(b[4:len(b)-4]))
+	_, _ = buf.Write(b)
+}
+
+// scale down the img with the nearest neighbor and convert it to a paletted image
+func (r *GIFRecorder) toFrame(img image.Image) *image.Paletted {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+
+	if r.MaxWidth > 0 && w > r.MaxWidth {
+		h = h * r.MaxWidth / w
+		w = r.MaxWidth
+	}
+
+	frame := image.NewPaletted(image.Rect(0, 0, w, h), palette.Plan9)
+
+	scaled := image.NewRGBA(frame.Rect)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			scaled.Set(x, y, img.At(b.Min.X+x*b.Dx()/w, b.Min.Y+y*b.Dy()/h))
+		}
+	}
+
+	draw.FloydSteinberg.Draw(frame, frame.Rect, scaled, image.Point{})
+
+	return frame
+}
+
+// RecordedAction is a user action captured by the [ActionRecorder]
+type RecordedAction struct {
+	// Type is "navigate", "click", "input", "select", or "key"
+	Type string `json:"type"`
+
+	// Selector suggested for the target element, it prefers the id, test id, name, and aria-label of the element,
+	// then the css path from the closest ancestor with an id.
+	Selector string `json:"selector,omitempty"`
+
+	// Value is the url of "navigate", the text of "input" and "select", and the key name of "key"
+	Value string `json:"value,omitempty"`
+}
+
+// ActionRecorder captures the clicks, typing, and navigations of the user on a page, then generates
+// a Go test skeleton with the rod APIs. Use it with a headful browser, such as:
+//
+//	page := rod.New().ControlURL(launcher.New().Headless(false).MustLaunch()).MustConnect().MustPage()
+//	r := page.MustRecordActions()
+//	// operate the page manually
+//	fmt.Println(r.Code("TestLogin"))
+type ActionRecorder struct {
+	lock    *sync.Mutex
+	actions []*RecordedAction
+	stop    func() error
+}
+
+// RecordActions starts to record the actions of the user on the page, the recording survives navigations.
+// Call [ActionRecorder.Stop] to stop it.
+func (p *Page) RecordActions() (*ActionRecorder, error) {
+	r := &ActionRecorder{lock: &sync.Mutex{}}
+	name := "_" + utils.RandString(8)
+
+	stopExpose, err := p.Expose(name, func(j gson.JSON) (interface{}, error) {
+		var a RecordedAction
+		err := j.Unmarshal(&a)
+		if err == nil {
+			r.add(&a)
+		}
+		return nil, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	code := fmt.Sprintf(`(%s)("%s")`, js.RecordActions.Definition, name)
+	remove, err := p.EvalOnNewDocument(code)
+	if err != nil {
+		_ = stopExpose()
+		return nil, err
+	}
+
+	_, err = p.Evaluate(Eval(js.RecordActions.Definition, name))
+	if err != nil {
+		_ = remove()
+		_ = stopExpose()
+		return nil, err
+	}
+
+	p, cancel := p.WithCancel()
+	go p.EachEvent(func(e *proto.PageFrameNavigated) {
+		if e.Frame.ParentID == "" {
+			r.add(&RecordedAction{Type: "navigate", Value: e.Frame.URL})
+		}
+	})()
+
+	info, err := p.Info()
+	if err == nil && info.URL != "about:blank" {
+		r.add(&RecordedAction{Type: "navigate", Value: info.URL})
+	}
+
+	r.stop = func() error {
+		defer cancel()
+		err := remove()
+		if err != nil {
+			return err
+		}
+		return stopExpose()
+	}
+
+	return r, nil
+}
+
+func (r *ActionRecorder) add(a *RecordedAction) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.actions = append(r.actions, a)
+}
+
+// Stop recording
+func (r *ActionRecorder) Stop() error {
+	return r.stop()
+}
+
+// Actions returns the recorded actions so far
+func (r *ActionRecorder) Actions() []*RecordedAction {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*RecordedAction{}, r.actions...)
+}
+
+// Code generates a Go test function named name from the recorded actions.
+// The first navigation becomes the url of the page, a navigation caused by a click or a key press
+// becomes a wait for the page load, the others become [Page.MustNavigate].
+func (r *ActionRecorder) Code(name string) string {
+	actions := r.Actions()
+
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "func %s(t *testing.T) {\n", name)
+	fmt.Fprintln(b, "\tbrowser := rod.New().MustConnect()")
+	fmt.Fprintln(b, "\tdefer browser.MustClose()")
+	fmt.Fprintln(b)
+
+	start := ""
+	if len(actions) > 0 && actions[0].Type == "navigate" {
+		start = fmt.Sprintf("%q", actions[0].Value)
+		actions = actions[1:]
+	}
+	fmt.Fprintf(b, "\tpage := browser.MustPage(%s)\n", start)
+
+	prev := ""
+	for _, a := range actions {
+		switch a.Type {
+		case "navigate":
+			if prev == "click" || prev == "key" {
+				fmt.Fprintf(b, "\tpage.MustWaitLoad() // %s\n", a.Value)
+			} else {
+				fmt.Fprintf(b, "\tpage.MustNavigate(%q)\n", a.Value)
+			}
+		case "click":
+			fmt.Fprintf(b, "\tpage.MustElement(%q).MustClick()\n", a.Selector)
+		case "input":
+			fmt.Fprintf(b, "\tpage.MustElement(%q).MustInput(%q)\n", a.Selector, a.Value)
+		case "select":
+			fmt.Fprintf(b, "\tpage.MustElement(%q).MustSelect(%q)\n", a.Selector, a.Value)
+		case "key":
+			fmt.Fprintf(b, "\tpage.Keyboard.MustType(input.%s)\n", a.Value)
+		}
+		prev = a.Type
+	}
+
+	fmt.Fprintln(b, "}")
+
+	return b.String()
+}
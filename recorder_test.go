@@ -0,0 +1,99 @@
+package rod_test
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/input"
+	"github.com/go-rod/rod/lib/utils"
+)
+
+func TestGIFRecorder(t *testing.T) {
+	g := setup(t)
+
+	actions := 0
+
+	b := *g.browser
+	b.AfterAction(func(a *rod.Action) { actions++ })
+	r := rod.NewGIFRecorder(&b)
+
+	p := b.MustPage(g.srcFile("fixtures/click.html"))
+	defer p.MustClose()
+
+	p.MustElement("button").MustClick()
+	r.Capture(nil)
+
+	g.Eq(actions, 2)
+	g.Eq(r.Len(), 2)
+
+	buf := bytes.NewBuffer(nil)
+	g.E(r.Encode(buf))
+	img, err := gif.DecodeAll(buf)
+	g.E(err)
+	g.Len(img.Image, 2)
+	g.Eq(img.Image[0].Bounds().Dx(), 640)
+	g.Eq(img.Delay[0], 50)
+
+	f := filepath.Join("tmp", "gif", g.RandStr(16)+".gif")
+	g.E(r.Save(f))
+	g.True(g.PathExists(f))
+
+	f = filepath.Join("tmp", "gif", g.RandStr(16)+".png")
+	g.E(r.Save(f))
+	apng, err := png.Decode(g.Read(f))
+	g.E(err)
+	g.Eq(apng.Bounds().Dx(), 640)
+	g.Eq(bytes.Count(g.Read(f).Bytes(), []byte("fcTL")), 2)
+
+	r.Stop()
+	p.MustElement("button").MustClick()
+	g.Eq(actions, 3)
+	g.Eq(r.Len(), 2)
+
+	empty := rod.NewGIFRecorder(&b)
+	g.Err(empty.Save(f))
+	g.Err(empty.EncodeAPNG(buf))
+
+	// a frame is taller than the first one
+	p.MustSetViewport(800, 600, 1, false)
+	empty.Capture(p)
+	p.MustSetViewport(800, 1200, 1, false)
+	empty.Capture(p)
+	g.Eq(empty.EncodeAPNG(buf).Error(), "apng: frame 1 is larger than the first one")
+	empty.Stop()
+}
+
+func TestActionRecorder(t *testing.T) {
+	g := setup(t)
+
+	p := g.newPage(g.srcFile("fixtures/click.html"))
+	r := p.MustRecordActions()
+
+	p.MustElement("button").MustClick()
+	p.MustNavigate(g.srcFile("fixtures/input.html")).MustWaitLoad()
+	p.MustElement("[type=text]").MustClick()
+	p.MustInsertText("abc")
+	p.Keyboard.MustType(input.Enter)
+
+	g.E(utils.Retry(g.Context(), utils.BackoffSleeper(10*time.Millisecond, 100*time.Millisecond, nil), func() (bool, error) {
+		return len(r.Actions()) >= 5, nil
+	}))
+	g.E(r.Stop())
+
+	list := r.Actions()
+	g.Eq(list[0].Value, g.srcFile("fixtures/click.html"))
+	g.Eq(*list[1], rod.RecordedAction{Type: "click", Selector: "body > button"})
+	g.Eq(list[2].Type, "navigate")
+	g.Eq(*list[3], rod.RecordedAction{Type: "input", Selector: "body > form > input:nth-of-type(1)", Value: "abc"})
+	g.Eq(*list[4], rod.RecordedAction{Type: "key", Selector: "body > form > input:nth-of-type(1)", Value: "Enter"})
+
+	g.Has(r.Code("TestRecorded"), `page.MustElement("body > button").MustClick()`)
+	g.Has(r.Code("TestRecorded"), `page.MustNavigate("`+g.srcFile("fixtures/input.html")+`")`)
+	g.Has(r.Code("TestRecorded"), `page.MustElement("body > form > input:nth-of-type(1)").MustInput("abc")`)
+	g.Has(r.Code("TestRecorded"), `page.Keyboard.MustType(input.Enter)`)
+}
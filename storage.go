@@ -0,0 +1,199 @@
+package rod
+
+import (
+	"io/ioutil"
+	"net/url"
+
+	"github.com/goccy/go-json"
+
+	"github.com/go-rod/rod/lib/js"
+	"github.com/go-rod/rod/lib/proto"
+	"github.com/go-rod/rod/lib/utils"
+	"github.com/ysmood/gson"
+)
+
+// StorageState is the authenticated state of a browser context, such as to log in interactively once,
+// then restore the state in many headless runs:
+//
+//	state, _ := browser.StorageState("https://example.com")
+//	_ = state.Save("state.json")
+//
+// Then in other runs:
+//
+//	state, _ := rod.LoadStorageState("state.json")
+//	_ = browser.SetStorageState(state)
+type StorageState struct {
+	Cookies []*proto.NetworkCookie `json:"cookies"`
+	Origins []*OriginStorage       `json:"origins"`
+}
+
+// OriginStorage is the web storage of an origin
+type OriginStorage struct {
+	Origin         string            `json:"origin"`
+	LocalStorage   map[string]string `json:"localStorage"`
+	SessionStorage map[string]string `json:"sessionStorage"`
+
+	// IndexedDB is the databases of the origin, only the values that can be encoded as json are kept
+	IndexedDB gson.JSON `json:"indexedDB"`
+}
+
+// LoadStorageState from the json file at path
+func LoadStorageState(path string) (*StorageState, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &StorageState{}
+	return s, json.Unmarshal(b, s)
+}
+
+// Save the state as a json file to path
+func (s *StorageState) Save(path string) error {
+	return utils.OutputFile(path, s)
+}
+
+// StorageState returns the cookies of the browser context and the web storage of the origins, such as
+// "https://example.com". The storage of an origin is read from an opened page of the origin if there's one,
+// so its sessionStorage is kept, otherwise a temporary page is used and the sessionStorage will be empty.
+func (b *Browser) StorageState(origins ...string) (*StorageState, error) {
+	cookies, err := b.GetCookies()
+	if err != nil {
+		return nil, err
+	}
+
+	state := &StorageState{Cookies: cookies, Origins: []*OriginStorage{}}
+
+	for _, origin := range origins {
+		s := &OriginStorage{}
+		err := b.withOrigin(origin, func(p *Page) error {
+			res, err := p.Evaluate(EvalHelper(js.StorageState).ByPromise())
+			if err != nil {
+				return err
+			}
+			return res.Value.Unmarshal(s)
+		})
+		if err != nil {
+			return nil, err
+		}
+		state.Origins = append(state.Origins, s)
+	}
+
+	return state, nil
+}
+
+// SetStorageState restores the cookies and the localStorage and IndexedDB of each origin of the state
+// to the browser context. The existing databases with the same names will be replaced, it fails if a page of
+// the origin still holds an open connection to one of them.
+// The sessionStorage belongs to a tab, use [Page.SetSessionStorage] to restore it.
+func (b *Browser) SetStorageState(s *StorageState) error {
+	err := b.SetCookies(proto.CookiesToParams(s.Cookies))
+	if err != nil {
+		return err
+	}
+
+	for _, o := range s.Origins {
+		o := &OriginStorage{Origin: o.Origin, LocalStorage: o.LocalStorage, IndexedDB: o.IndexedDB}
+		err := b.withOrigin(o.Origin, func(p *Page) error {
+			_, err := p.Evaluate(EvalHelper(js.SetStorageState, o).ByPromise())
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetSessionStorage restores the sessionStorage of the origins of the state to the page after each navigation
+// until remove is called. The items are only set when the sessionStorage of the origin is empty.
+func (p *Page) SetSessionStorage(s *StorageState) (remove func() error, err error) {
+	items := map[string]map[string]string{}
+	for _, o := range s.Origins {
+		items[o.Origin] = o.SessionStorage
+	}
+
+	return p.EvalOnNewDocument(`(items => {
+		const list = items[location.origin]
+		if (!list || sessionStorage.length) return
+		for (const [k, v] of Object.entries(list)) sessionStorage.setItem(k, v)
+	})(` + utils.MustToJSON(items) + `)`)
+}
+
+// withOrigin calls fn with an opened page of the origin in the browser context, if there's none
+// a temporary page will be used. The document of the temporary page is hijacked to be blank,
+// so the server of the origin won't be requested.
+func (b *Browser) withOrigin(origin string, fn func(*Page) error) error {
+	p, err := b.originPage(origin)
+	if err != nil {
+		return err
+	}
+	if p != nil {
+		return fn(p)
+	}
+
+	p, err = b.Page(proto.TargetCreateTarget{})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = p.Close() }()
+
+	router := p.HijackRequests()
+	defer func() { _ = router.Stop() }()
+
+	err = router.Add("*", "", func(h *Hijack) {
+		h.Response.SetHeader("Content-Type", "text/html; charset=utf-8")
+		h.Response.SetBody("<html></html>")
+	})
+	if err != nil {
+		return err
+	}
+	go router.Run()
+
+	err = p.Navigate(origin)
+	if err != nil {
+		return err
+	}
+
+	return fn(p)
+}
+
+// originPage returns an opened page of the origin in the browser context, or nil if there's none.
+func (b *Browser) originPage(origin string) (*Page, error) {
+	res, err := proto.TargetGetTargets{}.Call(b)
+	if err != nil {
+		return nil, err
+	}
+
+	// the targets of the default browser context don't belong to any created context
+	created := map[proto.BrowserBrowserContextID]bool{}
+	if b.BrowserContextID == "" {
+		contexts, err := proto.TargetGetBrowserContexts{}.Call(b)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range contexts.BrowserContextIds {
+			created[id] = true
+		}
+	}
+
+	for _, t := range res.TargetInfos {
+		if t.Type != proto.TargetTargetInfoTypePage {
+			continue
+		}
+		if b.BrowserContextID == "" && created[t.BrowserContextID] ||
+			b.BrowserContextID != "" && t.BrowserContextID != b.BrowserContextID {
+			continue
+		}
+
+		u, err := url.Parse(t.URL)
+		if err != nil || u.Scheme+"://"+u.Host != origin {
+			continue
+		}
+
+		return b.PageFromTarget(t.TargetID)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,102 @@
+package rod_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-rod/rod"
+	"github.com/go-rod/rod/lib/proto"
+)
+
+func TestStorageState(t *testing.T) {
+	g := setup(t)
+
+	s := g.Serve()
+	s.Route("/", ".html", `<html></html>`)
+
+	path := filepath.Join("tmp", "storage-state", g.RandStr(16)+".json")
+
+	{ // log in and save the state
+		b := g.browser.MustIncognito()
+		defer b.MustClose()
+
+		p := b.MustPage(s.URL())
+		p.MustEval(`async () => {
+			document.cookie = 'token=a'
+			localStorage.setItem('user', 'jack')
+			sessionStorage.setItem('tab', '1')
+
+			const open = indexedDB.open('db', 2)
+			open.onupgradeneeded = () => open.result.createObjectStore('items', { keyPath: 'id' })
+			const db = await new Promise((r) => (open.onsuccess = () => r(open.result)))
+			const tx = db.transaction('items', 'readwrite')
+			tx.objectStore('items').put({ id: 1, name: 'x' })
+			await new Promise((r) => (tx.oncomplete = r))
+			db.close()
+		}`)
+
+		state := b.MustStorageState(s.URL())
+		g.Len(state.Cookies, 1)
+		g.Len(state.Origins, 1)
+		g.Eq(state.Origins[0].Origin, s.URL())
+		g.Eq(state.Origins[0].LocalStorage, map[string]string{"user": "jack"})
+		g.Eq(state.Origins[0].SessionStorage, map[string]string{"tab": "1"})
+		g.Eq(state.Origins[0].IndexedDB.Get("0.stores.0.values.0.name").Str(), "x")
+
+		g.E(state.Save(path))
+	}
+
+	{ // restore the state in a new context
+		state, err := rod.LoadStorageState(path)
+		g.E(err)
+
+		b := g.browser.MustIncognito()
+		defer b.MustClose()
+
+		b.MustSetStorageState(state)
+
+		p := b.MustPage()
+		remove, err := p.SetSessionStorage(state)
+		g.E(err)
+		defer func() { g.E(remove()) }()
+
+		p.MustNavigate(s.URL())
+		g.Eq(p.MustEval(`() => document.cookie`).Str(), "token=a")
+		g.Eq(p.MustEval(`() => localStorage.getItem('user')`).Str(), "jack")
+		g.Eq(p.MustEval(`() => sessionStorage.getItem('tab')`).Str(), "1")
+		item := func() string {
+			return p.MustEval(`async () => {
+				const open = indexedDB.open('db')
+				const db = await new Promise((r) => (open.onsuccess = () => r(open.result)))
+				const get = db.transaction('items').objectStore('items').get(1)
+				return (await new Promise((r) => (get.onsuccess = () => r(get.result)))).name
+			}`).Str()
+		}
+		g.Eq(item(), "x")
+
+		// the page above still holds the connection to the db
+		err = b.SetStorageState(state)
+		g.Is(err, &rod.ErrEval{})
+		g.Has(err.Error(), `indexedDB "db" is blocked by an open connection`)
+
+		// the db isn't deleted after the connection is closed
+		p.MustNavigate("about:blank").MustNavigate(s.URL())
+		g.Eq(item(), "x")
+
+		// the temporary page doesn't request the server of the origin
+		state = b.MustStorageState("http://not-exists.com")
+		g.Len(state.Origins[0].LocalStorage, 0)
+	}
+
+	_, err := rod.LoadStorageState("not-exists")
+	g.Err(err)
+
+	g.mc.stubErr(1, proto.StorageGetCookies{})
+	g.Err(g.browser.StorageState())
+
+	g.mc.stubErr(1, proto.TargetGetTargets{})
+	g.Err(g.browser.StorageState("http://a.com"))
+
+	g.mc.stubErr(1, proto.StorageSetCookies{})
+	g.Err(g.browser.SetStorageState(&rod.StorageState{}))
+}
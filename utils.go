@@ -13,6 +13,7 @@ import (
 	"reflect"
 	"regexp"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
@@ -67,6 +68,50 @@ func (msg *Message) Load(e proto.Event) bool {
 // DefaultLogger for rod
 var DefaultLogger = log.New(os.Stdout, "[rod] ", log.LstdFlags)
 
+// StructuredLogger converts the messages of the Browser.Logger and cdp.Client.Logger into key/value fields,
+// so that you can forward them to a structured logging lib, such as zap's SugaredLogger.Debugw:
+//
+//	l := rod.StructuredLogger(sugar.Debugw)
+//	browser := rod.New().Logger(l).Client(cdp.New().Logger(l).Start(ws))
+//
+// The msg will be one of "cdp request", "cdp response", "cdp event", "trace", and "log".
+func StructuredLogger(fn func(msg string, keysAndValues ...interface{})) utils.Log {
+	return func(list ...interface{}) {
+		if len(list) == 0 {
+			return
+		}
+
+		switch v := list[0].(type) {
+		case *cdp.Request:
+			fn("cdp request", "id", v.ID, "session", v.SessionID, "method", v.Method, "params", v.Params)
+		case *cdp.Response:
+			if v.Error != nil {
+				fn("cdp response", "id", v.ID, "error", v.Error)
+			} else {
+				fn("cdp response", "id", v.ID, "result", v.Result)
+			}
+		case *cdp.Event:
+			fn("cdp event", "session", v.SessionID, "method", v.Method, "params", v.Params)
+		case TraceType:
+			kv := []interface{}{"type", string(v)}
+			args := list[1:]
+			if len(args) > 0 {
+				switch target := args[len(args)-1].(type) {
+				case *Page:
+					kv = append(kv, "page", target.String())
+					args = args[:len(args)-1]
+				case *Element:
+					kv = append(kv, "element", target.String())
+					args = args[:len(args)-1]
+				}
+			}
+			fn("trace", append(kv, "args", args)...)
+		default:
+			fn("log", "args", list)
+		}
+	}
+}
+
 // DefaultSleeper generates the default sleeper for retry, it uses backoff to grow the interval.
 // The growth looks like:
 //
@@ -78,6 +123,12 @@ var DefaultSleeper = func() utils.Sleeper {
 	return utils.BackoffSleeper(100*time.Millisecond, time.Second, nil)
 }
 
+// DefaultEvalSleeper generates the sleeper to retry the js evaluation when the js context of the page
+// is not ready yet, such as during a navigation. If the sleeper returns an error the evaluation will stop.
+var DefaultEvalSleeper = func() utils.Sleeper {
+	return utils.BackoffSleeper(30*time.Millisecond, 3*time.Second, nil)
+}
+
 // PagePool to thread-safely limit the number of pages at the same time.
 // It's a common practice to use a channel to limit concurrency, it's not special for rod.
 // This helper is more like an example to use Go Channel.
@@ -156,7 +207,10 @@ func (bp BrowserPool) Cleanup(iteratee func(*Browser)) {
 	}
 }
 
-var _ io.ReadCloser = &StreamReader{}
+var (
+	_ io.ReadCloser = &StreamReader{}
+	_ io.WriterTo   = &StreamReader{}
+)
 
 // StreamReader for browser data stream
 type StreamReader struct {
@@ -177,6 +231,10 @@ func NewStreamReader(c proto.Client, h proto.IOStreamHandle) *StreamReader {
 }
 
 func (sr *StreamReader) Read(p []byte) (n int, err error) {
+	if sr.buf.Len() > 0 {
+		return sr.buf.Read(p)
+	}
+
 	res, err := proto.IORead{
 		Handle: sr.handle,
 		Offset: sr.Offset,
@@ -186,22 +244,80 @@ func (sr *StreamReader) Read(p []byte) (n int, err error) {
 	}
 
 	if !res.EOF {
-		var bin []byte
-		if res.Base64Encoded {
-			bin, err = base64.StdEncoding.DecodeString(res.Data)
-			if err != nil {
-				return 0, err
-			}
-		} else {
-			bin = []byte(res.Data)
+		_, err = io.Copy(sr.buf, chunkReader(res))
+		if err != nil {
+			return 0, err
 		}
-
-		_, _ = sr.buf.Write(bin)
 	}
 
 	return sr.buf.Read(p)
 }
 
+// WriteTo writes the rest of the stream to w chunk by chunk, the base64 chunks are decoded into w directly
+// without buffering the whole stream, such as a large PDF. The [io.Copy] uses it automatically.
+func (sr *StreamReader) WriteTo(w io.Writer) (n int64, err error) {
+	n, err = sr.buf.WriteTo(w)
+	if err != nil {
+		return
+	}
+
+	for {
+		res, err := proto.IORead{
+			Handle: sr.handle,
+			Offset: sr.Offset,
+		}.Call(sr.c)
+		if err != nil {
+			return n, err
+		}
+
+		if res.EOF {
+			return n, nil
+		}
+
+		written, err := io.Copy(w, chunkReader(res))
+		n += written
+		if err != nil {
+			return n, err
+		}
+	}
+}
+
+// writeBase64Field decodes the base64 string field of the json object into w. Unlike decoding the whole
+// object, it doesn't copy the field into a string or a byte slice first.
+func writeBase64Field(w io.Writer, obj []byte, field string) error {
+	key := []byte(`"` + field + `":"`)
+
+	start := bytes.Index(obj, key)
+	if start >= 0 {
+		data := obj[start+len(key):]
+		if end := bytes.IndexByte(data, '"'); end >= 0 {
+			_, err := io.Copy(w, base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data[:end])))
+			return err
+		}
+	}
+
+	// fallback to the standard way for the unusual json format
+	var res map[string]json.RawMessage
+	err := json.Unmarshal(obj, &res)
+	if err != nil {
+		return err
+	}
+	var bin []byte
+	err = json.Unmarshal(res[field], &bin)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bin)
+	return err
+}
+
+func chunkReader(res *proto.IOReadResult) io.Reader {
+	if res.Base64Encoded {
+		return base64.NewDecoder(base64.StdEncoding, strings.NewReader(res.Data))
+	}
+	return strings.NewReader(res.Data)
+}
+
 // Close the stream, discard any temporary backing storage.
 func (sr *StreamReader) Close() error {
 	return proto.IOClose{Handle: sr.handle}.Call(sr.c)
@@ -247,6 +363,35 @@ func genRegMatcher(includes, excludes []string) func(string) bool {
 	}
 }
 
+// validateCookies checks the attributes of the cookies that the browser requires
+func validateCookies(cookies []*proto.NetworkCookieParam) error {
+	for _, c := range cookies {
+		reason := ""
+
+		switch {
+		case c.SameSite != "" && c.SameSite != proto.NetworkCookieSameSiteStrict &&
+			c.SameSite != proto.NetworkCookieSameSiteLax && c.SameSite != proto.NetworkCookieSameSiteNone:
+			reason = "unknown SameSite " + string(c.SameSite)
+		case c.Priority != "" && c.Priority != proto.NetworkCookiePriorityLow &&
+			c.Priority != proto.NetworkCookiePriorityMedium && c.Priority != proto.NetworkCookiePriorityHigh:
+			reason = "unknown Priority " + string(c.Priority)
+		case c.SameSite == proto.NetworkCookieSameSiteNone && !c.Secure:
+			reason = "SameSite=None requires Secure"
+		case c.PartitionKey != "" && !c.Secure:
+			reason = "a partitioned cookie requires Secure"
+		case strings.HasPrefix(c.Name, "__Secure-") && !c.Secure:
+			reason = "the __Secure- prefix requires Secure"
+		case strings.HasPrefix(c.Name, "__Host-") && (!c.Secure || c.Domain != "" || (c.Path != "" && c.Path != "/")):
+			reason = `the __Host- prefix requires Secure, Path "/", and no Domain`
+		}
+
+		if reason != "" {
+			return &ErrInvalidCookie{c, reason}
+		}
+	}
+	return nil
+}
+
 type saveFileType int
 
 const (